@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 )
 
@@ -26,27 +27,35 @@ func (acl Acl) Compare(obj interface{}) bool {
 }
 
 func (c *Client) CreateAcl(acl Acl) (*Acl, error) {
+	return c.CreateAclContext(context.Background(), acl)
+}
+
+func (c *Client) CreateAclContext(ctx context.Context, acl Acl) (*Acl, error) {
 	var success bool
 	params := map[string]interface{}{
 		"subject": acl.Subject,
 		"object":  acl.Object,
 		"action":  acl.Action,
 	}
-	err := c.Call("acl.add", params, &success)
+	err := c.CallContext(ctx, "acl.add", params, &success)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return c.GetAcl(acl)
+	return c.GetAclContext(ctx, acl)
 }
 
 func (c *Client) GetAcls() ([]Acl, error) {
+	return c.GetAclsContext(context.Background())
+}
+
+func (c *Client) GetAclsContext(ctx context.Context) ([]Acl, error) {
 	params := map[string]interface{}{
 		"dummy": "dummy",
 	}
 	acls := []Acl{}
-	err := c.Call("acl.get", params, &acls)
+	err := c.CallContext(ctx, "acl.get", params, &acls)
 
 	if err != nil {
 		return nil, err
@@ -56,7 +65,11 @@ func (c *Client) GetAcls() ([]Acl, error) {
 }
 
 func (c *Client) GetAcl(aclReq Acl) (*Acl, error) {
-	acls, err := c.GetAcls()
+	return c.GetAclContext(context.Background(), aclReq)
+}
+
+func (c *Client) GetAclContext(ctx context.Context, aclReq Acl) (*Acl, error) {
+	acls, err := c.GetAclsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -76,10 +89,14 @@ func (c *Client) GetAcl(aclReq Acl) (*Acl, error) {
 }
 
 func (c *Client) DeleteAcl(acl Acl) error {
+	return c.DeleteAclContext(context.Background(), acl)
+}
+
+func (c *Client) DeleteAclContext(ctx context.Context, acl Acl) error {
 	var err error
 	var aclRef *Acl
 	if getAclById(acl) {
-		aclRef, err = c.GetAcl(acl)
+		aclRef, err = c.GetAclContext(ctx, acl)
 		if err != nil {
 			return err
 		}
@@ -91,7 +108,7 @@ func (c *Client) DeleteAcl(acl Acl) error {
 		"object":  acl.Object,
 		"action":  acl.Action,
 	}
-	err = c.Call("acl.remove", params, &success)
+	err = c.CallContext(ctx, "acl.remove", params, &success)
 
 	if err != nil {
 		return err
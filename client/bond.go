@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -36,7 +37,11 @@ func (b Bond) Compare(obj interface{}) bool {
 }
 
 func (c *Client) GetBond(bondReq Bond) (*Bond, error) {
-	obj, err := c.FindFromGetAllObjects(bondReq)
+	return c.GetBondContext(context.Background(), bondReq)
+}
+
+func (c *Client) GetBondContext(ctx context.Context, bondReq Bond) (*Bond, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, bondReq)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +53,11 @@ func (c *Client) GetBond(bondReq Bond) (*Bond, error) {
 }
 
 func (c *Client) GetBonds(bondReq Bond) ([]Bond, error) {
-	obj, err := c.FindFromGetAllObjects(bondReq)
+	return c.GetBondsContext(context.Background(), bondReq)
+}
+
+func (c *Client) GetBondsContext(ctx context.Context, bondReq Bond) ([]Bond, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, bondReq)
 	if err != nil {
 		return nil, err
 	}
@@ -20,20 +20,14 @@ import (
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/sourcegraph/jsonrpc2"
 	"github.com/sourcegraph/jsonrpc2/websocket"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/redact"
 )
 
-// sanitizeParams removes or obfuscates sensitive information from the parameters
+// sanitizeParams removes the credentials from the parameters, with the same
+// rules as the logs of the v2 SDK.
 func sanitizeParams(params interface{}) map[string]interface{} {
-	sanitizedParams := make(map[string]interface{})
-	for k, v := range params.(map[string]interface{}) {
-		switch k {
-		case "password":
-			sanitizedParams[k] = "****"
-		default:
-			sanitizedParams[k] = v
-		}
-	}
-	return sanitizedParams
+	p, _ := params.(map[string]interface{})
+	return redact.Params(p)
 }
 
 const (
@@ -43,100 +37,181 @@ const (
 )
 
 type XOClient interface {
+	// Connected returns whether the websocket connection to XO is up.
+	Connected() bool
+	// Health checks that XO answers with a valid session.
+	Health(ctx context.Context) error
+
 	GetObjectsWithTags(tags []string) ([]Object, error)
+	GetObjectsWithTagsContext(ctx context.Context, tags []string) ([]Object, error)
 	GetAllObjectsOfType(obj XoObject, response interface{}) error
+	GetAllObjectsOfTypeContext(ctx context.Context, obj XoObject, response interface{}) error
 
 	CreateVm(vmReq Vm, d time.Duration) (*Vm, error)
+	CreateVmContext(ctx context.Context, vmReq Vm, d time.Duration) (*Vm, error)
 	GetVm(vmReq Vm) (*Vm, error)
+	GetVmContext(ctx context.Context, vmReq Vm) (*Vm, error)
 	GetVms(vm Vm) ([]Vm, error)
+	GetVmsContext(ctx context.Context, vm Vm) ([]Vm, error)
 	UpdateVm(vmReq Vm) (*Vm, error)
+	UpdateVmContext(ctx context.Context, vmReq Vm) (*Vm, error)
 	DeleteVm(id string) error
+	DeleteVmContext(ctx context.Context, id string) error
 	HaltVm(id string) error
+	HaltVmContext(ctx context.Context, id string) error
 	StartVm(id string) error
+	StartVmContext(ctx context.Context, id string) error
 	SuspendVm(id string) error
+	SuspendVmContext(ctx context.Context, id string) error
 	PauseVm(id string) error
+	PauseVmContext(ctx context.Context, id string) error
 
 	GetCloudConfigByName(name string) ([]CloudConfig, error)
+	GetCloudConfigByNameContext(ctx context.Context, name string) ([]CloudConfig, error)
 	CreateCloudConfig(name, template string) (*CloudConfig, error)
+	CreateCloudConfigContext(ctx context.Context, name, template string) (*CloudConfig, error)
 	GetCloudConfig(id string) (*CloudConfig, error)
+	GetCloudConfigContext(ctx context.Context, id string) (*CloudConfig, error)
 	DeleteCloudConfig(id string) error
+	DeleteCloudConfigContext(ctx context.Context, id string) error
 	GetAllCloudConfigs() ([]CloudConfig, error)
+	GetAllCloudConfigsContext(ctx context.Context) ([]CloudConfig, error)
 
 	GetHostById(id string) (host Host, err error)
+	GetHostByIdContext(ctx context.Context, id string) (host Host, err error)
 	GetHostByName(nameLabel string) (hosts []Host, err error)
+	GetHostByNameContext(ctx context.Context, nameLabel string) (hosts []Host, err error)
 
 	GetPools(pool Pool) ([]Pool, error)
+	GetPoolsContext(ctx context.Context, pool Pool) ([]Pool, error)
 	GetPoolByName(name string) (pools []Pool, err error)
+	GetPoolByNameContext(ctx context.Context, name string) (pools []Pool, err error)
 
 	GetSortedHosts(host Host, sortBy, sortOrder string) (hosts []Host, err error)
+	GetSortedHostsContext(ctx context.Context, host Host, sortBy, sortOrder string) (hosts []Host, err error)
 
 	CreateResourceSet(rsReq ResourceSet) (*ResourceSet, error)
+	CreateResourceSetContext(ctx context.Context, rsReq ResourceSet) (*ResourceSet, error)
 	GetResourceSets() ([]ResourceSet, error)
+	GetResourceSetsContext(ctx context.Context) ([]ResourceSet, error)
 	GetResourceSet(rsReq ResourceSet) ([]ResourceSet, error)
+	GetResourceSetContext(ctx context.Context, rsReq ResourceSet) ([]ResourceSet, error)
 	GetResourceSetById(id string) (*ResourceSet, error)
+	GetResourceSetByIdContext(ctx context.Context, id string) (*ResourceSet, error)
 	DeleteResourceSet(rsReq ResourceSet) error
+	DeleteResourceSetContext(ctx context.Context, rsReq ResourceSet) error
 	AddResourceSetSubject(rsReq ResourceSet, subject string) error
+	AddResourceSetSubjectContext(ctx context.Context, rsReq ResourceSet, subject string) error
 	AddResourceSetObject(rsReq ResourceSet, object string) error
+	AddResourceSetObjectContext(ctx context.Context, rsReq ResourceSet, object string) error
 	AddResourceSetLimit(rsReq ResourceSet, limit string, quantity int) error
+	AddResourceSetLimitContext(ctx context.Context, rsReq ResourceSet, limit string, quantity int) error
 	RemoveResourceSetSubject(rsReq ResourceSet, subject string) error
+	RemoveResourceSetSubjectContext(ctx context.Context, rsReq ResourceSet, subject string) error
 	RemoveResourceSetObject(rsReq ResourceSet, object string) error
+	RemoveResourceSetObjectContext(ctx context.Context, rsReq ResourceSet, object string) error
 	RemoveResourceSetLimit(rsReq ResourceSet, limit string) error
+	RemoveResourceSetLimitContext(ctx context.Context, rsReq ResourceSet, limit string) error
 
 	CreateUser(user User) (*User, error)
+	CreateUserContext(ctx context.Context, user User) (*User, error)
 	GetAllUsers() ([]User, error)
+	GetAllUsersContext(ctx context.Context) ([]User, error)
 	GetUser(userReq User) (*User, error)
+	GetUserContext(ctx context.Context, userReq User) (*User, error)
 	GetCurrentUser() (*User, error)
+	GetCurrentUserContext(ctx context.Context) (*User, error)
 	DeleteUser(userReq User) error
+	DeleteUserContext(ctx context.Context, userReq User) error
 
 	CreateNetwork(netReq CreateNetworkRequest) (*Network, error)
+	CreateNetworkContext(ctx context.Context, netReq CreateNetworkRequest) (*Network, error)
 	GetNetwork(netReq Network) (*Network, error)
+	GetNetworkContext(ctx context.Context, netReq Network) (*Network, error)
 	UpdateNetwork(netReq UpdateNetworkRequest) (*Network, error)
+	UpdateNetworkContext(ctx context.Context, netReq UpdateNetworkRequest) (*Network, error)
 	CreateBondedNetwork(netReq CreateBondedNetworkRequest) (*Network, error)
+	CreateBondedNetworkContext(ctx context.Context, netReq CreateBondedNetworkRequest) (*Network, error)
 	GetNetworks() ([]Network, error)
+	GetNetworksContext(ctx context.Context) ([]Network, error)
 	DeleteNetwork(id string) error
+	DeleteNetworkContext(ctx context.Context, id string) error
 
 	GetPIF(pifReq PIF) (pifs []PIF, err error)
+	GetPIFContext(ctx context.Context, pifReq PIF) (pifs []PIF, err error)
 	GetPIFByDevice(dev string, vlan int) ([]PIF, error)
+	GetPIFByDeviceContext(ctx context.Context, dev string, vlan int) ([]PIF, error)
 
 	GetStorageRepository(sr StorageRepository) ([]StorageRepository, error)
+	GetStorageRepositoryContext(ctx context.Context, sr StorageRepository) ([]StorageRepository, error)
 	GetStorageRepositoryById(id string) (StorageRepository, error)
+	GetStorageRepositoryByIdContext(ctx context.Context, id string) (StorageRepository, error)
 
 	GetTemplate(template Template) ([]Template, error)
+	GetTemplateContext(ctx context.Context, template Template) ([]Template, error)
 
 	GetAllVDIs() ([]VDI, error)
+	GetAllVDIsContext(ctx context.Context) ([]VDI, error)
 	GetVDIs(vdiReq VDI) ([]VDI, error)
+	GetVDIsContext(ctx context.Context, vdiReq VDI) ([]VDI, error)
 	GetVDI(vdiReq VDI) (VDI, error)
+	GetVDIContext(ctx context.Context, vdiReq VDI) (VDI, error)
 	CreateVDI(vdiReq CreateVDIReq) (VDI, error)
+	CreateVDIContext(ctx context.Context, vdiReq CreateVDIReq) (VDI, error)
 	UpdateVDI(d Disk) error
+	UpdateVDIContext(ctx context.Context, d Disk) error
 	ResizeVDI(d Disk) error
+	ResizeVDIContext(ctx context.Context, d Disk) error
 	DeleteVDI(id string) error
+	DeleteVDIContext(ctx context.Context, id string) error
 
 	CreateAcl(acl Acl) (*Acl, error)
+	CreateAclContext(ctx context.Context, acl Acl) (*Acl, error)
 	GetAcl(aclReq Acl) (*Acl, error)
+	GetAclContext(ctx context.Context, aclReq Acl) (*Acl, error)
 	DeleteAcl(acl Acl) error
+	DeleteAclContext(ctx context.Context, acl Acl) error
 
 	AddTag(id, tag string) error
+	AddTagContext(ctx context.Context, id, tag string) error
 	RemoveTag(id, tag string) error
+	RemoveTagContext(ctx context.Context, id, tag string) error
 
 	GetDisks(vm *Vm) ([]Disk, error)
+	GetDisksContext(ctx context.Context, vm *Vm) ([]Disk, error)
 	CreateDisk(vm Vm, d Disk) (string, error)
+	CreateDiskContext(ctx context.Context, vm Vm, d Disk) (string, error)
 	DeleteDisk(vm Vm, d Disk) error
+	DeleteDiskContext(ctx context.Context, vm Vm, d Disk) error
 	ConnectDisk(d Disk) error
+	ConnectDiskContext(ctx context.Context, d Disk) error
 	DisconnectDisk(d Disk) error
+	DisconnectDiskContext(ctx context.Context, d Disk) error
 
 	GetVIF(vifReq *VIF) (*VIF, error)
+	GetVIFContext(ctx context.Context, vifReq *VIF) (*VIF, error)
 	GetVIFs(vm *Vm) ([]VIF, error)
+	GetVIFsContext(ctx context.Context, vm *Vm) ([]VIF, error)
 	CreateVIF(vm *Vm, vif *VIF) (*VIF, error)
+	CreateVIFContext(ctx context.Context, vm *Vm, vif *VIF) (*VIF, error)
 	DeleteVIF(vifReq *VIF) (err error)
+	DeleteVIFContext(ctx context.Context, vifReq *VIF) (err error)
 	DisconnectVIF(vifReq *VIF) (err error)
+	DisconnectVIFContext(ctx context.Context, vifReq *VIF) (err error)
 	ConnectVIF(vifReq *VIF) (err error)
+	ConnectVIFContext(ctx context.Context, vifReq *VIF) (err error)
 
 	GetCdroms(vm *Vm) ([]Disk, error)
+	GetCdromsContext(ctx context.Context, vm *Vm) ([]Disk, error)
 	EjectCd(id string) error
+	EjectCdContext(ctx context.Context, id string) error
 	InsertCd(vmId, cdId string) error
+	InsertCdContext(ctx context.Context, vmId, cdId string) error
 
 	GetBond(bondReq Bond) (*Bond, error)
+	GetBondContext(ctx context.Context, bondReq Bond) (*Bond, error)
 	GetBonds(bondReq Bond) ([]Bond, error)
+	GetBondsContext(ctx context.Context, bondReq Bond) ([]Bond, error)
 }
 
 type Client struct {
@@ -167,8 +242,31 @@ type Config struct {
 	InsecureSkipVerify bool
 	RetryMode          RetryMode
 	RetryMaxTime       time.Duration
+	// NotificationHandler, when set, receives the JSON-RPC notifications pushed
+	// by XO on the websocket, e.g. "all" for the changes of the objects.
+	NotificationHandler NotificationHandler
+	// Credentials, when set, is used instead of Token, Username and Password.
+	Credentials CredentialsFunc
 }
 
+// Credentials are the credentials to sign in with: either a token, or a
+// username and a password.
+type Credentials struct {
+	Token    string
+	Username string
+	Password string //gosec:disable G117
+}
+
+// CredentialsFunc returns the credentials to sign in with. It is called each
+// time the websocket connects, including when it reconnects, e.g. to fetch a
+// rotated token from a secret manager.
+type CredentialsFunc func(ctx context.Context) (Credentials, error)
+
+// NotificationHandler is called with the method and the params of each
+// notification. It is called from the read loop of the connection, so it must
+// not block.
+type NotificationHandler func(method string, params json.RawMessage)
+
 var dialer = gorillawebsocket.Dialer{
 	ReadBufferSize:  MaxMessageSize,
 	WriteBufferSize: MaxMessageSize,
@@ -254,43 +352,67 @@ func NewClientWithLogger(config Config, logger *slog.Logger) (XOClient, error) {
 		logger = slog.New(slog.NewTextHandler(os.Stderr, handlerOpt))
 	}
 
-	if token == "" && (username == "" || password == "") {
+	if config.Credentials == nil && token == "" && (username == "" || password == "") {
 		return nil,
 			fmt.Errorf("one of the following environment variable(s) must be set: XOA_USER and XOA_PASSWORD or XOA_TOKEN")
 	}
 
-	useTokenAuth := token != ""
+	credentials := config.Credentials
+	if credentials == nil {
+		credentials = func(context.Context) (Credentials, error) {
+			return Credentials{Token: token, Username: username, Password: password}, nil
+		}
+	}
 
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: config.InsecureSkipVerify, // #nosec G402
 	}
 	dialer.TLSClientConfig = tlsConfig
 
-	ws, _, err := dialer.Dial(fmt.Sprintf("%s/api/", wsURL), http.Header{})
-
-	if err != nil {
-		return nil, err
-	}
+	var h jsonrpc2.Handler = &handler{notify: config.NotificationHandler}
+	// signIn connects and signs in, with the credentials returned at that
+	// time. It also returns the token signed in with, if any.
+	signIn := func(ctx context.Context) (*jsonrpc2.Conn, string, error) {
+		creds, err := credentials(ctx)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get credentials: %w", err)
+		}
+		reqParams := map[string]interface{}{}
+		if creds.Token != "" {
+			reqParams["token"] = creds.Token
+		} else {
+			reqParams["email"] = creds.Username
+			reqParams["password"] = creds.Password
+		}
 
-	objStream := websocket.NewObjectStream(ws)
-	var h jsonrpc2.Handler = &handler{}
-	c := jsonrpc2.NewConn(context.Background(), objStream, h)
+		ws, _, err := dialer.DialContext(ctx, fmt.Sprintf("%s/api/", wsURL), http.Header{})
+		if err != nil {
+			return nil, "", err
+		}
 
-	reqParams := map[string]interface{}{}
-	if useTokenAuth {
-		reqParams["token"] = token
-	} else {
+		objStream := websocket.NewObjectStream(ws)
+		c := jsonrpc2.NewConn(context.Background(), objStream, h)
 
-		reqParams["email"] = username
-		reqParams["password"] = password
+		var reply signInResponse
+		err = c.Call(ctx, "session.signIn", reqParams, &reply)
+		if err != nil {
+			_ = c.Close()
+			return nil, "", err
+		}
+		return c, creds.Token, nil
 	}
-	var reply signInResponse
-	err = c.Call(context.Background(), "session.signIn", reqParams, &reply)
+	// dial is used to reconnect, which fetches the credentials again.
+	dial := func(ctx context.Context) (*jsonrpc2.Conn, error) {
+		c, _, err := signIn(ctx)
+		return c, err
+	}
+
+	c, token, err := signIn(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	if !useTokenAuth {
+	if token == "" {
 		err = c.Call(context.Background(), "token.create", map[string]interface{}{}, &token)
 		if err != nil {
 			return nil, err
@@ -324,7 +446,7 @@ func NewClientWithLogger(config Config, logger *slog.Logger) (XOClient, error) {
 	return &Client{
 		RetryMode:    config.RetryMode,
 		RetryMaxTime: config.RetryMaxTime,
-		rpc:          c,
+		rpc:          newReconnectingConn(c, dial, config.RetryMaxTime, logger),
 		httpClient:   httpClient,
 		restApiURL:   restApiURL,
 		logger:       logger,
@@ -357,8 +479,14 @@ func (c *Client) IsRetryableError(err jsonrpc2.Error) bool {
 }
 
 func (c *Client) Call(method string, params, result interface{}) error {
+	return c.CallContext(context.Background(), method, params, result)
+}
+
+// CallContext is like Call, but the call and its retries are abandoned once
+// ctx is done.
+func (c *Client) CallContext(ctx context.Context, method string, params, result interface{}) error {
 	operation := func() error {
-		err := c.rpc.Call(context.Background(), method, params, result)
+		err := c.rpc.Call(ctx, method, params, result)
 		var callRes interface{}
 		t := reflect.TypeOf(result)
 		if t == nil || t.Kind() != reflect.Ptr {
@@ -395,7 +523,7 @@ func (c *Client) Call(method string, params, result interface{}) error {
 
 	bo := backoff.NewExponentialBackOff()
 	bo.MaxElapsedTime = c.RetryMaxTime
-	return backoff.Retry(operation, bo)
+	return backoff.Retry(operation, backoff.WithContext(bo, ctx))
 }
 
 type RefreshComparison interface {
@@ -442,14 +570,22 @@ func (c *Client) getObjectTypeFilter(obj XoObject) map[string]interface{} {
 }
 
 func (c *Client) GetAllObjectsOfType(obj XoObject, response interface{}) error {
-	return c.Call("xo.getAllObjects", c.getObjectTypeFilter(obj), response)
+	return c.GetAllObjectsOfTypeContext(context.Background(), obj, response)
+}
+
+func (c *Client) GetAllObjectsOfTypeContext(ctx context.Context, obj XoObject, response interface{}) error {
+	return c.CallContext(ctx, "xo.getAllObjects", c.getObjectTypeFilter(obj), response)
 }
 
 func (c *Client) FindFromGetAllObjects(obj XoObject) (interface{}, error) {
+	return c.FindFromGetAllObjectsContext(context.Background(), obj)
+}
+
+func (c *Client) FindFromGetAllObjectsContext(ctx context.Context, obj XoObject) (interface{}, error) {
 	var objsRes struct {
 		Objects map[string]interface{} `json:"-"`
 	}
-	err := c.GetAllObjectsOfType(obj, &objsRes.Objects)
+	err := c.GetAllObjectsOfTypeContext(ctx, obj, &objsRes.Objects)
 	if err != nil {
 		return obj, err
 	}
@@ -486,11 +622,54 @@ func (c *Client) FindFromGetAllObjects(obj XoObject) (interface{}, error) {
 	return objs.Interface(), nil
 }
 
-type handler struct{}
+// DisconnectNotify returns a channel closed when the current websocket
+// connection is closed, either by Close or because it was lost. A lost
+// connection is dialed again by the next call.
+func (c *Client) DisconnectNotify() <-chan struct{} {
+	switch rpc := c.rpc.(type) {
+	case *reconnectingConn:
+		return rpc.conn.Load().DisconnectNotify()
+	case *jsonrpc2.Conn:
+		return rpc.DisconnectNotify()
+	}
+	return nil
+}
+
+// Connected returns whether the websocket connection to XO is up. It is false
+// once the connection is lost, until a call dials it again.
+func (c *Client) Connected() bool {
+	switch rpc := c.rpc.(type) {
+	case *reconnectingConn:
+		return rpc.connected()
+	case *jsonrpc2.Conn:
+		return !disconnected(rpc)
+	}
+	return false
+}
+
+// Health checks that XO answers on the connection with a valid session,
+// reconnecting first if the connection was lost.
+func (c *Client) Health(ctx context.Context) error {
+	var user User
+	return c.CallContext(ctx, "session.getUser", map[string]interface{}{}, &user)
+}
+
+// Close closes the websocket connection, which is no longer dialed again.
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
+
+type handler struct {
+	notify NotificationHandler
+}
 
 func (h *handler) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
-	// We do not react to json rpc notifications and are only acting as a client.
-	// So there is no need to handle these callbacks.
+	// We are only acting as a client, so XO only sends notifications, which
+	// are ignored unless a handler was configured.
+	if !req.Notif || h.notify == nil || req.Params == nil {
+		return
+	}
+	h.notify(req.Method, *req.Params)
 }
 
 type signInResponse struct {
@@ -1,12 +1,16 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -169,3 +173,56 @@ func Test_convertWebsocketURLToRestApi(t *testing.T) {
 		}
 	}
 }
+
+// jsonRPCBlock blocks until the context of the call is done.
+type jsonRPCBlock struct {
+	jsonRPCFail
+}
+
+func (rpc jsonRPCBlock) Call(ctx context.Context, method string, params, result interface{},
+	opt ...jsonrpc2.CallOption) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCallContext_canceled(t *testing.T) {
+	c := Client{
+		rpc:    jsonRPCBlock{},
+		logger: slog.Default(),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := c.CallContext(ctx, "dummy method", map[string]interface{}{}, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CallContext should return the error of the context, received: %v", err)
+	}
+}
+
+func TestCallContext_sanitizesLoggedParams(t *testing.T) {
+	var logs bytes.Buffer
+	c := Client{
+		rpc:    jsonRPCFail{},
+		logger: slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	err := c.CallContext(context.Background(), "user.changePassword", map[string]interface{}{
+		"oldPassword": "old-secret",
+		"newPassword": "new-secret",
+		"token":       "token-secret",
+		"url":         "s3://key:url-secret@s3.example.com/bucket",
+	}, nil)
+	if err != nil {
+		t.Fatalf("CallContext failed: %v", err)
+	}
+
+	for _, secret := range []string{"old-secret", "new-secret", "token-secret", "url-secret"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("%s should not be logged, logs: %s", secret, logs.String())
+		}
+	}
+	if !strings.Contains(logs.String(), "[REDACTED]") {
+		t.Errorf("the credentials should be redacted, logs: %s", logs.String())
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -31,7 +32,11 @@ type CloudConfigResponse struct {
 }
 
 func (c *Client) GetCloudConfig(id string) (*CloudConfig, error) {
-	cloudConfigs, err := c.GetAllCloudConfigs()
+	return c.GetCloudConfigContext(context.Background(), id)
+}
+
+func (c *Client) GetCloudConfigContext(ctx context.Context, id string) (*CloudConfig, error) {
+	cloudConfigs, err := c.GetAllCloudConfigsContext(ctx)
 
 	if err != nil {
 		return nil, err
@@ -51,7 +56,11 @@ func (c *Client) GetCloudConfig(id string) (*CloudConfig, error) {
 }
 
 func (c *Client) GetCloudConfigByName(name string) ([]CloudConfig, error) {
-	allCloudConfigs, err := c.GetAllCloudConfigs()
+	return c.GetCloudConfigByNameContext(context.Background(), name)
+}
+
+func (c *Client) GetCloudConfigByNameContext(ctx context.Context, name string) ([]CloudConfig, error) {
+	allCloudConfigs, err := c.GetAllCloudConfigsContext(ctx)
 
 	if err != nil {
 		return nil, err
@@ -72,9 +81,13 @@ func (c *Client) GetCloudConfigByName(name string) ([]CloudConfig, error) {
 }
 
 func (c *Client) GetAllCloudConfigs() ([]CloudConfig, error) {
+	return c.GetAllCloudConfigsContext(context.Background())
+}
+
+func (c *Client) GetAllCloudConfigsContext(ctx context.Context) ([]CloudConfig, error) {
 	var getAllResp CloudConfigResponse
 	params := map[string]interface{}{}
-	err := c.Call("cloudConfig.getAll", params, &getAllResp.Result)
+	err := c.CallContext(ctx, "cloudConfig.getAll", params, &getAllResp.Result)
 
 	if err != nil {
 		return nil, err
@@ -83,6 +96,10 @@ func (c *Client) GetAllCloudConfigs() ([]CloudConfig, error) {
 }
 
 func (c *Client) CreateCloudConfig(name, template string) (*CloudConfig, error) {
+	return c.CreateCloudConfigContext(context.Background(), name, template)
+}
+
+func (c *Client) CreateCloudConfigContext(ctx context.Context, name, template string) (*CloudConfig, error) {
 	params := map[string]interface{}{
 		"name":     name,
 		"template": template,
@@ -92,7 +109,7 @@ func (c *Client) CreateCloudConfig(name, template string) (*CloudConfig, error)
 	// type in order to be backwards compatible while fixing this bug. See
 	// GitHub issue 204 for more details.
 	var resp interface{}
-	err := c.Call("cloudConfig.create", params, &resp)
+	err := c.CallContext(ctx, "cloudConfig.create", params, &resp)
 
 	if err != nil {
 		return nil, err
@@ -100,7 +117,7 @@ func (c *Client) CreateCloudConfig(name, template string) (*CloudConfig, error)
 
 	// Since the Id isn't returned in the response loop over all cloud configs
 	// and find the one we just created
-	cloudConfigs, err := c.GetAllCloudConfigs()
+	cloudConfigs, err := c.GetAllCloudConfigsContext(ctx)
 
 	if err != nil {
 		return nil, err
@@ -116,11 +133,15 @@ func (c *Client) CreateCloudConfig(name, template string) (*CloudConfig, error)
 }
 
 func (c *Client) DeleteCloudConfig(id string) error {
+	return c.DeleteCloudConfigContext(context.Background(), id)
+}
+
+func (c *Client) DeleteCloudConfigContext(ctx context.Context, id string) error {
 	params := map[string]interface{}{
 		"id": id,
 	}
 	var resp bool
-	err := c.Call("cloudConfig.delete", params, &resp)
+	err := c.CallContext(ctx, "cloudConfig.delete", params, &resp)
 
 	if err != nil {
 		return err
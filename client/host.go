@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -37,7 +38,11 @@ func (h Host) Compare(obj interface{}) bool {
 }
 
 func (c *Client) GetHostByName(nameLabel string) (hosts []Host, err error) {
-	obj, err := c.FindFromGetAllObjects(Host{NameLabel: nameLabel})
+	return c.GetHostByNameContext(context.Background(), nameLabel)
+}
+
+func (c *Client) GetHostByNameContext(ctx context.Context, nameLabel string) (hosts []Host, err error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, Host{NameLabel: nameLabel})
 	if err != nil {
 		return
 	}
@@ -45,7 +50,11 @@ func (c *Client) GetHostByName(nameLabel string) (hosts []Host, err error) {
 }
 
 func (c *Client) GetHostById(id string) (host Host, err error) {
-	obj, err := c.FindFromGetAllObjects(Host{Id: id})
+	return c.GetHostByIdContext(context.Background(), id)
+}
+
+func (c *Client) GetHostByIdContext(ctx context.Context, id string) (host Host, err error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, Host{Id: id})
 	if err != nil {
 		return
 	}
@@ -81,7 +90,12 @@ func FindHostForTests(hostId string, host *Host) {
 }
 
 func (c *Client) GetSortedHosts(host Host, sortBy, sortOrder string) (hosts []Host, err error) {
-	obj, err := c.FindFromGetAllObjects(host)
+	return c.GetSortedHostsContext(context.Background(), host, sortBy, sortOrder)
+}
+
+func (c *Client) GetSortedHostsContext(ctx context.Context, host Host, sortBy, sortOrder string) (
+	hosts []Host, err error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, host)
 
 	if err != nil {
 		return
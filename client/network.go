@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -112,6 +113,10 @@ func (c UpdateNetworkRequest) Propagated(obj interface{}) bool {
 }
 
 func (c *Client) CreateNetwork(netReq CreateNetworkRequest) (*Network, error) {
+	return c.CreateNetworkContext(context.Background(), netReq)
+}
+
+func (c *Client) CreateNetworkContext(ctx context.Context, netReq CreateNetworkRequest) (*Network, error) {
 	var id string
 	var params map[string]interface{}
 	err := mapstructure.Decode(netReq, &params)
@@ -123,7 +128,7 @@ func (c *Client) CreateNetwork(netReq CreateNetworkRequest) (*Network, error) {
 	delete(params, "defaultIsLocked")
 
 	c.logger.Debug("params for network.create", "params", params)
-	err = c.Call("network.create", params, &id)
+	err = c.CallContext(ctx, "network.create", params, &id)
 
 	if err != nil {
 		return nil, err
@@ -132,7 +137,7 @@ func (c *Client) CreateNetwork(netReq CreateNetworkRequest) (*Network, error) {
 	// Neither automatic nor defaultIsLocked can be specified in the network.create RPC.
 	// Update them afterwards if the user requested it during creation.
 	if netReq.Automatic || netReq.DefaultIsLocked {
-		_, err = c.UpdateNetwork(UpdateNetworkRequest{
+		_, err = c.UpdateNetworkContext(ctx, UpdateNetworkRequest{
 			Id:              id,
 			Automatic:       netReq.Automatic,
 			DefaultIsLocked: &netReq.DefaultIsLocked,
@@ -142,10 +147,14 @@ func (c *Client) CreateNetwork(netReq CreateNetworkRequest) (*Network, error) {
 		}
 	}
 
-	return c.waitForModifyNetwork(id, netReq, 10*time.Second)
+	return c.waitForModifyNetwork(ctx, id, netReq, 10*time.Second)
 }
 
 func (c *Client) CreateBondedNetwork(netReq CreateBondedNetworkRequest) (*Network, error) {
+	return c.CreateBondedNetworkContext(context.Background(), netReq)
+}
+
+func (c *Client) CreateBondedNetworkContext(ctx context.Context, netReq CreateBondedNetworkRequest) (*Network, error) {
 	var params map[string]interface{}
 	err := mapstructure.Decode(netReq, &params)
 	if err != nil {
@@ -158,7 +167,7 @@ func (c *Client) CreateBondedNetwork(netReq CreateBondedNetworkRequest) (*Networ
 	c.logger.Debug("params for network.createBonded", "params", params)
 
 	var result map[string]interface{}
-	err = c.Call("network.createBonded", params, &result)
+	err = c.CallContext(ctx, "network.createBonded", params, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +176,7 @@ func (c *Client) CreateBondedNetwork(netReq CreateBondedNetworkRequest) (*Networ
 	// Neither automatic nor defaultIsLocked can be specified in the network.create RPC.
 	// Update them afterwards if the user requested it during creation.
 	if netReq.Automatic || netReq.DefaultIsLocked {
-		_, err = c.UpdateNetwork(UpdateNetworkRequest{
+		_, err = c.UpdateNetworkContext(ctx, UpdateNetworkRequest{
 			Id:              id,
 			Automatic:       netReq.Automatic,
 			DefaultIsLocked: &netReq.DefaultIsLocked,
@@ -176,12 +185,13 @@ func (c *Client) CreateBondedNetwork(netReq CreateBondedNetworkRequest) (*Networ
 			return nil, err
 		}
 	}
-	return c.waitForModifyNetwork(id, netReq, 10*time.Second)
+	return c.waitForModifyNetwork(ctx, id, netReq, 10*time.Second)
 }
 
-func (c *Client) waitForModifyNetwork(id string, target RefreshComparison, timeout time.Duration) (*Network, error) {
+func (c *Client) waitForModifyNetwork(ctx context.Context, id string, target RefreshComparison,
+	timeout time.Duration) (*Network, error) {
 	refreshFn := func() (result interface{}, state string, err error) {
-		network, err := c.GetNetwork(Network{Id: id})
+		network, err := c.GetNetworkContext(ctx, Network{Id: id})
 
 		if err != nil {
 			return network, "", err
@@ -198,11 +208,15 @@ func (c *Client) waitForModifyNetwork(id string, target RefreshComparison, timeo
 		Timeout: timeout,
 		logger:  c.logger,
 	}
-	network, err := stateConf.WaitForState()
+	network, err := stateConf.WaitForStateContext(ctx)
 	return network.(*Network), err
 }
 
 func (c *Client) UpdateNetwork(netReq UpdateNetworkRequest) (*Network, error) {
+	return c.UpdateNetworkContext(context.Background(), netReq)
+}
+
+func (c *Client) UpdateNetworkContext(ctx context.Context, netReq UpdateNetworkRequest) (*Network, error) {
 	var params map[string]interface{}
 	err := mapstructure.Decode(netReq, &params)
 	if err != nil {
@@ -210,16 +224,20 @@ func (c *Client) UpdateNetwork(netReq UpdateNetworkRequest) (*Network, error) {
 	}
 
 	var success bool
-	err = c.Call("network.set", params, &success)
+	err = c.CallContext(ctx, "network.set", params, &success)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.waitForModifyNetwork(netReq.Id, netReq, 10*time.Second)
+	return c.waitForModifyNetwork(ctx, netReq.Id, netReq, 10*time.Second)
 }
 
 func (c *Client) GetNetwork(netReq Network) (*Network, error) {
-	obj, err := c.FindFromGetAllObjects(netReq)
+	return c.GetNetworkContext(context.Background(), netReq)
+}
+
+func (c *Client) GetNetworkContext(ctx context.Context, netReq Network) (*Network, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, netReq)
 
 	if err != nil {
 		return nil, err
@@ -237,8 +255,12 @@ func (c *Client) GetNetwork(netReq Network) (*Network, error) {
 }
 
 func (c *Client) GetNetworks() ([]Network, error) {
+	return c.GetNetworksContext(context.Background())
+}
+
+func (c *Client) GetNetworksContext(ctx context.Context) ([]Network, error) {
 	var response map[string]Network
-	err := c.GetAllObjectsOfType(Network{}, &response)
+	err := c.GetAllObjectsOfTypeContext(ctx, Network{}, &response)
 
 	nets := make([]Network, 0, len(response))
 	for _, net := range response {
@@ -248,12 +270,16 @@ func (c *Client) GetNetworks() ([]Network, error) {
 }
 
 func (c *Client) DeleteNetwork(id string) error {
+	return c.DeleteNetworkContext(context.Background(), id)
+}
+
+func (c *Client) DeleteNetworkContext(ctx context.Context, id string) error {
 	var success bool
 	params := map[string]interface{}{
 		"id": id,
 	}
 
-	err := c.Call("network.delete", params, &success)
+	err := c.CallContext(ctx, "network.delete", params, &success)
 
 	return err
 }
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -45,7 +46,11 @@ func (p PIF) Compare(obj interface{}) bool {
 }
 
 func (c *Client) GetPIFByDevice(dev string, vlan int) ([]PIF, error) {
-	obj, err := c.FindFromGetAllObjects(PIF{Device: dev, Vlan: vlan})
+	return c.GetPIFByDeviceContext(context.Background(), dev, vlan)
+}
+
+func (c *Client) GetPIFByDeviceContext(ctx context.Context, dev string, vlan int) ([]PIF, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, PIF{Device: dev, Vlan: vlan})
 
 	if err != nil {
 		return []PIF{}, err
@@ -60,7 +65,11 @@ func (c *Client) GetPIFByDevice(dev string, vlan int) ([]PIF, error) {
 }
 
 func (c *Client) GetPIF(pifReq PIF) (pifs []PIF, err error) {
-	obj, err := c.FindFromGetAllObjects(pifReq)
+	return c.GetPIFContext(context.Background(), pifReq)
+}
+
+func (c *Client) GetPIFContext(ctx context.Context, pifReq PIF) (pifs []PIF, err error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, pifReq)
 
 	if err != nil {
 		return
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -34,7 +35,11 @@ func (p Pool) Compare(obj interface{}) bool {
 }
 
 func (c *Client) GetPoolByName(name string) (pools []Pool, err error) {
-	obj, err := c.FindFromGetAllObjects(Pool{NameLabel: name})
+	return c.GetPoolByNameContext(context.Background(), name)
+}
+
+func (c *Client) GetPoolByNameContext(ctx context.Context, name string) (pools []Pool, err error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, Pool{NameLabel: name})
 	if err != nil {
 		return
 	}
@@ -44,7 +49,11 @@ func (c *Client) GetPoolByName(name string) (pools []Pool, err error) {
 }
 
 func (c *Client) GetPools(pool Pool) (pools []Pool, err error) {
-	obj, err := c.FindFromGetAllObjects(pool)
+	return c.GetPoolsContext(context.Background(), pool)
+}
+
+func (c *Client) GetPoolsContext(ctx context.Context, pool Pool) (pools []Pool, err error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, pool)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v3"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const (
+	reconnectMinBackoff = time.Second
+	reconnectMaxBackoff = 30 * time.Second
+	// reconnectMaxTime is how long to try to reconnect when RetryMaxTime is
+	// not set.
+	reconnectMaxTime = 5 * time.Minute
+)
+
+// replayablePrefixes are the prefixes of the read-only XO methods, e.g.
+// "xo.getAllObjects" or "remote.getAll". Only those calls are sent again when
+// the connection is lost before their response: the others may already have
+// been applied by XO.
+var replayablePrefixes = []string{"get", "list"}
+
+func isReplayable(method string) bool {
+	action := method[strings.LastIndex(method, ".")+1:]
+	for _, prefix := range replayablePrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconnectingConn is a JSON-RPC connection to XO which is dialed and signed
+// in again when it is lost, e.g. when XO restarts or a load balancer drops the
+// websocket.
+type reconnectingConn struct {
+	// dial connects to XO and signs in.
+	dial       func(ctx context.Context) (*jsonrpc2.Conn, error)
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	// maxTime is how long to try to reconnect before failing the call.
+	maxTime time.Duration
+	logger  *slog.Logger
+
+	conn   atomic.Pointer[jsonrpc2.Conn]
+	closed atomic.Bool
+	// redial is a lock held while reconnecting, which callers can stop
+	// waiting for when their context is done.
+	redial chan struct{}
+	// failures counts the failed reconnections and redialErr, guarded by
+	// redial, is the error of the last one: the callers which waited for it
+	// fail with it rather than trying again one after the other.
+	failures  atomic.Uint64
+	redialErr error
+}
+
+func newReconnectingConn(
+	conn *jsonrpc2.Conn,
+	dial func(ctx context.Context) (*jsonrpc2.Conn, error),
+	maxTime time.Duration,
+	logger *slog.Logger,
+) *reconnectingConn {
+	if maxTime <= 0 {
+		maxTime = reconnectMaxTime
+	}
+	r := &reconnectingConn{
+		dial:       dial,
+		minBackoff: reconnectMinBackoff,
+		maxBackoff: reconnectMaxBackoff,
+		maxTime:    maxTime,
+		logger:     logger,
+		redial:     make(chan struct{}, 1),
+	}
+	r.conn.Store(conn)
+	return r
+}
+
+func disconnected(conn *jsonrpc2.Conn) bool {
+	select {
+	case <-conn.DisconnectNotify():
+		return true
+	default:
+		return false
+	}
+}
+
+// connected returns whether the current connection is up.
+func (r *reconnectingConn) connected() bool {
+	conn := r.conn.Load()
+	return !r.closed.Load() && conn != nil && !disconnected(conn)
+}
+
+// connection returns the current connection, or a new one once it is lost.
+func (r *reconnectingConn) connection(ctx context.Context) (*jsonrpc2.Conn, error) {
+	if r.closed.Load() {
+		return nil, jsonrpc2.ErrClosed
+	}
+	if conn := r.conn.Load(); !disconnected(conn) {
+		return conn, nil
+	}
+
+	failures := r.failures.Load()
+	select {
+	case r.redial <- struct{}{}:
+		defer func() { <-r.redial }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// Another caller may have reconnected, or failed to, while this one was
+	// waiting.
+	if conn := r.conn.Load(); !disconnected(conn) {
+		return conn, nil
+	}
+	if r.failures.Load() != failures {
+		return nil, r.redialErr
+	}
+
+	var conn *jsonrpc2.Conn
+	operation := func() error {
+		if r.closed.Load() {
+			return backoff.Permanent(jsonrpc2.ErrClosed)
+		}
+		var err error
+		conn, err = r.dial(ctx)
+		// XO rejected the sign-in, e.g. as the token was revoked: retrying
+		// would not change its answer.
+		var rpcErr *jsonrpc2.Error
+		if errors.As(err, &rpcErr) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = r.minBackoff
+	bo.MaxInterval = r.maxBackoff
+	bo.MaxElapsedTime = r.maxTime
+	notify := func(err error, next time.Duration) {
+		r.logger.Warn("Failed to reconnect to XO", "error", err, "retryIn", next)
+	}
+	if err := backoff.RetryNotify(operation, backoff.WithContext(bo, ctx), notify); err != nil {
+		// The context of this caller says nothing about the other ones.
+		if ctx.Err() == nil {
+			r.redialErr = err
+			r.failures.Add(1)
+		}
+		return nil, err
+	}
+
+	r.conn.Store(conn)
+	// Close may have been called while dialing.
+	if r.closed.Load() {
+		_ = conn.Close()
+		return nil, jsonrpc2.ErrClosed
+	}
+	r.logger.Info("Reconnected to XO")
+	return conn, nil
+}
+
+func (r *reconnectingConn) Call(ctx context.Context, method string, params, result interface{},
+	opt ...jsonrpc2.CallOption) error {
+	conn, err := r.connection(ctx)
+	if err != nil {
+		return err
+	}
+	err = conn.Call(ctx, method, params, result, opt...)
+	if !errors.Is(err, jsonrpc2.ErrClosed) || r.closed.Load() || !isReplayable(method) {
+		return err
+	}
+
+	r.logger.Debug("Connection lost during the call, replaying it", "method", method)
+	conn, err = r.connection(ctx)
+	if err != nil {
+		return err
+	}
+	return conn.Call(ctx, method, params, result, opt...)
+}
+
+func (r *reconnectingConn) Notify(ctx context.Context, method string, params interface{},
+	opt ...jsonrpc2.CallOption) error {
+	conn, err := r.connection(ctx)
+	if err != nil {
+		return err
+	}
+	return conn.Notify(ctx, method, params, opt...)
+}
+
+// Close closes the connection, which is no longer reconnected.
+func (r *reconnectingConn) Close() error {
+	if r.closed.Swap(true) {
+		return jsonrpc2.ErrClosed
+	}
+	return r.conn.Load().Close()
+}
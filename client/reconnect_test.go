@@ -0,0 +1,286 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+// fakeXO is a JSON-RPC websocket server which drops the connection on the
+// first call of the methods in drop.
+type fakeXO struct {
+	mu    sync.Mutex
+	calls map[string]int
+	drop  map[string]bool
+	conns []*gorillawebsocket.Conn
+	// tokens are the tokens signed in with.
+	tokens []string
+	// down refuses the connections, rejectSignIn rejects the sign-ins.
+	down         bool
+	rejectSignIn bool
+}
+
+func (x *fakeXO) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	down := x.down
+	x.mu.Unlock()
+	if down {
+		http.Error(w, "XO is down", http.StatusServiceUnavailable)
+		return
+	}
+	upgrader := gorillawebsocket.Upgrader{}
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	x.mu.Lock()
+	x.conns = append(x.conns, ws)
+	x.mu.Unlock()
+	defer ws.Close()
+
+	for {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Token string `json:"token"`
+			} `json:"params"`
+		}
+		if err := ws.ReadJSON(&req); err != nil {
+			return
+		}
+		x.mu.Lock()
+		x.calls[req.Method]++
+		if req.Method == "session.signIn" {
+			x.tokens = append(x.tokens, req.Params.Token)
+		}
+		drop := x.drop[req.Method] && x.calls[req.Method] == 1
+		reject := x.rejectSignIn && req.Method == "session.signIn"
+		x.mu.Unlock()
+		if drop {
+			return
+		}
+		response := map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": true}
+		switch {
+		case reject:
+			delete(response, "result")
+			response["error"] = map[string]any{"code": 3, "message": "invalid credentials"}
+		case strings.HasPrefix(req.Method, "session."):
+			response["result"] = map[string]any{"id": "user"}
+		}
+		if err := ws.WriteJSON(response); err != nil {
+			return
+		}
+	}
+}
+
+// disconnect drops the connections, as when XO restarts.
+func (x *fakeXO) disconnect() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, ws := range x.conns {
+		_ = ws.Close()
+	}
+	x.conns = nil
+}
+
+// stop drops the connections and refuses the new ones, or only rejects the
+// sign-ins with rejectSignIn.
+func (x *fakeXO) stop(rejectSignIn bool) {
+	x.mu.Lock()
+	x.down = !rejectSignIn
+	x.rejectSignIn = rejectSignIn
+	x.mu.Unlock()
+	x.disconnect()
+}
+
+func (x *fakeXO) count(method string) int {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.calls[method]
+}
+
+func newReconnectTestClient(t *testing.T, drop ...string) (*Client, *fakeXO) {
+	return newReconnectTestClientWithConfig(t, Config{Token: "token"}, drop...)
+}
+
+// newReconnectTestClientWithConfig creates a client of a new fakeXO, with the
+// URL of config set to it.
+func newReconnectTestClientWithConfig(t *testing.T, config Config, drop ...string) (*Client, *fakeXO) {
+	xo := &fakeXO{calls: make(map[string]int), drop: make(map[string]bool)}
+	for _, method := range drop {
+		xo.drop[method] = true
+	}
+	server := httptest.NewServer(xo)
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	config.Url = "ws" + strings.TrimPrefix(server.URL, "http")
+	xoClient, err := NewClientWithLogger(config, logger)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	c := xoClient.(*Client)
+	c.rpc.(*reconnectingConn).minBackoff = time.Millisecond
+	t.Cleanup(func() { _ = c.Close() })
+	return c, xo
+}
+
+func waitDisconnected(t *testing.T, c *Client) {
+	select {
+	case <-c.DisconnectNotify():
+	case <-time.After(time.Second):
+		t.Fatal("the connection was not lost")
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	c, xo := newReconnectTestClient(t)
+	if !c.Connected() {
+		t.Fatal("Connected should be true once the client is created")
+	}
+
+	xo.disconnect()
+	waitDisconnected(t, c)
+	if c.Connected() {
+		t.Error("Connected should be false once the connection is lost")
+	}
+
+	// Whatever the method, as the call is sent on the new connection.
+	var result bool
+	if err := c.Call("vm.start", map[string]interface{}{}, &result); err != nil {
+		t.Fatalf("Call should reconnect, received: %v", err)
+	}
+	if n := xo.count("session.signIn"); n != 2 {
+		t.Errorf("the client should sign in again, signed in %d times", n)
+	}
+	if !c.Connected() {
+		t.Error("Connected should be true once reconnected")
+	}
+	if err := c.Health(context.Background()); err != nil {
+		t.Errorf("Health should succeed, received: %v", err)
+	}
+}
+
+func TestReconnect_credentials(t *testing.T) {
+	var fetched atomic.Int32
+	c, xo := newReconnectTestClientWithConfig(t, Config{
+		Credentials: func(context.Context) (Credentials, error) {
+			return Credentials{Token: fmt.Sprintf("token-%d", fetched.Add(1))}, nil
+		},
+	})
+
+	xo.disconnect()
+	waitDisconnected(t, c)
+	if err := c.Call("vm.start", map[string]interface{}{}, nil); err != nil {
+		t.Fatalf("Call should reconnect, received: %v", err)
+	}
+
+	xo.mu.Lock()
+	defer xo.mu.Unlock()
+	if !slices.Equal(xo.tokens, []string{"token-1", "token-2"}) {
+		t.Errorf("the client should sign in again with the current token, signed in with %v", xo.tokens)
+	}
+}
+
+func TestReconnect_replay(t *testing.T) {
+	c, xo := newReconnectTestClient(t, "vm.getCloudInitConfig", "vm.start")
+
+	var result bool
+	if err := c.Call("vm.getCloudInitConfig", map[string]interface{}{}, &result); err != nil {
+		t.Errorf("a read-only call should be replayed, received: %v", err)
+	}
+	if n := xo.count("vm.getCloudInitConfig"); n != 2 {
+		t.Errorf("a read-only call should be sent again, sent %d times", n)
+	}
+
+	err := c.Call("vm.start", map[string]interface{}{}, &result)
+	if !errors.Is(err, jsonrpc2.ErrClosed) {
+		t.Errorf("a call lost with the connection should fail, received: %v", err)
+	}
+	if n := xo.count("vm.start"); n != 1 {
+		t.Errorf("a call which is not read-only should not be sent again, sent %d times", n)
+	}
+}
+
+func TestReconnect_closed(t *testing.T) {
+	c, xo := newReconnectTestClient(t)
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	err := c.Call("vm.getAll", map[string]interface{}{}, nil)
+	if !errors.Is(err, jsonrpc2.ErrClosed) {
+		t.Errorf("Call should fail once closed, received: %v", err)
+	}
+	if n := xo.count("session.signIn"); n != 1 {
+		t.Errorf("a closed client should not reconnect, signed in %d times", n)
+	}
+}
+
+func TestReconnect_signInRejected(t *testing.T) {
+	c, xo := newReconnectTestClient(t)
+	xo.stop(true)
+	waitDisconnected(t, c)
+
+	err := c.Call("vm.getAll", map[string]interface{}{}, nil)
+	var rpcErr *jsonrpc2.Error
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("Call should fail with the rejection of the sign-in, received: %v", err)
+	}
+	if n := xo.count("session.signIn"); n != 2 {
+		t.Errorf("a rejected sign-in should not be retried, signed in %d times", n)
+	}
+}
+
+func TestReconnect_unreachable(t *testing.T) {
+	c, xo := newReconnectTestClient(t)
+	c.rpc.(*reconnectingConn).maxTime = 50 * time.Millisecond
+	xo.stop(false)
+	waitDisconnected(t, c)
+
+	// The caller waiting for the reconnection of the other one fails with it.
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() { errs <- c.Call("vm.getAll", map[string]interface{}{}, nil) }()
+	}
+	for range 2 {
+		select {
+		case err := <-errs:
+			if err == nil {
+				t.Error("Call should fail when XO cannot be reached")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Call should stop reconnecting once the retry time has elapsed")
+		}
+	}
+}
+
+func TestIsReplayable(t *testing.T) {
+	for method, expected := range map[string]bool{
+		"xo.getAllObjects": true,
+		"remote.getAll":    true,
+		"session.getUser":  true,
+		"vm.start":         false,
+		"token.create":     false,
+		"vm.set":           false,
+	} {
+		if isReplayable(method) != expected {
+			t.Errorf("isReplayable(%q) should be %t", method, expected)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -39,11 +40,19 @@ func (rs ResourceSet) Compare(obj interface{}) bool {
 }
 
 func (c Client) GetResourceSets() ([]ResourceSet, error) {
-	return c.makeResourceSetGetAllCall()
+	return c.GetResourceSetsContext(context.Background())
+}
+
+func (c Client) GetResourceSetsContext(ctx context.Context) ([]ResourceSet, error) {
+	return c.makeResourceSetGetAllCall(ctx)
 }
 
 func (c Client) GetResourceSetById(id string) (*ResourceSet, error) {
-	resourceSets, err := c.GetResourceSet(ResourceSet{
+	return c.GetResourceSetByIdContext(context.Background(), id)
+}
+
+func (c Client) GetResourceSetByIdContext(ctx context.Context, id string) (*ResourceSet, error) {
+	resourceSets, err := c.GetResourceSetContext(ctx, ResourceSet{
 		Id: id,
 	})
 
@@ -60,7 +69,11 @@ func (c Client) GetResourceSetById(id string) (*ResourceSet, error) {
 }
 
 func (c Client) GetResourceSet(rsReq ResourceSet) ([]ResourceSet, error) {
-	resourceSets, err := c.makeResourceSetGetAllCall()
+	return c.GetResourceSetContext(context.Background(), rsReq)
+}
+
+func (c Client) GetResourceSetContext(ctx context.Context, rsReq ResourceSet) ([]ResourceSet, error) {
+	resourceSets, err := c.makeResourceSetGetAllCall(ctx)
 
 	if err != nil {
 		return nil, err
@@ -81,7 +94,7 @@ func (c Client) GetResourceSet(rsReq ResourceSet) ([]ResourceSet, error) {
 	return rsRv, nil
 }
 
-func (c Client) makeResourceSetGetAllCall() ([]ResourceSet, error) {
+func (c Client) makeResourceSetGetAllCall(ctx context.Context) ([]ResourceSet, error) {
 
 	var res struct {
 		ResourceSets []ResourceSet `json:"-"`
@@ -89,7 +102,7 @@ func (c Client) makeResourceSetGetAllCall() ([]ResourceSet, error) {
 	params := map[string]interface{}{
 		"id": "dummy",
 	}
-	err := c.Call("resourceSet.getAll", params, &res.ResourceSets)
+	err := c.CallContext(ctx, "resourceSet.getAll", params, &res.ResourceSets)
 	c.logger.Debug("Calling resourceSet.getAll received response", "response", res, "error", err)
 
 	if err != nil {
@@ -115,6 +128,10 @@ func createLimitsMap(rsl ResourceSetLimits) map[string]interface{} {
 }
 
 func (c Client) CreateResourceSet(rsReq ResourceSet) (*ResourceSet, error) {
+	return c.CreateResourceSetContext(context.Background(), rsReq)
+}
+
+func (c Client) CreateResourceSetContext(ctx context.Context, rsReq ResourceSet) (*ResourceSet, error) {
 	rs := ResourceSet{}
 	limits := createLimitsMap(rsReq.Limits)
 	params := map[string]interface{}{
@@ -123,7 +140,7 @@ func (c Client) CreateResourceSet(rsReq ResourceSet) (*ResourceSet, error) {
 		"objects":  rsReq.Objects,
 		"limits":   limits,
 	}
-	err := c.Call("resourceSet.create", params, &rs)
+	err := c.CallContext(ctx, "resourceSet.create", params, &rs)
 	c.logger.Debug(fmt.Sprintf("[DEBUG] Calling resourceSet.create with params: %v returned: %+v with error: %v\n",
 		params, rs, err))
 
@@ -135,10 +152,14 @@ func (c Client) CreateResourceSet(rsReq ResourceSet) (*ResourceSet, error) {
 }
 
 func (c Client) DeleteResourceSet(rsReq ResourceSet) error {
+	return c.DeleteResourceSetContext(context.Background(), rsReq)
+}
+
+func (c Client) DeleteResourceSetContext(ctx context.Context, rsReq ResourceSet) error {
 
 	id := rsReq.Id
 	if id == "" {
-		rs, err := c.GetResourceSet(rsReq)
+		rs, err := c.GetResourceSetContext(ctx, rsReq)
 
 		if err != nil {
 			return err
@@ -154,75 +175,99 @@ func (c Client) DeleteResourceSet(rsReq ResourceSet) error {
 	params := map[string]interface{}{
 		"id": id,
 	}
-	err := c.Call("resourceSet.delete", params, &success)
+	err := c.CallContext(ctx, "resourceSet.delete", params, &success)
 	c.logger.Debug("Calling resourceSet.delete call successful", "success", success, "error", err)
 
 	return err
 }
 
 func (c Client) RemoveResourceSetSubject(rsReq ResourceSet, subject string) error {
+	return c.RemoveResourceSetSubjectContext(context.Background(), rsReq, subject)
+}
+
+func (c Client) RemoveResourceSetSubjectContext(ctx context.Context, rsReq ResourceSet, subject string) error {
 	params := map[string]interface{}{
 		"id":      rsReq.Id,
 		"subject": subject,
 	}
 	var success bool
-	err := c.Call("resourceSet.removeSubject", params, &success)
+	err := c.CallContext(ctx, "resourceSet.removeSubject", params, &success)
 	c.logger.Debug("Calling resourceSet.removeSubject call successful", "success", success, "error", err)
 	return err
 }
 
 func (c Client) AddResourceSetSubject(rsReq ResourceSet, subject string) error {
+	return c.AddResourceSetSubjectContext(context.Background(), rsReq, subject)
+}
+
+func (c Client) AddResourceSetSubjectContext(ctx context.Context, rsReq ResourceSet, subject string) error {
 	params := map[string]interface{}{
 		"id":      rsReq.Id,
 		"subject": subject,
 	}
 	var success bool
-	err := c.Call("resourceSet.addSubject", params, &success)
+	err := c.CallContext(ctx, "resourceSet.addSubject", params, &success)
 	c.logger.Debug("Calling resourceSet.addSubject call successful", "success", success, "error", err)
 	return err
 }
 
 func (c Client) RemoveResourceSetObject(rsReq ResourceSet, object string) error {
+	return c.RemoveResourceSetObjectContext(context.Background(), rsReq, object)
+}
+
+func (c Client) RemoveResourceSetObjectContext(ctx context.Context, rsReq ResourceSet, object string) error {
 	params := map[string]interface{}{
 		"id":     rsReq.Id,
 		"object": object,
 	}
 	var success bool
-	err := c.Call("resourceSet.removeObject", params, &success)
+	err := c.CallContext(ctx, "resourceSet.removeObject", params, &success)
 	c.logger.Debug("Calling resourceSet.removeObject call successful", "success", success, "error", err)
 	return err
 }
 
 func (c Client) AddResourceSetObject(rsReq ResourceSet, object string) error {
+	return c.AddResourceSetObjectContext(context.Background(), rsReq, object)
+}
+
+func (c Client) AddResourceSetObjectContext(ctx context.Context, rsReq ResourceSet, object string) error {
 	params := map[string]interface{}{
 		"id":     rsReq.Id,
 		"object": object,
 	}
 	var success bool
-	err := c.Call("resourceSet.addObject", params, &success)
+	err := c.CallContext(ctx, "resourceSet.addObject", params, &success)
 	c.logger.Debug("Calling resourceSet.addObject call successful", "success", success, "error", err)
 	return err
 }
 
 func (c Client) RemoveResourceSetLimit(rsReq ResourceSet, limit string) error {
+	return c.RemoveResourceSetLimitContext(context.Background(), rsReq, limit)
+}
+
+func (c Client) RemoveResourceSetLimitContext(ctx context.Context, rsReq ResourceSet, limit string) error {
 	params := map[string]interface{}{
 		"id":      rsReq.Id,
 		"limitId": limit,
 	}
 	var success bool
-	err := c.Call("resourceSet.removeLimit", params, &success)
+	err := c.CallContext(ctx, "resourceSet.removeLimit", params, &success)
 	c.logger.Debug("Calling resourceSet.removeLimit call successful", "success", success, "error", err)
 	return err
 }
 
 func (c Client) AddResourceSetLimit(rsReq ResourceSet, limit string, quantity int) error {
+	return c.AddResourceSetLimitContext(context.Background(), rsReq, limit, quantity)
+}
+
+func (c Client) AddResourceSetLimitContext(ctx context.Context, rsReq ResourceSet, limit string, quantity int) error {
 	params := map[string]interface{}{
 		"id":       rsReq.Id,
 		"limitId":  limit,
 		"quantity": quantity,
 	}
 	var success bool
-	err := c.Call("resourceSet.addLimit", params, &success)
+	err := c.CallContext(ctx, "resourceSet.addLimit", params, &success)
 	c.logger.Debug(fmt.Sprintf("Calling resourceSet.addLimit call with params: %v successful: %t with error: %v\n",
 		params, success, err))
 	return err
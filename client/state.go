@@ -11,6 +11,7 @@ package client
 // will likely need to be reconsidered
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -70,6 +71,13 @@ func (conf *StateChangeConf) Logger() *slog.Logger {
 // Otherwise, the result is the result of the first call to the Refresh function to
 // reach the target state.
 func (conf *StateChangeConf) WaitForState() (interface{}, error) {
+	return conf.WaitForStateContext(context.Background())
+}
+
+// WaitForStateContext is like WaitForState, but stops waiting and returns the
+// error of ctx once it is done. The Refresh function should honor ctx too, so
+// that the refresh in progress is abandoned.
+func (conf *StateChangeConf) WaitForStateContext(ctx context.Context) (interface{}, error) {
 	conf.Logger().Debug(fmt.Sprintf("Waiting for state to become: %s", conf.Target))
 
 	notfoundTick := 0
@@ -101,7 +109,11 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 	go func() {
 		defer close(resCh)
 
-		time.Sleep(conf.Delay)
+		select {
+		case <-cancelCh:
+			return
+		case <-time.After(conf.Delay):
+		}
 
 		// start with 0 delay for the first loop
 		var wait time.Duration
@@ -235,6 +247,18 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			// still waiting, store the last result
 			lastResult = r
 
+		case <-ctx.Done():
+			conf.Logger().Debug("WaitForState canceled", "error", ctx.Err())
+
+			// cancel the goroutine, and drain the channel so that it does not
+			// block on its last results
+			close(cancelCh)
+			go func() {
+				for range resCh {
+				}
+			}()
+			return nil, ctx.Err()
+
 		case <-timeout:
 			conf.Logger().Warn("WaitForState timeout", "after", conf.Timeout)
 			conf.Logger().Warn("WaitForState starting refresh grace period", "refreshGracePeriod", refreshGracePeriod)
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitForStateContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	refreshes := 0
+	conf := StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"done"},
+		Refresh: func() (interface{}, string, error) {
+			refreshes++
+			if refreshes == 2 {
+				cancel()
+			}
+			return struct{}{}, "pending", nil
+		},
+		Timeout:      time.Minute,
+		PollInterval: time.Millisecond,
+	}
+
+	start := time.Now()
+	_, err := conf.WaitForStateContext(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForStateContext should return the error of the context, received: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("WaitForStateContext should return once the context is canceled, returned after %s", elapsed)
+	}
+}
+
+func TestWaitForStateContext_target(t *testing.T) {
+	states := []string{"pending", "pending", "done"}
+	conf := StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"done"},
+		Refresh: func() (interface{}, string, error) {
+			state := states[0]
+			states = states[1:]
+			return state, state, nil
+		},
+		Timeout:      time.Minute,
+		PollInterval: time.Millisecond,
+	}
+
+	result, err := conf.WaitForStateContext(context.Background())
+
+	if err != nil || result != "done" {
+		t.Errorf("WaitForStateContext should return the target state, received: %v, %v", result, err)
+	}
+}
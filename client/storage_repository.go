@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -56,7 +57,11 @@ func stringInSlice(needle string, haystack []string) bool {
 }
 
 func (c *Client) GetStorageRepositoryById(id string) (StorageRepository, error) {
-	obj, err := c.FindFromGetAllObjects(StorageRepository{Id: id})
+	return c.GetStorageRepositoryByIdContext(context.Background(), id)
+}
+
+func (c *Client) GetStorageRepositoryByIdContext(ctx context.Context, id string) (StorageRepository, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, StorageRepository{Id: id})
 	var sr StorageRepository
 
 	if err != nil {
@@ -77,7 +82,11 @@ func (c *Client) GetStorageRepositoryById(id string) (StorageRepository, error)
 }
 
 func (c *Client) GetStorageRepository(sr StorageRepository) ([]StorageRepository, error) {
-	obj, err := c.FindFromGetAllObjects(sr)
+	return c.GetStorageRepositoryContext(context.Background(), sr)
+}
+
+func (c *Client) GetStorageRepositoryContext(ctx context.Context, sr StorageRepository) ([]StorageRepository, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, sr)
 
 	if err != nil {
 		return nil, err
@@ -1,18 +1,23 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 )
 
 func (c *Client) AddTag(id, tag string) error {
+	return c.AddTagContext(context.Background(), id, tag)
+}
+
+func (c *Client) AddTagContext(ctx context.Context, id, tag string) error {
 	var success bool
 	params := map[string]interface{}{
 		"id":  id,
 		"tag": tag,
 	}
-	err := c.Call("tag.add", params, &success)
+	err := c.CallContext(ctx, "tag.add", params, &success)
 
 	if err != nil {
 		return err
@@ -21,12 +26,16 @@ func (c *Client) AddTag(id, tag string) error {
 }
 
 func (c *Client) RemoveTag(id, tag string) error {
+	return c.RemoveTagContext(context.Background(), id, tag)
+}
+
+func (c *Client) RemoveTagContext(ctx context.Context, id, tag string) error {
 	var success bool
 	params := map[string]interface{}{
 		"id":  id,
 		"tag": tag,
 	}
-	err := c.Call("tag.remove", params, &success)
+	err := c.CallContext(ctx, "tag.remove", params, &success)
 
 	if err != nil {
 		return err
@@ -40,6 +49,10 @@ type Object struct {
 }
 
 func (c *Client) GetObjectsWithTags(tags []string) ([]Object, error) {
+	return c.GetObjectsWithTagsContext(context.Background(), tags)
+}
+
+func (c *Client) GetObjectsWithTagsContext(ctx context.Context, tags []string) ([]Object, error) {
 	var objsRes struct {
 		Objects map[string]interface{} `json:"-"`
 	}
@@ -48,7 +61,7 @@ func (c *Client) GetObjectsWithTags(tags []string) ([]Object, error) {
 			"tags": tags,
 		},
 	}
-	err := c.Call("xo.getAllObjects", params, &objsRes.Objects)
+	err := c.CallContext(ctx, "xo.getAllObjects", params, &objsRes.Objects)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -62,7 +63,11 @@ func (t Template) isDiskTemplate() bool {
 }
 
 func (c *Client) GetTemplate(template Template) ([]Template, error) {
-	obj, err := c.FindFromGetAllObjects(template)
+	return c.GetTemplateContext(context.Background(), template)
+}
+
+func (c *Client) GetTemplateContext(ctx context.Context, template Template) ([]Template, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, template)
 	var templates []Template
 	if err != nil {
 		return templates, err
@@ -116,8 +121,12 @@ func (t *Template) getDiskCount() int {
 // GetTemplateVBDs retrieves all VBDs for a given template and returns them as a map
 // where the key is the VBD's position.
 func (c *Client) GetTemplateVBDs(template Template) (map[string]VBD, error) {
+	return c.GetTemplateVBDsContext(context.Background(), template)
+}
+
+func (c *Client) GetTemplateVBDsContext(ctx context.Context, template Template) (map[string]VBD, error) {
 	var response map[string]VBD
-	err := c.GetAllObjectsOfType(VBD{}, &response)
+	err := c.GetAllObjectsOfTypeContext(ctx, VBD{}, &response)
 	if err != nil {
 		slog.Error("failed to get template VBDs", "error", err)
 		return nil, err
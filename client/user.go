@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -41,27 +42,35 @@ func (user User) Compare(obj interface{}) bool {
 }
 
 func (c *Client) CreateUser(user User) (*User, error) {
+	return c.CreateUserContext(context.Background(), user)
+}
+
+func (c *Client) CreateUserContext(ctx context.Context, user User) (*User, error) {
 	var id string
 	params := map[string]interface{}{
 		"email":    user.Email,
 		"password": user.Password,
 	}
-	err := c.Call("user.create", params, &id)
+	err := c.CallContext(ctx, "user.create", params, &id)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return c.GetUser(User{Id: id})
+	return c.GetUserContext(ctx, User{Id: id})
 }
 
 func (c *Client) GetAllUsers() ([]User, error) {
+	return c.GetAllUsersContext(context.Background())
+}
+
+func (c *Client) GetAllUsersContext(ctx context.Context) ([]User, error) {
 	params := map[string]interface{}{
 		"dummy": "dummy",
 	}
 	users := []User{}
 	c.logger.Debug("Calling user.getAll")
-	err := c.Call("user.getAll", params, &users)
+	err := c.CallContext(ctx, "user.getAll", params, &users)
 
 	c.logger.Debug("Found the following users", "users", users)
 	if err != nil {
@@ -71,11 +80,15 @@ func (c *Client) GetAllUsers() ([]User, error) {
 }
 
 func (c *Client) GetCurrentUser() (*User, error) {
+	return c.GetCurrentUserContext(context.Background())
+}
+
+func (c *Client) GetCurrentUserContext(ctx context.Context) (*User, error) {
 	params := map[string]interface{}{
 		"dummy": "dummy",
 	}
 	user := User{}
-	err := c.Call("session.getUser", params, &user)
+	err := c.CallContext(ctx, "session.getUser", params, &user)
 
 	c.logger.Debug("Found the following user", "user", user, "error", err)
 	if err != nil {
@@ -85,7 +98,11 @@ func (c *Client) GetCurrentUser() (*User, error) {
 }
 
 func (c *Client) GetUser(userReq User) (*User, error) {
-	users, err := c.GetAllUsers()
+	return c.GetUserContext(context.Background(), userReq)
+}
+
+func (c *Client) GetUserContext(ctx context.Context, userReq User) (*User, error) {
+	users, err := c.GetAllUsersContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -105,11 +122,15 @@ func (c *Client) GetUser(userReq User) (*User, error) {
 }
 
 func (c *Client) DeleteUser(user User) error {
+	return c.DeleteUserContext(context.Background(), user)
+}
+
+func (c *Client) DeleteUserContext(ctx context.Context, user User) error {
 	var success bool
 	params := map[string]interface{}{
 		"id": user.Id,
 	}
-	err := c.Call("user.delete", params, &success)
+	err := c.CallContext(ctx, "user.delete", params, &success)
 
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -93,7 +94,11 @@ func (v VBD) Compare(obj interface{}) bool {
 }
 
 func (c *Client) GetVBD(vbdReq VBD) (VBD, error) {
-	obj, err := c.FindFromGetAllObjects(vbdReq)
+	return c.GetVBDContext(context.Background(), vbdReq)
+}
+
+func (c *Client) GetVBDContext(ctx context.Context, vbdReq VBD) (VBD, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, vbdReq)
 
 	if err != nil {
 		return VBD{}, err
@@ -113,8 +118,8 @@ func (c *Client) GetVBD(vbdReq VBD) (VBD, error) {
 	return vbds[0], nil
 }
 
-func (c *Client) getDisksFromVBDs(vbd VBD) ([]Disk, error) {
-	obj, err := c.FindFromGetAllObjects(vbd)
+func (c *Client) getDisksFromVBDs(ctx context.Context, vbd VBD) ([]Disk, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, vbd)
 
 	if _, ok := err.(NotFound); ok {
 		return []Disk{}, nil
@@ -131,7 +136,7 @@ func (c *Client) getDisksFromVBDs(vbd VBD) ([]Disk, error) {
 
 	vdis := []Disk{}
 	for _, disk := range disks {
-		vdi, err := c.GetParentVDI(disk)
+		vdi, err := c.GetParentVDIContext(ctx, disk)
 
 		if err != nil {
 			return []Disk{}, err
@@ -143,14 +148,22 @@ func (c *Client) getDisksFromVBDs(vbd VBD) ([]Disk, error) {
 }
 
 func (c *Client) GetDisks(vm *Vm) ([]Disk, error) {
-	return c.getDisksFromVBDs(VBD{
+	return c.GetDisksContext(context.Background(), vm)
+}
+
+func (c *Client) GetDisksContext(ctx context.Context, vm *Vm) ([]Disk, error) {
+	return c.getDisksFromVBDs(ctx, VBD{
 		VmId:      vm.Id,
 		IsCdDrive: false,
 	})
 }
 
 func (c *Client) GetCdroms(vm *Vm) ([]Disk, error) {
-	cds, err := c.getDisksFromVBDs(VBD{
+	return c.GetCdromsContext(context.Background(), vm)
+}
+
+func (c *Client) GetCdromsContext(ctx context.Context, vm *Vm) ([]Disk, error) {
+	cds, err := c.getDisksFromVBDs(ctx, VBD{
 		VmId:      vm.Id,
 		IsCdDrive: true,
 	})
@@ -166,8 +179,12 @@ func (c *Client) GetCdroms(vm *Vm) ([]Disk, error) {
 }
 
 func (c *Client) GetAllVDIs() ([]VDI, error) {
+	return c.GetAllVDIsContext(context.Background())
+}
+
+func (c *Client) GetAllVDIsContext(ctx context.Context) ([]VDI, error) {
 	var response map[string]VDI
-	err := c.GetAllObjectsOfType(VDI{}, &response)
+	err := c.GetAllObjectsOfTypeContext(ctx, VDI{}, &response)
 
 	vdis := make([]VDI, 0, len(response))
 	for _, net := range response {
@@ -177,7 +194,11 @@ func (c *Client) GetAllVDIs() ([]VDI, error) {
 }
 
 func (c *Client) GetVDIs(vdiReq VDI) ([]VDI, error) {
-	obj, err := c.FindFromGetAllObjects(vdiReq)
+	return c.GetVDIsContext(context.Background(), vdiReq)
+}
+
+func (c *Client) GetVDIsContext(ctx context.Context, vdiReq VDI) ([]VDI, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, vdiReq)
 
 	if err != nil {
 		return nil, err
@@ -193,7 +214,11 @@ func (c *Client) GetVDIs(vdiReq VDI) ([]VDI, error) {
 }
 
 func (c *Client) GetVDI(vdiReq VDI) (VDI, error) {
-	obj, err := c.FindFromGetAllObjects(vdiReq)
+	return c.GetVDIContext(context.Background(), vdiReq)
+}
+
+func (c *Client) GetVDIContext(ctx context.Context, vdiReq VDI) (VDI, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, vdiReq)
 
 	if err != nil {
 		return VDI{}, err
@@ -214,7 +239,11 @@ func (c *Client) GetVDI(vdiReq VDI) (VDI, error) {
 }
 
 func (c *Client) GetParentVDI(vbd VBD) (VDI, error) {
-	obj, err := c.FindFromGetAllObjects(VDI{
+	return c.GetParentVDIContext(context.Background(), vbd)
+}
+
+func (c *Client) GetParentVDIContext(ctx context.Context, vbd VBD) (VDI, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, VDI{
 		VDIId: vbd.VDI,
 	})
 
@@ -238,6 +267,10 @@ func (c *Client) GetParentVDI(vbd VBD) (VDI, error) {
 }
 
 func (c *Client) CreateDisk(vm Vm, d Disk) (string, error) {
+	return c.CreateDiskContext(context.Background(), vm, d)
+}
+
+func (c *Client) CreateDiskContext(ctx context.Context, vm Vm, d Disk) (string, error) {
 	var id string
 	params := map[string]interface{}{
 		"name": d.NameLabel,
@@ -245,7 +278,7 @@ func (c *Client) CreateDisk(vm Vm, d Disk) (string, error) {
 		"sr":   d.SrId,
 		"vm":   vm.Id,
 	}
-	err := c.Call("disk.create", params, &id)
+	err := c.CallContext(ctx, "disk.create", params, &id)
 
 	return id, err
 }
@@ -255,35 +288,43 @@ DeleteDisk detaches the specified disk from the given virtual machine if it is a
 and then deletes the disk's VDI. Returns an error if any operation fails.
 */
 func (c *Client) DeleteDisk(vm Vm, d Disk) error {
+	return c.DeleteDiskContext(context.Background(), vm, d)
+}
+
+func (c *Client) DeleteDiskContext(ctx context.Context, vm Vm, d Disk) error {
 	var success bool
 	if d.Attached {
 		disconnectParams := map[string]interface{}{
 			"id": d.Id,
 		}
-		err := c.Call("vbd.disconnect", disconnectParams, &success)
+		err := c.CallContext(ctx, "vbd.disconnect", disconnectParams, &success)
 
 		if err != nil {
 			return err
 		}
 	}
 
-	return c.DeleteVDI(d.VDIId)
+	return c.DeleteVDIContext(ctx, d.VDIId)
 }
 
 var notFoundState string = "NotFound"
 
 func (c *Client) DeleteVDI(id string) error {
+	return c.DeleteVDIContext(context.Background(), id)
+}
+
+func (c *Client) DeleteVDIContext(ctx context.Context, id string) error {
 	var success bool
 	params := map[string]interface{}{
 		"id": id,
 	}
 
-	err := c.Call("vdi.delete", params, &success)
+	err := c.CallContext(ctx, "vdi.delete", params, &success)
 	if err != nil {
 		return err
 	}
 	refreshFn := func() (result interface{}, state string, err error) {
-		vdi, err := c.GetVDI(VDI{
+		vdi, err := c.GetVDIContext(ctx, VDI{
 			VDIId: id,
 		})
 
@@ -300,64 +341,92 @@ func (c *Client) DeleteVDI(id string) error {
 		Timeout: time.Minute,
 		logger:  c.logger,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	return err
 }
 
 func (c *Client) ConnectDisk(d Disk) error {
+	return c.ConnectDiskContext(context.Background(), d)
+}
+
+func (c *Client) ConnectDiskContext(ctx context.Context, d Disk) error {
 	var success bool
 	params := map[string]interface{}{
 		"id": d.Id,
 	}
-	return c.Call("vbd.connect", params, &success)
+	return c.CallContext(ctx, "vbd.connect", params, &success)
 }
 
 func (c *Client) DisconnectDisk(d Disk) error {
+	return c.DisconnectDiskContext(context.Background(), d)
+}
+
+func (c *Client) DisconnectDiskContext(ctx context.Context, d Disk) error {
 	var success bool
 	params := map[string]interface{}{
 		"id": d.Id,
 	}
-	return c.Call("vbd.disconnect", params, &success)
+	return c.CallContext(ctx, "vbd.disconnect", params, &success)
 }
 
 func (c *Client) UpdateVDI(d Disk) error {
+	return c.UpdateVDIContext(context.Background(), d)
+}
+
+func (c *Client) UpdateVDIContext(ctx context.Context, d Disk) error {
 	var success bool
 	params := map[string]interface{}{
 		"id":               d.VDIId,
 		"name_description": d.NameDescription,
 		"name_label":       d.NameLabel,
 	}
-	return c.Call("vdi.set", params, &success)
+	return c.CallContext(ctx, "vdi.set", params, &success)
 }
 
 func (c *Client) ResizeVDI(d Disk) error {
+	return c.ResizeVDIContext(context.Background(), d)
+}
+
+func (c *Client) ResizeVDIContext(ctx context.Context, d Disk) error {
 	var success bool
 	params := map[string]interface{}{
 		"id":   d.VDIId,
 		"size": d.Size,
 	}
-	return c.Call("vdi.set", params, &success)
+	return c.CallContext(ctx, "vdi.set", params, &success)
 }
 
 func (c *Client) EjectCd(id string) error {
+	return c.EjectCdContext(context.Background(), id)
+}
+
+func (c *Client) EjectCdContext(ctx context.Context, id string) error {
 	var success bool
 	params := map[string]interface{}{
 		"id": id,
 	}
-	return c.Call("vm.ejectCd", params, &success)
+	return c.CallContext(ctx, "vm.ejectCd", params, &success)
 }
 
 func (c *Client) InsertCd(vmId, cdId string) error {
+	return c.InsertCdContext(context.Background(), vmId, cdId)
+}
+
+func (c *Client) InsertCdContext(ctx context.Context, vmId, cdId string) error {
 	var success bool
 	params := map[string]interface{}{
 		"id":    vmId,
 		"cd_id": cdId,
 	}
-	return c.Call("vm.insertCd", params, &success)
+	return c.CallContext(ctx, "vm.insertCd", params, &success)
 }
 
-// #nosec G704 -- ignoring this as migration to v2 SDK is in progress
 func (c *Client) CreateVDI(vdiReq CreateVDIReq) (VDI, error) {
+	return c.CreateVDIContext(context.Background(), vdiReq)
+}
+
+// #nosec G704 -- ignoring this as migration to v2 SDK is in progress
+func (c *Client) CreateVDIContext(ctx context.Context, vdiReq CreateVDIReq) (VDI, error) {
 	file, err := os.Open(vdiReq.Filepath)
 	if err != nil {
 		return VDI{}, err
@@ -381,7 +450,7 @@ func (c *Client) CreateVDI(vdiReq CreateVDIReq) (VDI, error) {
 	}
 
 	contentType := "application/octet-stream"
-	req, err := http.NewRequest("POST", reqURL.String(), file)
+	req, err := http.NewRequestWithContext(ctx, "POST", reqURL.String(), file)
 
 	if err != nil {
 		return VDI{}, err
@@ -418,7 +487,7 @@ func (c *Client) CreateVDI(vdiReq CreateVDIReq) (VDI, error) {
 		return VDI{}, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	return c.GetVDI(VDI{
+	return c.GetVDIContext(ctx, VDI{
 		VDIId: vdiResponse.Id,
 	})
 }
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
@@ -31,7 +32,11 @@ func (v VIF) Compare(obj interface{}) bool {
 }
 
 func (c *Client) GetVIFs(vm *Vm) ([]VIF, error) {
-	obj, err := c.FindFromGetAllObjects(VIF{VmId: vm.Id})
+	return c.GetVIFsContext(context.Background(), vm)
+}
+
+func (c *Client) GetVIFsContext(ctx context.Context, vm *Vm) ([]VIF, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, VIF{VmId: vm.Id})
 
 	if _, ok := err.(NotFound); ok {
 		return []VIF{}, nil
@@ -49,8 +54,12 @@ func (c *Client) GetVIFs(vm *Vm) ([]VIF, error) {
 }
 
 func (c *Client) GetVIF(vifReq *VIF) (*VIF, error) {
+	return c.GetVIFContext(context.Background(), vifReq)
+}
 
-	obj, err := c.FindFromGetAllObjects(VIF{
+func (c *Client) GetVIFContext(ctx context.Context, vifReq *VIF) (*VIF, error) {
+
+	obj, err := c.FindFromGetAllObjectsContext(ctx, VIF{
 		Id:         vifReq.Id,
 		MacAddress: vifReq.MacAddress,
 	})
@@ -68,6 +77,10 @@ func (c *Client) GetVIF(vifReq *VIF) (*VIF, error) {
 }
 
 func (c *Client) CreateVIF(vm *Vm, vif *VIF) (*VIF, error) {
+	return c.CreateVIFContext(context.Background(), vm, vif)
+}
+
+func (c *Client) CreateVIFContext(ctx context.Context, vm *Vm, vif *VIF) (*VIF, error) {
 
 	var id string
 	params := map[string]interface{}{
@@ -77,49 +90,61 @@ func (c *Client) CreateVIF(vm *Vm, vif *VIF) (*VIF, error) {
 	if vif.MacAddress != "" {
 		params["mac"] = vif.MacAddress
 	}
-	err := c.Call("vm.createInterface", params, &id)
+	err := c.CallContext(ctx, "vm.createInterface", params, &id)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return c.GetVIF(&VIF{Id: id})
+	return c.GetVIFContext(ctx, &VIF{Id: id})
 }
 
 func (c *Client) ConnectVIF(vifReq *VIF) (err error) {
-	vif, err := c.GetVIF(vifReq)
+	return c.ConnectVIFContext(context.Background(), vifReq)
+}
+
+func (c *Client) ConnectVIFContext(ctx context.Context, vifReq *VIF) (err error) {
+	vif, err := c.GetVIFContext(ctx, vifReq)
 
 	if err != nil {
 		return
 	}
 	var success bool
-	err = c.Call("vif.connect", map[string]interface{}{
+	err = c.CallContext(ctx, "vif.connect", map[string]interface{}{
 		"id": vif.Id,
 	}, &success)
 	return
 }
 
 func (c *Client) DisconnectVIF(vifReq *VIF) (err error) {
-	vif, err := c.GetVIF(vifReq)
+	return c.DisconnectVIFContext(context.Background(), vifReq)
+}
+
+func (c *Client) DisconnectVIFContext(ctx context.Context, vifReq *VIF) (err error) {
+	vif, err := c.GetVIFContext(ctx, vifReq)
 
 	if err != nil {
 		return
 	}
 
 	var success bool
-	err = c.Call("vif.disconnect", map[string]interface{}{
+	err = c.CallContext(ctx, "vif.disconnect", map[string]interface{}{
 		"id": vif.Id,
 	}, &success)
 	return
 }
 
 func (c *Client) DeleteVIF(vifReq *VIF) (err error) {
+	return c.DeleteVIFContext(context.Background(), vifReq)
+}
+
+func (c *Client) DeleteVIFContext(ctx context.Context, vifReq *VIF) (err error) {
 	var vif *VIF
 
 	// This is a request that is looking the VIF
 	// up by macaddress and needs to lookup the ID first.
 	if vifReq.Id == "" {
-		vif, err = c.GetVIF(vifReq)
+		vif, err = c.GetVIFContext(ctx, vifReq)
 
 		if err != nil {
 			return err
@@ -128,7 +153,7 @@ func (c *Client) DeleteVIF(vifReq *VIF) (err error) {
 		vif = vifReq
 	}
 
-	err = c.DisconnectVIF(vif)
+	err = c.DisconnectVIFContext(ctx, vif)
 
 	if err != nil {
 		return err
@@ -138,7 +163,7 @@ func (c *Client) DeleteVIF(vifReq *VIF) (err error) {
 		"id": vif.Id,
 	}
 	var result bool
-	err = c.Call("vif.delete", params, &result)
+	err = c.CallContext(ctx, "vif.delete", params, &result)
 	c.logger.Debug("Calling vif.delete received err", "error", err)
 
 	if err != nil {
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -231,14 +232,19 @@ func (v Vm) Compare(obj interface{}) bool {
 }
 
 func (c *Client) SuspendVm(id string) error {
-	return c.changeVmState(id, "suspend", []string{SuspendedPowerState}, []string{RunningPowerState}, 2*time.Minute)
+	return c.SuspendVmContext(context.Background(), id)
 }
 
-func (c *Client) changeVmState(id, action string, target, pending []string, timeout time.Duration) error {
+func (c *Client) SuspendVmContext(ctx context.Context, id string) error {
+	return c.changeVmState(ctx, id, "suspend", []string{SuspendedPowerState}, []string{RunningPowerState}, 2*time.Minute)
+}
+
+func (c *Client) changeVmState(ctx context.Context, id, action string, target, pending []string,
+	timeout time.Duration) error {
 	// PV drivers are necessary for the XO api to issue a graceful shutdown.
 	// See https://github.com/terra-farm/terraform-provider-xenorchestra/issues/220
 	// for more details.
-	if err := c.waitForPVDriversDetected(id); err != nil {
+	if err := c.waitForPVDriversDetected(ctx, id); err != nil {
 		return fmt.Errorf("failed to gracefully %s vm (%s) since PV drivers were never detected", action, id)
 	}
 
@@ -246,12 +252,12 @@ func (c *Client) changeVmState(id, action string, target, pending []string, time
 		"id": id,
 	}
 	var success bool
-	err := c.Call(fmt.Sprintf("vm.%s", action), params, &success)
+	err := c.CallContext(ctx, fmt.Sprintf("vm.%s", action), params, &success)
 
 	if err != nil {
 		return err
 	}
-	return c.waitForVmState(
+	return c.waitForVmState(ctx,
 		id,
 		StateChangeConf{
 			Pending: pending,
@@ -262,11 +268,19 @@ func (c *Client) changeVmState(id, action string, target, pending []string, time
 }
 
 func (c *Client) PauseVm(id string) error {
-	return c.changeVmState(id, "pause", []string{PausedPowerState}, []string{RunningPowerState}, 2*time.Minute)
+	return c.PauseVmContext(context.Background(), id)
+}
+
+func (c *Client) PauseVmContext(ctx context.Context, id string) error {
+	return c.changeVmState(ctx, id, "pause", []string{PausedPowerState}, []string{RunningPowerState}, 2*time.Minute)
 }
 
 func (c *Client) CreateVm(vmReq Vm, createTime time.Duration) (*Vm, error) {
-	tmpl, err := c.GetTemplate(Template{
+	return c.CreateVmContext(context.Background(), vmReq, createTime)
+}
+
+func (c *Client) CreateVmContext(ctx context.Context, vmReq Vm, createTime time.Duration) (*Vm, error) {
+	tmpl, err := c.GetTemplateContext(ctx, Template{
 		Id: vmReq.Template,
 	})
 
@@ -287,7 +301,7 @@ func (c *Client) CreateVm(vmReq Vm, createTime time.Duration) (*Vm, error) {
 	vdis := []interface{}{}
 	disks := vmReq.Disks
 	templateDiskCount := tmpl[0].getDiskCount()
-	tmplVBDs, err := c.GetTemplateVBDs(tmpl[0])
+	tmplVBDs, err := c.GetTemplateVBDsContext(ctx, tmpl[0])
 	if err != nil {
 		return nil, fmt.Errorf("cannot create VM from template: '%s': %w", tmpl[0].Id, err)
 	}
@@ -327,7 +341,7 @@ func (c *Client) CreateVm(vmReq Vm, createTime time.Duration) (*Vm, error) {
 		} else {
 			// Remove existing disks from the template that are not in the new VM.
 			// Fetch related VDI to provide SR and size information.
-			vdi, err := c.GetVDI(VDI{
+			vdi, err := c.GetVDIContext(ctx, VDI{
 				VDIId: vbd.VDI,
 			})
 			if err != nil {
@@ -450,7 +464,7 @@ func (c *Client) CreateVm(vmReq Vm, createTime time.Duration) (*Vm, error) {
 	}
 	c.logger.Debug("VM params for vm.create", "param", params)
 	var vmId string
-	err = c.Call("vm.create", params, &vmId)
+	err = c.CallContext(ctx, "vm.create", params, &vmId)
 
 	if err != nil {
 		return nil, err
@@ -462,7 +476,7 @@ func (c *Client) CreateVm(vmReq Vm, createTime time.Duration) (*Vm, error) {
 	}
 
 	// Set dynamic memory after VM creation
-	vm, err := c.GetVm(Vm{Id: vmId})
+	vm, err := c.GetVmContext(ctx, Vm{Id: vmId})
 	if err != nil {
 		return nil, err
 	}
@@ -472,7 +486,7 @@ func (c *Client) CreateVm(vmReq Vm, createTime time.Duration) (*Vm, error) {
 	}
 
 	var success bool
-	err = c.Call("vm.set", otherParams, &success)
+	err = c.CallContext(ctx, "vm.set", otherParams, &success)
 
 	if err != nil {
 		return nil, err
@@ -480,19 +494,19 @@ func (c *Client) CreateVm(vmReq Vm, createTime time.Duration) (*Vm, error) {
 
 	bootAfterCreate := params["bootAfterCreate"].(bool)
 	if !bootAfterCreate && vmReq.PowerState == RunningPowerState {
-		err = c.StartVm(vmId)
+		err = c.StartVmContext(ctx, vmId)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	err = c.waitForModifyVm(vmId, vmReq.PowerState, vmReq.WaitForIps, createTime)
+	err = c.waitForModifyVm(ctx, vmId, vmReq.PowerState, vmReq.WaitForIps, createTime)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return c.GetVm(
+	return c.GetVmContext(ctx,
 		Vm{
 			Id: vmId,
 		},
@@ -511,6 +525,10 @@ func createVdiMap(disk Disk) map[string]interface{} {
 }
 
 func (c *Client) UpdateVm(vmReq Vm) (*Vm, error) {
+	return c.UpdateVmContext(context.Background(), vmReq)
+}
+
+func (c *Client) UpdateVmContext(ctx context.Context, vmReq Vm) (*Vm, error) {
 	params := map[string]interface{}{
 		"id":                vmReq.Id,
 		"name_label":        vmReq.NameLabel,
@@ -598,7 +616,7 @@ func (c *Client) UpdateVm(vmReq Vm) (*Vm, error) {
 	c.logger.Debug("VM params for vm.set", "params", params)
 
 	var success bool
-	err := c.Call("vm.set", params, &success)
+	err := c.CallContext(ctx, "vm.set", params, &success)
 
 	if err != nil {
 		return nil, err
@@ -606,23 +624,31 @@ func (c *Client) UpdateVm(vmReq Vm) (*Vm, error) {
 
 	// TODO: This is a poor way to ensure that terraform will see the updated
 	// attributes after calling vm.set. Need to investigate a better way to detect this.
-	time.Sleep(25 * time.Second)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(25 * time.Second):
+	}
 
-	return c.GetVm(vmReq)
+	return c.GetVmContext(ctx, vmReq)
 }
 
 func (c *Client) StartVm(id string) error {
+	return c.StartVmContext(context.Background(), id)
+}
+
+func (c *Client) StartVmContext(ctx context.Context, id string) error {
 	params := map[string]interface{}{
 		"id": id,
 	}
 	var success bool
 	// TODO: This can block indefinitely before we get to the waitForVmHalt
-	err := c.Call("vm.start", params, &success)
+	err := c.CallContext(ctx, "vm.start", params, &success)
 
 	if err != nil {
 		return err
 	}
-	return c.waitForVmState(
+	return c.waitForVmState(ctx,
 		id,
 		StateChangeConf{
 			Pending: []string{HaltedPowerState},
@@ -633,10 +659,18 @@ func (c *Client) StartVm(id string) error {
 }
 
 func (c *Client) HaltVm(id string) error {
-	return c.changeVmState(id, "stop", []string{HaltedPowerState}, []string{RunningPowerState}, 2*time.Minute)
+	return c.HaltVmContext(context.Background(), id)
+}
+
+func (c *Client) HaltVmContext(ctx context.Context, id string) error {
+	return c.changeVmState(ctx, id, "stop", []string{HaltedPowerState}, []string{RunningPowerState}, 2*time.Minute)
 }
 
 func (c *Client) DeleteVm(id string) error {
+	return c.DeleteVmContext(context.Background(), id)
+}
+
+func (c *Client) DeleteVmContext(ctx context.Context, id string) error {
 	params := map[string]interface{}{
 		"id": id,
 	}
@@ -645,11 +679,15 @@ func (c *Client) DeleteVm(id string) error {
 	// type in order to be backwards compatible while fixing this bug. See
 	// GitHub issue 196 for more details.
 	var reply interface{}
-	return c.Call("vm.delete", params, &reply)
+	return c.CallContext(ctx, "vm.delete", params, &reply)
 }
 
 func (c *Client) GetVm(vmReq Vm) (*Vm, error) {
-	obj, err := c.FindFromGetAllObjects(vmReq)
+	return c.GetVmContext(context.Background(), vmReq)
+}
+
+func (c *Client) GetVmContext(ctx context.Context, vmReq Vm) (*Vm, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, vmReq)
 
 	if err != nil {
 		return nil, err
@@ -665,7 +703,11 @@ func (c *Client) GetVm(vmReq Vm) (*Vm, error) {
 }
 
 func (c *Client) GetVms(vm Vm) ([]Vm, error) {
-	obj, err := c.FindFromGetAllObjects(vm)
+	return c.GetVmsContext(context.Background(), vm)
+}
+
+func (c *Client) GetVmsContext(ctx context.Context, vm Vm) ([]Vm, error) {
+	obj, err := c.FindFromGetAllObjectsContext(ctx, vm)
 	if err != nil {
 		return []Vm{}, err
 	}
@@ -675,11 +717,15 @@ func (c *Client) GetVms(vm Vm) ([]Vm, error) {
 }
 
 func (c *Client) EjectVmCd(vm *Vm) error {
+	return c.EjectVmCdContext(context.Background(), vm)
+}
+
+func (c *Client) EjectVmCdContext(ctx context.Context, vm *Vm) error {
 	params := map[string]interface{}{
 		"id": vm.Id,
 	}
 	var result bool
-	err := c.Call("vm.ejectCd", params, &result)
+	err := c.CallContext(ctx, "vm.ejectCd", params, &result)
 	if err != nil || !result {
 		return err
 	}
@@ -687,8 +733,13 @@ func (c *Client) EjectVmCd(vm *Vm) error {
 }
 
 func GetVmPowerState(c *Client, id string) func() (result interface{}, state string, err error) {
+	return GetVmPowerStateContext(context.Background(), c, id)
+}
+
+func GetVmPowerStateContext(ctx context.Context, c *Client, id string) func() (
+	result interface{}, state string, err error) {
 	return func() (interface{}, string, error) {
-		vm, err := c.GetVm(Vm{Id: id})
+		vm, err := c.GetVmContext(ctx, Vm{Id: id})
 
 		if err != nil {
 			return vm, "", err
@@ -698,9 +749,9 @@ func GetVmPowerState(c *Client, id string) func() (result interface{}, state str
 	}
 }
 
-func (c *Client) waitForPVDriversDetected(id string) error {
+func (c *Client) waitForPVDriversDetected(ctx context.Context, id string) error {
 	refreshFn := func() (result interface{}, state string, err error) {
-		vm, err := c.GetVm(Vm{Id: id})
+		vm, err := c.GetVmContext(ctx, Vm{Id: id})
 
 		if err != nil {
 			return vm, "", err
@@ -715,17 +766,18 @@ func (c *Client) waitForPVDriversDetected(id string) error {
 		Timeout: 2 * time.Minute,
 		logger:  c.logger,
 	}
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForStateContext(ctx)
 	return err
 }
 
-func (c *Client) waitForVmState(id string, stateConf StateChangeConf) error {
-	stateConf.Refresh = GetVmPowerState(c, id)
-	_, err := stateConf.WaitForState()
+func (c *Client) waitForVmState(ctx context.Context, id string, stateConf StateChangeConf) error {
+	stateConf.Refresh = GetVmPowerStateContext(ctx, c, id)
+	_, err := stateConf.WaitForStateContext(ctx)
 	return err
 }
 
-func waitForPowerStateReached(c *Client, vmId, desiredPowerState string, timeout time.Duration) error {
+func waitForPowerStateReached(ctx context.Context, c *Client, vmId, desiredPowerState string,
+	timeout time.Duration) error {
 	var pending []string
 	target := desiredPowerState
 	switch desiredPowerState {
@@ -737,7 +789,7 @@ func waitForPowerStateReached(c *Client, vmId, desiredPowerState string, timeout
 		return fmt.Errorf("invalid VM power state requested: %s", desiredPowerState)
 	}
 	refreshFn := func() (result interface{}, state string, err error) {
-		vm, err := c.GetVm(Vm{Id: vmId})
+		vm, err := c.GetVmContext(ctx, Vm{Id: vmId})
 
 		if err != nil {
 			return vm, "", err
@@ -752,7 +804,7 @@ func waitForPowerStateReached(c *Client, vmId, desiredPowerState string, timeout
 		Timeout: timeout,
 		logger:  c.logger,
 	}
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForStateContext(ctx)
 	return err
 }
 
@@ -762,10 +814,11 @@ type ifaceMatchCheck struct {
 	ifaceAddrs []string
 }
 
-func waitForIPAssignment(c *Client, vmId string, waitForIps map[string]string, timeout time.Duration) error {
+func waitForIPAssignment(ctx context.Context, c *Client, vmId string, waitForIps map[string]string,
+	timeout time.Duration) error {
 	var lastResult ifaceMatchCheck
 	refreshFn := func() (result interface{}, state string, err error) {
-		vm, err := c.GetVm(Vm{Id: vmId})
+		vm, err := c.GetVmContext(ctx, Vm{Id: vmId})
 
 		if err != nil {
 			return vm, "", err
@@ -826,7 +879,7 @@ func waitForIPAssignment(c *Client, vmId string, waitForIps map[string]string, t
 		Timeout: timeout,
 		logger:  c.logger,
 	}
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForStateContext(ctx)
 	if _, ok := err.(*TimeoutError); ok {
 		return fmt.Errorf("network[%s] never converged to the following cidr: %s, addresses: %s failed to match",
 			lastResult.ifaceIdx, lastResult.cidrRange, lastResult.ifaceAddrs)
@@ -834,12 +887,12 @@ func waitForIPAssignment(c *Client, vmId string, waitForIps map[string]string, t
 	return err
 }
 
-func (c *Client) waitForModifyVm(id string, desiredPowerState string, waitForIps map[string]string,
+func (c *Client) waitForModifyVm(ctx context.Context, id string, desiredPowerState string, waitForIps map[string]string,
 	timeout time.Duration) error {
 	if len(waitForIps) == 0 {
-		return waitForPowerStateReached(c, id, desiredPowerState, timeout)
+		return waitForPowerStateReached(ctx, c, id, desiredPowerState, timeout)
 	}
-	return waitForIPAssignment(c, id, waitForIps, timeout)
+	return waitForIPAssignment(ctx, c, id, waitForIps, timeout)
 }
 
 func FindOrCreateVmForTests(vm *Vm, poolId, srId, templateName, tag string) {
@@ -0,0 +1,198 @@
+package cloudinit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+func TestRender(t *testing.T) {
+	vars := payloads.CloudConfigVariables{
+		Name:   "web",
+		Index:  3,
+		Custom: map[string]string{"domain": "example.org"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"name", "hostname: {name}", "hostname: web"},
+		{"index", "hostname: vm-%-{index}", "hostname: vm-3-3"},
+		{"custom variable", "fqdn: {name}.{domain}", "fqdn: web.example.org"},
+		{"escaped placeholders", `runcmd: [date +\%s, echo \{name}]`, "runcmd: [date +%s, echo {name}]"},
+		{"other backslashes are kept", `path: C:\temp\{other}`, `path: C:\temp\{other}`},
+		{"unknown keys are kept", "users: {default: true} {missing}", "users: {default: true} {missing}"},
+		{"unterminated brace", "key: {name", "key: {name"},
+		{"trailing backslash", `value\`, `value\`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Render(tt.template, vars))
+		})
+	}
+}
+
+func TestValidateUserData(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid cloud-config",
+			data: "#cloud-config\nhostname: web\nusers:\n  - default\n  - name: admin\n" +
+				"packages: [nginx]\nwrite_files:\n  - path: /etc/motd\n    content: hello\n",
+		},
+		{name: "comments only", data: "#cloud-config\n# nothing yet\n"},
+		{name: "shell script", data: "#!/bin/sh\necho hello\n"},
+		{name: "include", data: "#include\nhttps://example.org/user-data\n"},
+		{name: "gzip", data: "\x1f\x8b\x08\x00"},
+		{name: "multipart MIME", data: "Content-Type: multipart/mixed; boundary=\"x\"\n"},
+		{name: "archive", data: "#cloud-config-archive\n- type: text/cloud-config\n  content: '#cloud-config'\n"},
+		{name: "empty", data: "  \n", wantErr: "empty document"},
+		{name: "missing header", data: "hostname: web\n", wantErr: "must start with #cloud-config"},
+		{name: "single part MIME", data: "Content-Type: text/plain\n", wantErr: "must be multipart"},
+		{name: "invalid YAML", data: "#cloud-config\nhostname: [web\n", wantErr: "invalid user data: yaml"},
+		{name: "not a mapping", data: "#cloud-config\n- web\n", wantErr: "document: must be a mapping, got list"},
+		{
+			name:    "wrong types",
+			data:    "#cloud-config\nhostname: [web]\nruncmd: reboot\nusers:\n  - groups: sudo\n",
+			wantErr: "hostname: must be a string, got list",
+		},
+		{name: "write_files without path", data: "#cloud-config\nwrite_files:\n  - content: x\n",
+			wantErr: "write_files[0].path: is required"},
+		{name: "multiple documents", data: "#cloud-config\na: 1\n---\nb: 2\n", wantErr: "multiple YAML documents"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUserData(tt.data)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("reports every problem", func(t *testing.T) {
+		err := ValidateUserData("#cloud-config\nhostname: [web]\nruncmd: reboot\nusers:\n  - groups: sudo\n")
+		assert.ErrorContains(t, err, "hostname: must be a string")
+		assert.ErrorContains(t, err, "runcmd: must be a list")
+		assert.ErrorContains(t, err, "users[0].name: is required")
+	})
+}
+
+func TestValidateNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid version 1",
+			data: `version: 1
+config:
+  - type: physical
+    name: eth0
+    mac_address: "c0:d6:9f:2c:e8:80"
+    subnets:
+      - type: static
+        address: 192.168.1.10/24
+        gateway: 192.168.1.1
+        dns_nameservers: [1.1.1.1]
+      - type: dhcp6
+  - type: vlan
+    name: eth0.10
+    vlan_link: eth0
+    vlan_id: 10
+  - type: nameserver
+    address: 9.9.9.9
+  - type: route
+    destination: 10.0.0.0/8
+    gateway: 192.168.1.254
+`,
+		},
+		{name: "disabled", data: "network:\n  version: 1\n  config: disabled\n"},
+		{
+			name: "valid version 2",
+			data: `network:
+  version: 2
+  renderer: networkd
+  ethernets:
+    eth0:
+      match:
+        macaddress: "c0:d6:9f:2c:e8:80"
+      set-name: eth0
+      dhcp4: no
+      addresses: [192.168.1.10/24, "2001:db8::10/64"]
+      gateway4: 192.168.1.1
+      nameservers:
+        addresses: [1.1.1.1]
+        search: [example.org]
+      routes:
+        - to: default
+          via: 192.168.1.1
+    eth1:
+      dhcp4: true
+  bonds:
+    bond0:
+      interfaces: [eth1]
+  vlans:
+    vlan10:
+      id: 10
+      link: bond0
+`,
+		},
+		{name: "empty", data: "", wantErr: "empty document"},
+		{name: "missing version", data: "config: []\n", wantErr: "version: is required"},
+		{name: "unsupported version", data: "version: 3\n", wantErr: "unsupported version 3"},
+		{name: "v1 missing config", data: "version: 1\n", wantErr: "config: is required"},
+		{name: "v1 unknown type", data: "version: 1\nconfig:\n  - type: tunnel\n", wantErr: "config[0].type: unknown type"},
+		{
+			name:    "v1 static without address",
+			data:    "version: 1\nconfig:\n  - type: physical\n    name: eth0\n    subnets:\n      - type: static\n",
+			wantErr: "config[0].subnets[0].address: is required",
+		},
+		{
+			name:    "v1 invalid vlan id",
+			data:    "version: 1\nconfig:\n  - type: vlan\n    name: v\n    vlan_link: eth0\n    vlan_id: 5000\n",
+			wantErr: "config[0].vlan_id: must be between 0 and 4094",
+		},
+		{
+			name:    "v2 invalid address",
+			data:    "version: 2\nethernets:\n  eth0:\n    addresses: [192.168.1.10]\n",
+			wantErr: `ethernets.eth0.addresses[0]: invalid CIDR "192.168.1.10"`,
+		},
+		{
+			name:    "v2 undefined vlan link",
+			data:    "network:\n  version: 2\n  vlans:\n    vlan10:\n      id: 10\n      link: eth9\n",
+			wantErr: `network.vlans.vlan10.link: references undefined device "eth9"`,
+		},
+		{
+			name:    "v2 unknown key",
+			data:    "version: 2\nethernet:\n  eth0: {}\n",
+			wantErr: "ethernet: unknown key",
+		},
+		{
+			name:    "v2 invalid boolean",
+			data:    "version: 2\nethernets:\n  eth0:\n    dhcp4: maybe\n",
+			wantErr: "ethernets.eth0.dhcp4: must be a boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNetworkConfig(tt.data)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package cloudinit renders and validates the cloud-init templates stored in
+// Xen Orchestra, locally and before they reach a VM.
+package cloudinit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+// Render substitutes the placeholders of template with vars, see
+// payloads.CloudConfigVariables for the syntax.
+func Render(template string, vars payloads.CloudConfigVariables) string {
+	index := strconv.Itoa(vars.Index)
+
+	var out strings.Builder
+	out.Grow(len(template))
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch c {
+		case '\\':
+			// Only placeholders are escaped, other backslashes are kept.
+			if next := template[i+1:]; strings.HasPrefix(next, "%") {
+				out.WriteByte('%')
+				i++
+				continue
+			} else if _, length, ok := placeholder(next, vars); ok {
+				out.WriteString(next[:length])
+				i += length
+				continue
+			}
+			out.WriteByte(c)
+		case '%':
+			out.WriteString(index)
+		case '{':
+			if value, length, ok := placeholder(template[i:], vars); ok {
+				out.WriteString(value)
+				i += length - 1
+				continue
+			}
+			out.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String()
+}
+
+// placeholder reports whether s starts with a known {key} placeholder, and
+// returns its value and length. Unknown keys are not placeholders so that YAML
+// flow mappings such as {a: b} are left untouched.
+func placeholder(s string, vars payloads.CloudConfigVariables) (string, int, bool) {
+	if !strings.HasPrefix(s, "{") {
+		return "", 0, false
+	}
+	end := strings.IndexByte(s, '}')
+	if end < 0 {
+		return "", 0, false
+	}
+
+	switch key := s[1:end]; key {
+	case "name":
+		return vars.Name, end + 1, true
+	case "index":
+		return strconv.Itoa(vars.Index), end + 1, true
+	default:
+		value, ok := vars.Custom[key]
+		return value, end + 1, ok
+	}
+}
@@ -0,0 +1,503 @@
+package cloudinit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// userDataFormats are the first lines of the user-data formats cloud-init
+// handles without parsing them as cloud-config YAML.
+// See https://cloudinit.readthedocs.io/en/latest/explanation/format.html
+var userDataFormats = []string{
+	"#!",
+	"#include",
+	"#include-once",
+	"#cloud-boothook",
+	"#part-handler",
+	"## template: jinja",
+}
+
+// ValidateUserData checks that data is a user-data document cloud-init can
+// handle. Cloud-config documents are parsed and their most common keys are
+// type checked; scripts, includes and boot hooks are accepted as is.
+func ValidateUserData(data string) error {
+	if strings.TrimSpace(data) == "" {
+		return errors.New("invalid user data: empty document")
+	}
+	// Gzip compressed and MIME multi-part documents can't be inspected further.
+	if strings.HasPrefix(data, "\x1f\x8b") {
+		return nil
+	}
+	if strings.HasPrefix(data, "Content-Type:") {
+		if !strings.Contains(data, "multipart/") {
+			return errors.New("invalid user data: MIME documents must be multipart")
+		}
+		return nil
+	}
+
+	firstLine, _, _ := strings.Cut(data, "\n")
+	firstLine = strings.TrimRight(firstLine, " \t\r")
+	switch {
+	case firstLine == "#cloud-config":
+	case firstLine == "#cloud-config-archive":
+		return checkYAML("user data", data, func(v *validator, doc any) {
+			v.list("", doc)
+		})
+	case slices.ContainsFunc(userDataFormats, func(prefix string) bool { return strings.HasPrefix(firstLine, prefix) }):
+		return nil
+	default:
+		return fmt.Errorf("invalid user data: unknown format %q, cloud-config documents must start with #cloud-config",
+			firstLine)
+	}
+
+	return checkYAML("user data", data, validateCloudConfig)
+}
+
+// ValidateNetworkConfig checks that data is a valid cloud-init network
+// configuration, either version 1 or version 2 (netplan), optionally nested
+// under a top-level "network" key.
+// See https://cloudinit.readthedocs.io/en/latest/reference/network-config.html
+func ValidateNetworkConfig(data string) error {
+	if strings.TrimSpace(data) == "" {
+		return errors.New("invalid network config: empty document")
+	}
+
+	return checkYAML("network config", data, func(v *validator, doc any) {
+		root, ok := v.mapping("", doc)
+		if !ok {
+			return
+		}
+		path := ""
+		if network, ok := root["network"]; ok && len(root) == 1 {
+			path = "network"
+			if root, ok = v.mapping(path, network); !ok {
+				return
+			}
+		}
+
+		switch version := root["version"]; version {
+		case 1:
+			validateNetworkV1(v, path, root)
+		case 2:
+			validateNetworkV2(v, path, root)
+		case nil:
+			v.errorf(join(path, "version"), "is required")
+		default:
+			v.errorf(join(path, "version"), "unsupported version %v, expected 1 or 2", version)
+		}
+	})
+}
+
+func checkYAML(kind, data string, validate func(v *validator, doc any)) error {
+	var doc any
+	decoder := yaml.NewDecoder(strings.NewReader(data))
+	// A document holding only comments decodes as io.EOF.
+	if err := decoder.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid %s: %w", kind, err)
+	}
+	if err := decoder.Decode(new(any)); err == nil {
+		return fmt.Errorf("invalid %s: multiple YAML documents", kind)
+	}
+
+	v := &validator{}
+	if doc != nil {
+		validate(v, doc)
+	}
+	if len(v.problems) > 0 {
+		return fmt.Errorf("invalid %s: %w", kind, errors.Join(v.problems...))
+	}
+	return nil
+}
+
+func validateCloudConfig(v *validator, doc any) {
+	root, ok := v.mapping("", doc)
+	if !ok {
+		return
+	}
+
+	for _, key := range []string{"hostname", "fqdn", "timezone", "locale"} {
+		if value, ok := root[key]; ok {
+			v.str(key, value)
+		}
+	}
+	for _, key := range []string{"packages", "runcmd", "bootcmd", "ssh_authorized_keys"} {
+		if value, ok := root[key]; ok {
+			v.list(key, value)
+		}
+	}
+	if users, ok := root["users"]; ok {
+		list, _ := v.list("users", users)
+		for i, user := range list {
+			// "default" designates the distribution default user.
+			if user == "default" {
+				continue
+			}
+			path := fmt.Sprintf("users[%d]", i)
+			if user, ok := v.mapping(path, user); ok {
+				v.requireStr(path, user, "name")
+			}
+		}
+	}
+	if files, ok := root["write_files"]; ok {
+		list, _ := v.list("write_files", files)
+		for i, file := range list {
+			path := fmt.Sprintf("write_files[%d]", i)
+			if file, ok := v.mapping(path, file); ok {
+				v.requireStr(path, file, "path")
+			}
+		}
+	}
+}
+
+var (
+	networkV1Subnets = []string{
+		"dhcp", "dhcp4", "dhcp6", "static", "static6", "manual",
+		"ipv6_dhcpv6-stateful", "ipv6_dhcpv6-stateless", "ipv6_slaac",
+	}
+	networkV2Sections = []string{"ethernets", "bonds", "bridges", "vlans", "wifis"}
+)
+
+func validateNetworkV1(v *validator, path string, root map[string]any) {
+	configPath := join(path, "config")
+	config, ok := root["config"]
+	if !ok {
+		v.errorf(configPath, "is required")
+		return
+	}
+	if config == "disabled" {
+		return
+	}
+
+	entries, _ := v.list(configPath, config)
+	for i, entry := range entries {
+		entryPath := fmt.Sprintf("%s[%d]", configPath, i)
+		entry, ok := v.mapping(entryPath, entry)
+		if !ok {
+			continue
+		}
+
+		switch entryType := entry["type"]; entryType {
+		case "physical":
+			v.requireStr(entryPath, entry, "name")
+		case "bond":
+			v.requireStr(entryPath, entry, "name")
+			v.requireList(entryPath, entry, "bond_interfaces")
+		case "bridge":
+			v.requireStr(entryPath, entry, "name")
+			v.requireList(entryPath, entry, "bridge_interfaces")
+		case "vlan":
+			v.requireStr(entryPath, entry, "name")
+			v.requireStr(entryPath, entry, "vlan_link")
+			if id, ok := v.require(entryPath, entry, "vlan_id"); ok {
+				v.vlanID(join(entryPath, "vlan_id"), id)
+			}
+		case "nameserver":
+			if addresses, ok := entry["address"]; ok {
+				v.ipList(join(entryPath, "address"), addresses)
+			}
+		case "route":
+			if destination, ok := v.require(entryPath, entry, "destination"); ok {
+				v.prefix(join(entryPath, "destination"), destination)
+			}
+			if gateway, ok := v.require(entryPath, entry, "gateway"); ok {
+				v.ip(join(entryPath, "gateway"), gateway)
+			}
+		case nil:
+			v.errorf(join(entryPath, "type"), "is required")
+		default:
+			v.errorf(join(entryPath, "type"), "unknown type %v", entryType)
+		}
+
+		if mtu, ok := entry["mtu"]; ok {
+			v.integer(join(entryPath, "mtu"), mtu)
+		}
+		if subnets, ok := entry["subnets"]; ok {
+			validateNetworkV1Subnets(v, join(entryPath, "subnets"), subnets)
+		}
+	}
+}
+
+func validateNetworkV1Subnets(v *validator, path string, value any) {
+	subnets, _ := v.list(path, value)
+	for i, subnet := range subnets {
+		subnetPath := fmt.Sprintf("%s[%d]", path, i)
+		subnet, ok := v.mapping(subnetPath, subnet)
+		if !ok {
+			continue
+		}
+
+		subnetType, _ := subnet["type"].(string)
+		switch {
+		case subnetType == "":
+			v.errorf(join(subnetPath, "type"), "is required")
+		case !slices.Contains(networkV1Subnets, subnetType):
+			v.errorf(join(subnetPath, "type"), "unknown subnet type %q", subnetType)
+		case subnetType == "static" || subnetType == "static6":
+			if address, ok := v.require(subnetPath, subnet, "address"); ok {
+				v.address(join(subnetPath, "address"), address)
+			}
+		}
+		if gateway, ok := subnet["gateway"]; ok {
+			v.ip(join(subnetPath, "gateway"), gateway)
+		}
+		if nameservers, ok := subnet["dns_nameservers"]; ok {
+			v.ipList(join(subnetPath, "dns_nameservers"), nameservers)
+		}
+	}
+}
+
+func validateNetworkV2(v *validator, path string, root map[string]any) {
+	for _, key := range slices.Sorted(maps.Keys(root)) {
+		if key != "version" && key != "renderer" && !slices.Contains(networkV2Sections, key) {
+			v.errorf(join(path, key), "unknown key")
+		}
+	}
+	if renderer, ok := root["renderer"]; ok && renderer != "networkd" && renderer != "NetworkManager" {
+		v.errorf(join(path, "renderer"), "must be networkd or NetworkManager")
+	}
+
+	devices := make(map[string]bool)
+	for _, section := range networkV2Sections {
+		if value, ok := root[section]; ok {
+			if value, ok := v.mapping(join(path, section), value); ok {
+				for id := range value {
+					devices[id] = true
+				}
+			}
+		}
+	}
+
+	for _, section := range networkV2Sections {
+		sectionValue, ok := root[section].(map[string]any)
+		if !ok {
+			continue
+		}
+		// Sorted so that problems are reported in a stable order.
+		for _, id := range slices.Sorted(maps.Keys(sectionValue)) {
+			devicePath := join(path, section, id)
+			device, ok := v.mapping(devicePath, sectionValue[id])
+			if !ok {
+				continue
+			}
+			validateNetworkV2Device(v, devicePath, device)
+
+			switch section {
+			case "bonds", "bridges":
+				interfaces, _ := v.requireList(devicePath, device, "interfaces")
+				for i, name := range interfaces {
+					if name, ok := name.(string); !ok || !devices[name] {
+						v.errorf(fmt.Sprintf("%s[%d]", join(devicePath, "interfaces"), i),
+							"references undefined device %v", name)
+					}
+				}
+			case "vlans":
+				if vlanID, ok := v.require(devicePath, device, "id"); ok {
+					v.vlanID(join(devicePath, "id"), vlanID)
+				}
+				if link, ok := v.requireStr(devicePath, device, "link"); ok && !devices[link] {
+					v.errorf(join(devicePath, "link"), "references undefined device %q", link)
+				}
+			}
+		}
+	}
+}
+
+func validateNetworkV2Device(v *validator, path string, device map[string]any) {
+	for _, key := range []string{"dhcp4", "dhcp6", "optional"} {
+		if value, ok := device[key]; ok {
+			v.boolean(join(path, key), value)
+		}
+	}
+	if mtu, ok := device["mtu"]; ok {
+		v.integer(join(path, "mtu"), mtu)
+	}
+	if addresses, ok := device["addresses"]; ok {
+		list, _ := v.list(join(path, "addresses"), addresses)
+		for i, address := range list {
+			v.prefix(fmt.Sprintf("%s[%d]", join(path, "addresses"), i), address)
+		}
+	}
+	for _, key := range []string{"gateway4", "gateway6"} {
+		if gateway, ok := device[key]; ok {
+			v.ip(join(path, key), gateway)
+		}
+	}
+	if nameservers, ok := device["nameservers"]; ok {
+		if nameservers, ok := v.mapping(join(path, "nameservers"), nameservers); ok {
+			if addresses, ok := nameservers["addresses"]; ok {
+				v.ipList(join(path, "nameservers", "addresses"), addresses)
+			}
+			if search, ok := nameservers["search"]; ok {
+				v.list(join(path, "nameservers", "search"), search)
+			}
+		}
+	}
+	if routes, ok := device["routes"]; ok {
+		list, _ := v.list(join(path, "routes"), routes)
+		for i, route := range list {
+			routePath := fmt.Sprintf("%s[%d]", join(path, "routes"), i)
+			if route, ok := v.mapping(routePath, route); ok {
+				if to, ok := v.require(routePath, route, "to"); ok && to != "default" {
+					v.prefix(join(routePath, "to"), to)
+				}
+				if via, ok := v.require(routePath, route, "via"); ok {
+					v.ip(join(routePath, "via"), via)
+				}
+			}
+		}
+	}
+}
+
+// validator accumulates the problems found in a YAML document, each prefixed
+// by the path of the offending value.
+type validator struct {
+	problems []error
+}
+
+func (v *validator) errorf(path, format string, args ...any) {
+	if path == "" {
+		path = "document"
+	}
+	v.problems = append(v.problems, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) mapping(path string, value any) (map[string]any, bool) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		v.errorf(path, "must be a mapping, got %s", typeName(value))
+	}
+	return m, ok
+}
+
+func (v *validator) list(path string, value any) ([]any, bool) {
+	l, ok := value.([]any)
+	if !ok {
+		v.errorf(path, "must be a list, got %s", typeName(value))
+	}
+	return l, ok
+}
+
+func (v *validator) str(path string, value any) (string, bool) {
+	s, ok := value.(string)
+	if !ok {
+		v.errorf(path, "must be a string, got %s", typeName(value))
+	}
+	return s, ok
+}
+
+func (v *validator) integer(path string, value any) (int, bool) {
+	i, ok := value.(int)
+	if !ok {
+		v.errorf(path, "must be an integer, got %s", typeName(value))
+	}
+	return i, ok
+}
+
+// boolean also accepts the YAML 1.1 booleans used by netplan.
+func (v *validator) boolean(path string, value any) {
+	switch value {
+	case true, false, "yes", "no", "on", "off", "true", "false":
+	default:
+		v.errorf(path, "must be a boolean, got %v", value)
+	}
+}
+
+func (v *validator) require(path string, m map[string]any, key string) (any, bool) {
+	value, ok := m[key]
+	if !ok {
+		v.errorf(join(path, key), "is required")
+	}
+	return value, ok
+}
+
+func (v *validator) requireStr(path string, m map[string]any, key string) (string, bool) {
+	value, ok := v.require(path, m, key)
+	if !ok {
+		return "", false
+	}
+	return v.str(join(path, key), value)
+}
+
+func (v *validator) requireList(path string, m map[string]any, key string) ([]any, bool) {
+	value, ok := v.require(path, m, key)
+	if !ok {
+		return nil, false
+	}
+	return v.list(join(path, key), value)
+}
+
+func (v *validator) vlanID(path string, value any) {
+	if id, ok := v.integer(path, value); ok && (id < 0 || id > 4094) {
+		v.errorf(path, "must be between 0 and 4094, got %d", id)
+	}
+}
+
+func (v *validator) ip(path string, value any) {
+	if s, ok := v.str(path, value); ok {
+		if _, err := netip.ParseAddr(s); err != nil {
+			v.errorf(path, "invalid IP address %q", s)
+		}
+	}
+}
+
+func (v *validator) ipList(path string, value any) {
+	// A single address is accepted as well.
+	if _, ok := value.(string); ok {
+		v.ip(path, value)
+		return
+	}
+	list, _ := v.list(path, value)
+	for i, address := range list {
+		v.ip(fmt.Sprintf("%s[%d]", path, i), address)
+	}
+}
+
+func (v *validator) prefix(path string, value any) {
+	if s, ok := v.str(path, value); ok {
+		if _, err := netip.ParsePrefix(s); err != nil {
+			v.errorf(path, "invalid CIDR %q", s)
+		}
+	}
+}
+
+// address accepts an IP address, with or without a prefix length.
+func (v *validator) address(path string, value any) {
+	s, ok := v.str(path, value)
+	switch {
+	case !ok:
+	case strings.Contains(s, "/"):
+		v.prefix(path, value)
+	default:
+		v.ip(path, value)
+	}
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "mapping"
+	case []any:
+		return "list"
+	case string:
+		return "string"
+	case int:
+		return "integer"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func join(parts ...string) string {
+	return strings.Join(slices.DeleteFunc(parts, func(part string) bool { return part == "" }), ".")
+}
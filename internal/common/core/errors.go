@@ -1,6 +1,9 @@
 package core
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // ClientError is a type for errors that occur in the client package.
 // It is a string that can be formatted with arguments. It avoids to
@@ -29,3 +32,26 @@ const (
 func (e ClientError) WithArgs(args ...any) error {
 	return fmt.Errorf(string(e), args...)
 }
+
+// XOErrorMessage returns the message of an error reported by XO in a result,
+// which is either an object or a string.
+func XOErrorMessage(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return message
+	}
+	var object struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return string(raw)
+	}
+	if object.Code != "" && object.Code != object.Message {
+		return object.Code + ": " + object.Message
+	}
+	return object.Message
+}
@@ -0,0 +1,35 @@
+// Package redact removes the credentials from the values which are logged.
+package redact
+
+import (
+	"maps"
+	"regexp"
+)
+
+// Redacted replaces the values which must not be logged.
+const Redacted = "[REDACTED]"
+
+// sensitiveParams are the params whose value is never logged.
+var sensitiveParams = []string{"password", "oldPassword", "newPassword", "token", "secret"}
+
+var urlCredentials = regexp.MustCompile(`^([a-z0-9+]+://)[^@/]*@`)
+
+// URL removes the credentials from the URL of a remote.
+func URL(u string) string {
+	return urlCredentials.ReplaceAllString(u, "${1}"+Redacted+"@")
+}
+
+// Params returns a copy of the params of a request without their credentials.
+func Params(params map[string]any) map[string]any {
+	logged := maps.Clone(params)
+	for _, name := range sensitiveParams {
+		if _, ok := logged[name]; ok {
+			logged[name] = Redacted
+		}
+	}
+	// The URLs of the remotes contain their credentials.
+	if u, ok := logged["url"].(string); ok {
+		logged["url"] = URL(u)
+	}
+	return logged
+}
@@ -0,0 +1,31 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParams(t *testing.T) {
+	params := map[string]any{
+		"email":    "ci@example.com",
+		"password": "secret",
+		"token":    "abc",
+		"secret":   "def",
+	}
+
+	logged := Params(params)
+
+	assert.Equal(t, map[string]any{
+		"email":    "ci@example.com",
+		"password": "[REDACTED]",
+		"token":    "[REDACTED]",
+		"secret":   "[REDACTED]",
+	}, logged)
+	assert.Equal(t, "secret", params["password"], "the actual params must not be modified")
+
+	logged = Params(map[string]any{"url": "nfs://nas:/backups"})
+	assert.Equal(t, "nfs://nas:/backups", logged["url"])
+	logged = Params(map[string]any{"url": "s3://key:secret@s3.example.com/bucket"})
+	assert.Equal(t, "s3://[REDACTED]@s3.example.com/bucket", logged["url"])
+}
@@ -0,0 +1,250 @@
+// Package cron parses the cron patterns of XO schedules and computes their
+// next runs, without XO.
+//
+// Like XO, patterns have 5 fields (minute, hour, day of month, month, day of
+// week), optionally preceded by a seconds field. Fields accept "*", values,
+// ranges ("1-5"), steps ("*/15", "0-30/10") and lists ("1,15"). Months and days
+// of week accept their English three-letter names, and Sunday is both 0 and 7.
+// When both the day of month and the day of week are restricted, a day matching
+// either of them matches, as in Vixie cron.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded so that timezones can be validated on hosts without a zoneinfo database.
+	_ "time/tzdata"
+)
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	secondField = field{name: "second", min: 0, max: 59}
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	dowField = field{name: "day of week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+// maxYears bounds the search of the next run, for patterns like "0 0 30 2 *"
+// which never match.
+const maxYears = 5
+
+// Schedule is a parsed cron pattern in a timezone.
+type Schedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// domStar and dowStar are set when the field is "*", which does not
+	// restrict the days.
+	domStar, dowStar bool
+	location         *time.Location
+}
+
+// Parse parses a cron pattern in a timezone. An empty timezone is UTC.
+func Parse(pattern string, timezone string) (*Schedule, error) {
+	location, err := LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInLocation(pattern, location)
+}
+
+// ParseInLocation parses a cron pattern in a location.
+func ParseInLocation(pattern string, location *time.Location) (*Schedule, error) {
+	fields := strings.Fields(pattern)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron pattern %q: expected 5 or 6 fields, got %d", pattern, len(fields))
+	}
+
+	s := &Schedule{location: location}
+	var errs []error
+	parse := func(value string, f field) uint64 {
+		bits, err := parseField(value, f)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return bits
+	}
+	s.second = parse(fields[0], secondField)
+	s.minute = parse(fields[1], minuteField)
+	s.hour = parse(fields[2], hourField)
+	s.dom = parse(fields[3], domField)
+	s.month = parse(fields[4], monthField)
+	s.dow = parse(fields[5], dowField)
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid cron pattern %q: %w", pattern, err)
+	}
+
+	// Sunday is both 0 and 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[3] == "*"
+	s.dowStar = fields[5] == "*"
+	return s, nil
+}
+
+// LoadLocation returns the location of an IANA timezone, e.g. "Europe/Paris".
+// An empty timezone is UTC.
+func LoadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	// time.LoadLocation also accepts "Local", which is meaningless for XO.
+	if timezone == "Local" {
+		return nil, fmt.Errorf("invalid timezone %q", timezone)
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	return location, nil
+}
+
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		b, err := parseRange(part, f)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+// parseRange parses "*", "v", "a-b" with an optional "/step".
+func parseRange(value string, f field) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(value, "/")
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("%s: invalid step %q", f.name, stepPart)
+		}
+	}
+
+	start, end := f.min, f.max
+	if rangePart != "*" {
+		low, high, isRange := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(low, f); err != nil {
+			return 0, err
+		}
+		switch {
+		case isRange:
+			if end, err = parseValue(high, f); err != nil {
+				return 0, err
+			}
+			if end < start {
+				return 0, fmt.Errorf("%s: invalid range %q", f.name, rangePart)
+			}
+		case !hasStep:
+			// A single value, "a/step" means from a to the maximum.
+			end = start
+		}
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+	return bits, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(value, name) {
+			// Months start at 1, days of week at 0.
+			return i + f.min, nil
+		}
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", f.name, value)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s: %d out of range [%d, %d]", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first run strictly after t, in the timezone of the
+// schedule, or the zero time if the pattern does not match in the next years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + maxYears
+
+	// Each field is incremented until it matches, resetting the smaller fields;
+	// when a field wraps, the larger fields must be checked again.
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+	for !has(s.month, int(t.Month())) {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for !has(s.hour, t.Hour()) {
+		next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		// Across a DST change, the next hour may be the same wall clock hour.
+		if !next.After(t) {
+			next = next.Add(time.Hour)
+		}
+		t = next
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for !has(s.minute, t.Minute()) {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	for !has(s.second, t.Second()) {
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+	return t
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(bits uint64, i int) bool {
+	return bits&(1<<i) != 0
+}
@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		timezone string
+		wantErr  string
+	}{
+		{pattern: "* * * *", wantErr: "expected 5 or 6 fields"},
+		{pattern: "60 * * * *", wantErr: "minute: 60 out of range"},
+		{pattern: "* 24 * * *", wantErr: "hour: 24 out of range"},
+		{pattern: "* * 0 * *", wantErr: "day of month: 0 out of range"},
+		{pattern: "* * * foo *", wantErr: `month: invalid value "foo"`},
+		{pattern: "*/0 * * * *", wantErr: `minute: invalid step "0"`},
+		{pattern: "* 10-2 * * *", wantErr: `hour: invalid range "10-2"`},
+		{pattern: "0 2 * * *", timezone: "Mars/Olympus", wantErr: `invalid timezone "Mars/Olympus"`},
+		{pattern: "0 2 * * *", timezone: "Local", wantErr: `invalid timezone "Local"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.timezone, func(t *testing.T) {
+			_, err := Parse(tt.pattern, tt.timezone)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		pattern  string
+		timezone string
+		from     time.Time
+		want     []time.Time
+	}{
+		{
+			name:    "every 15 minutes",
+			pattern: "*/15 * * * *",
+			from:    time.Date(2024, 3, 1, 10, 7, 30, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 10, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "strictly after",
+			pattern: "0 2 * * *",
+			from:    time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC),
+			want:    []time.Time{time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "seconds and names",
+			pattern: "30 0 22 * * mon-fri",
+			from:    time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC), // Friday
+			want: []time.Time{
+				time.Date(2024, 3, 4, 22, 0, 30, 0, time.UTC),
+				time.Date(2024, 3, 5, 22, 0, 30, 0, time.UTC),
+			},
+		},
+		{
+			name:    "day of month or day of week",
+			pattern: "0 0 1 * 0",
+			from:    time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), // 1st
+				time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), // Sunday
+				time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC), // Sunday
+			},
+		},
+		{
+			name:    "sunday as 7",
+			pattern: "0 12 * * 7",
+			from:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:    []time.Time{time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "leap day",
+			pattern: "0 0 29 feb *",
+			from:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:    []time.Time{time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "timezone",
+			pattern:  "0 2 * * *",
+			timezone: "Europe/Paris",
+			from:     time.Date(2024, 6, 30, 22, 0, 0, 0, time.UTC), // midnight in Paris
+			want:     []time.Time{time.Date(2024, 7, 1, 2, 0, 0, 0, paris)},
+		},
+		{
+			name:     "hour skipped by DST",
+			pattern:  "30 2 * * *",
+			timezone: "Europe/Paris",
+			from:     time.Date(2024, 3, 30, 12, 0, 0, 0, paris),
+			want: []time.Time{
+				time.Date(2024, 4, 1, 2, 30, 0, 0, paris),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.pattern, tt.timezone)
+			require.NoError(t, err)
+
+			from := tt.from
+			for _, want := range tt.want {
+				next := s.Next(from)
+				assert.True(t, want.Equal(next), "want %s, got %s", want, next)
+				from = next
+			}
+		})
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	s, err := Parse("0 0 30 2 *", "")
+	require.NoError(t, err)
+
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
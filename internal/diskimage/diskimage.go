@@ -0,0 +1,255 @@
+// Package diskimage converts virtual disk images between the formats accepted
+// by the XO API (raw and VHD) and the ones produced by common image pipelines
+// (QCOW2 and VMDK). Everything is done in Go, without external tools.
+package diskimage
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+const sectorSize = 512
+
+// ErrUnsupported is returned for images using features this package does not
+// handle, such as backing files or encryption.
+var ErrUnsupported = errors.New("unsupported disk image")
+
+// Image is a random-access view of the virtual disk stored in an image file.
+type Image interface {
+	// ReadAt reads the virtual disk content. Unallocated ranges read as zeroes.
+	io.ReaderAt
+	// Size returns the virtual size of the disk in bytes.
+	Size() int64
+	// Allocated reports whether the range [offset, offset+length) of the
+	// virtual disk contains allocated data.
+	Allocated(offset, length int64) (bool, error)
+}
+
+// Convertible reports whether format has to be converted locally because the
+// XO API does not handle it.
+func Convertible(format payloads.VDIFormat) bool {
+	return format == payloads.VDIFormatQCOW2 || format == payloads.VDIFormatVMDK
+}
+
+// Open parses the image file r of the given size and format.
+func Open(format payloads.VDIFormat, r io.ReaderAt, size int64) (Image, error) {
+	switch format {
+	case payloads.VDIFormatRaw:
+		return &rawImage{r: r, size: size}, nil
+	case payloads.VDIFormatVHD:
+		return openVHD(r, size)
+	case payloads.VDIFormatQCOW2:
+		return openQCOW2(r, size)
+	case payloads.VDIFormatVMDK:
+		return openVMDK(r, size)
+	default:
+		return nil, fmt.Errorf("%w: format %q", ErrUnsupported, format)
+	}
+}
+
+// FromRaw converts a raw disk stream of the given virtual size to format.
+// The conversion runs in a goroutine: the returned reader must be closed to
+// release it if it is not consumed until EOF.
+func FromRaw(format payloads.VDIFormat, raw io.Reader, size int64) (io.ReadCloser, error) {
+	var write func(io.Writer, io.Reader, int64) error
+	switch format {
+	case payloads.VDIFormatRaw:
+		return io.NopCloser(io.LimitReader(raw, size)), nil
+	case payloads.VDIFormatQCOW2:
+		write = writeQCOW2
+	case payloads.VDIFormatVMDK:
+		write = writeVMDK
+	default:
+		return nil, fmt.Errorf("%w: cannot convert raw to %q", ErrUnsupported, format)
+	}
+	if size < 0 || size%sectorSize != 0 {
+		return nil, fmt.Errorf("virtual size %d is not a multiple of %d", size, sectorSize)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(write(pw, raw, size))
+	}()
+	return pr, nil
+}
+
+type rawImage struct {
+	r    io.ReaderAt
+	size int64
+}
+
+func (i *rawImage) ReadAt(p []byte, off int64) (int, error) {
+	return io.NewSectionReader(i.r, 0, i.size).ReadAt(p, off)
+}
+
+func (i *rawImage) Size() int64 {
+	return i.size
+}
+
+func (i *rawImage) Allocated(int64, int64) (bool, error) {
+	return true, nil
+}
+
+// readVirtual implements io.ReaderAt for images split in fixed-size units
+// (clusters, grains, blocks): readUnit fills buf with the part of unit index
+// starting at offset within the unit.
+func readVirtual(p []byte, off, size, unitSize int64,
+	readUnit func(buf []byte, index, offset int64) error) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= size {
+		return 0, io.EOF
+	}
+
+	n := 0
+	for n < len(p) && off < size {
+		index, inUnit := off/unitSize, off%unitSize
+		chunk := min(int64(len(p)-n), unitSize-inUnit, size-off)
+		if err := readUnit(p[n:n+int(chunk)], index, inUnit); err != nil {
+			return n, err
+		}
+		n += int(chunk)
+		off += chunk
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// anyUnit reports whether allocated returns true for any of the units
+// covering [offset, offset+length).
+func anyUnit(offset, length, unitSize int64, allocated func(index int64) (bool, error)) (bool, error) {
+	if length <= 0 {
+		return false, nil
+	}
+	for index := offset / unitSize; index <= (offset+length-1)/unitSize; index++ {
+		ok, err := allocated(index)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// readFull reads len(p) bytes at off, failing on short reads.
+func readFull(r io.ReaderAt, p []byte, off int64) error {
+	n, err := r.ReadAt(p, off)
+	if n == len(p) {
+		return nil
+	}
+	if err == nil || errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("reading %d bytes at offset %d: %w", len(p), off, err)
+}
+
+func isZero(p []byte) bool {
+	for _, b := range p {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
+
+func alignUp(a, b int64) int64 {
+	return ceilDiv(a, b) * b
+}
+
+// errShortRaw wraps an error reading the raw stream given to FromRaw, which
+// must be exactly as long as the virtual disk.
+func errShortRaw(err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("reading raw disk: %w", err)
+}
+
+// zeroes writes n zero bytes to w.
+func zeroes(w io.Writer, n int64) error {
+	_, err := io.CopyN(w, zeroReader{}, n)
+	return err
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func (zeroReader) ReadAt(p []byte, _ int64) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// concatReader is the random-access concatenation of several segments.
+type concatReader struct {
+	segments []segment
+	size     int64
+}
+
+type segment struct {
+	r      io.ReaderAt
+	offset int64
+	length int64
+}
+
+func (c *concatReader) add(r io.ReaderAt, length int64) {
+	c.segments = append(c.segments, segment{r: r, offset: c.size, length: length})
+	c.size += length
+}
+
+func (c *concatReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+
+	// Find the first segment ending after off.
+	i, _ := slices.BinarySearchFunc(c.segments, off, func(s segment, off int64) int {
+		return cmp.Compare(s.offset+s.length, off+1)
+	})
+
+	n := 0
+	for ; n < len(p) && i < len(c.segments); i++ {
+		s := c.segments[i]
+		inSegment := off + int64(n) - s.offset
+		chunk := p[n:min(len(p), n+int(s.length-inSegment))]
+		if err := readFull(s.r, chunk, inSegment); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// countingWriter tracks the position in the output stream.
+type countingWriter struct {
+	w   io.Writer
+	pos int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.pos += int64(n)
+	return n, err
+}
+
+// pad writes zeroes up to the next multiple of align.
+func (c *countingWriter) pad(align int64) error {
+	return zeroes(c, alignUp(c.pos, align)-c.pos)
+}
@@ -0,0 +1,213 @@
+package diskimage
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+// testDisk generates a sparse raw disk whose size is not a multiple of the
+// cluster, grain or block sizes, with data at the start, in the middle and in
+// the last sector.
+func testDisk(t *testing.T) []byte {
+	t.Helper()
+	disk := make([]byte, 5<<20+3*sectorSize)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, region := range []struct{ offset, length int }{
+		{0, 4096},
+		{3<<20 + 1000, 70000},
+		{len(disk) - sectorSize, sectorSize},
+	} {
+		for i := range region.length {
+			disk[region.offset+i] = byte(rng.UintN(255) + 1)
+		}
+	}
+	return disk
+}
+
+func convert(t *testing.T, format payloads.VDIFormat, disk []byte) []byte {
+	t.Helper()
+	r, err := FromRaw(format, bytes.NewReader(disk), int64(len(disk)))
+	require.NoError(t, err)
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return out
+}
+
+func readAll(t *testing.T, img Image) []byte {
+	t.Helper()
+	data, err := io.ReadAll(io.NewSectionReader(img, 0, img.Size()))
+	require.NoError(t, err)
+	return data
+}
+
+func TestQCOW2(t *testing.T) {
+	disk := testDisk(t)
+
+	t.Run("round trip", func(t *testing.T) {
+		image := convert(t, payloads.VDIFormatQCOW2, disk)
+		assert.Equal(t, []byte("QFI\xfb"), image[:4])
+
+		img, err := Open(payloads.VDIFormatQCOW2, bytes.NewReader(image), int64(len(image)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(disk)), img.Size())
+		assert.Equal(t, disk, readAll(t, img))
+	})
+
+	t.Run("compressed and unallocated clusters", func(t *testing.T) {
+		image := convert(t, payloads.VDIFormatQCOW2, disk)
+		be := binary.BigEndian
+		l2Offset := be.Uint64(image[be.Uint64(image[40:]):]) & qcow2OffsetMask
+
+		// Compress the first cluster at the end of the file and unallocate
+		// the second one.
+		var compressed bytes.Buffer
+		fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+		require.NoError(t, err)
+		_, err = fw.Write(disk[:1<<qcow2WriteCluster])
+		require.NoError(t, err)
+		require.NoError(t, fw.Close())
+
+		offset := uint64(len(image))
+		sectors := uint64(ceilDiv(int64(compressed.Len()), sectorSize))
+		image = append(image, compressed.Bytes()...)
+		image = append(image, make([]byte, int(sectors*sectorSize)-compressed.Len())...)
+		sizeShift := 62 - (qcow2WriteCluster - 8)
+		be.PutUint64(image[l2Offset:], qcow2FlagCompressed|(sectors-1)<<sizeShift|offset)
+		be.PutUint64(image[l2Offset+8:], 0)
+
+		expected := bytes.Clone(disk)
+		clear(expected[1<<qcow2WriteCluster : 2<<qcow2WriteCluster])
+
+		img, err := Open(payloads.VDIFormatQCOW2, bytes.NewReader(image), int64(len(image)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, readAll(t, img))
+
+		allocated, err := img.Allocated(1<<qcow2WriteCluster, 1<<qcow2WriteCluster)
+		require.NoError(t, err)
+		assert.False(t, allocated)
+		allocated, err = img.Allocated(0, 1)
+		require.NoError(t, err)
+		assert.True(t, allocated)
+	})
+
+	t.Run("backing file", func(t *testing.T) {
+		image := convert(t, payloads.VDIFormatQCOW2, disk)
+		binary.BigEndian.PutUint64(image[8:], 4096)
+
+		_, err := Open(payloads.VDIFormatQCOW2, bytes.NewReader(image), int64(len(image)))
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("invalid magic", func(t *testing.T) {
+		_, err := Open(payloads.VDIFormatQCOW2, bytes.NewReader(disk), int64(len(disk)))
+		assert.ErrorContains(t, err, "invalid QCOW2 magic")
+	})
+}
+
+func TestVMDK(t *testing.T) {
+	disk := testDisk(t)
+
+	t.Run("round trip skips zero grains", func(t *testing.T) {
+		image := convert(t, payloads.VDIFormatVMDK, disk)
+		assert.Equal(t, []byte("KDMV"), image[:4])
+		assert.Less(t, len(image), len(disk)/4)
+
+		img, err := Open(payloads.VDIFormatVMDK, bytes.NewReader(image), int64(len(image)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(disk)), img.Size())
+		assert.Equal(t, disk, readAll(t, img))
+
+		allocated, err := img.Allocated(1<<20, 1<<20)
+		require.NoError(t, err)
+		assert.False(t, allocated)
+	})
+
+	t.Run("descriptor only", func(t *testing.T) {
+		descriptor := make([]byte, sectorSize)
+		copy(descriptor, "# Disk DescriptorFile\nversion=1\n")
+
+		_, err := Open(payloads.VDIFormatVMDK, bytes.NewReader(descriptor), int64(len(descriptor)))
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+}
+
+func TestToVHD(t *testing.T) {
+	disk := testDisk(t)
+
+	for _, format := range []payloads.VDIFormat{payloads.VDIFormatQCOW2, payloads.VDIFormatVMDK} {
+		t.Run(string(format), func(t *testing.T) {
+			image := convert(t, format, disk)
+			src, err := Open(format, bytes.NewReader(image), int64(len(image)))
+			require.NoError(t, err)
+
+			r, size, err := ToVHD(src)
+			require.NoError(t, err)
+			vhd, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, size, int64(len(vhd)), "announced size must match the stream")
+
+			img, err := Open(payloads.VDIFormatVHD, bytes.NewReader(vhd), size)
+			require.NoError(t, err)
+			assert.Equal(t, disk, readAll(t, img))
+
+			// Random access, used to resume uploads.
+			chunk := make([]byte, 3<<20)
+			_, err = r.ReadAt(chunk, 1000)
+			require.NoError(t, err)
+			assert.Equal(t, vhd[1000:1000+len(chunk)], chunk)
+		})
+	}
+
+	t.Run("only allocated blocks are written", func(t *testing.T) {
+		image := convert(t, payloads.VDIFormatVMDK, disk)
+		src, err := Open(payloads.VDIFormatVMDK, bytes.NewReader(image), int64(len(image)))
+		require.NoError(t, err)
+
+		_, size, err := ToVHD(src)
+		require.NoError(t, err)
+		// Blocks 0, 1 and 2 hold data, blocks 3 and 4 don't.
+		assert.Equal(t, int64(vhdFooterSize+vhdHeaderSize+sectorSize+3*(vhdBitmapSize+vhdBlockSize)+vhdFooterSize), size)
+	})
+
+	t.Run("corrupted footer", func(t *testing.T) {
+		r, size, err := ToVHD(&rawImage{r: bytes.NewReader(disk), size: int64(len(disk))})
+		require.NoError(t, err)
+		vhd, err := io.ReadAll(r)
+		require.NoError(t, err)
+		vhd[size-vhdFooterSize+40]++
+
+		_, err = Open(payloads.VDIFormatVHD, bytes.NewReader(vhd), size)
+		assert.ErrorContains(t, err, "checksum mismatch")
+	})
+}
+
+func TestFromRaw(t *testing.T) {
+	t.Run("short stream", func(t *testing.T) {
+		r, err := FromRaw(payloads.VDIFormatQCOW2, bytes.NewReader(make([]byte, 1024)), 4096)
+		require.NoError(t, err)
+		_, err = io.ReadAll(r)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := FromRaw("vdi", bytes.NewReader(nil), 0)
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("close stops the conversion", func(t *testing.T) {
+		r, err := FromRaw(payloads.VDIFormatVMDK, zeroReader{}, 1<<30)
+		require.NoError(t, err)
+		_, err = r.Read(make([]byte, 16))
+		require.NoError(t, err)
+		assert.NoError(t, r.Close())
+	})
+}
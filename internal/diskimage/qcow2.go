@@ -0,0 +1,305 @@
+package diskimage
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// QCOW2 layout constants, see docs/interop/qcow2.txt in the QEMU sources.
+// All fields are big-endian.
+const (
+	qcow2Magic           = 0x514649fb // "QFI\xfb"
+	qcow2OffsetMask      = 0x00fffffffffffe00
+	qcow2FlagCopied      = 1 << 63
+	qcow2FlagCompressed  = 1 << 62
+	qcow2FlagZero        = 1 // version 3 only
+	qcow2IncompatDirty   = 1
+	qcow2WriteCluster    = 16 // cluster_bits of the images we write
+	qcow2MinClusterBits  = 9
+	qcow2MaxClusterBits  = 21
+	qcow2MaxL1Size       = 32 << 20
+	qcow2RefcountEntries = 1 << (qcow2WriteCluster - 1) // 16-bit refcounts
+)
+
+type qcow2Image struct {
+	r           io.ReaderAt
+	fileSize    int64
+	size        int64
+	clusterBits uint
+	clusterSize int64
+	l1          []uint64
+
+	// Cache of the last L2 table and decompressed cluster: reads are mostly
+	// sequential so this avoids reading the same metadata for every cluster.
+	mu           sync.Mutex
+	l2Offset     uint64
+	l2           []uint64
+	cachedEntry  uint64
+	cachedBuffer []byte
+}
+
+func openQCOW2(r io.ReaderAt, fileSize int64) (Image, error) {
+	header := make([]byte, 104)
+	if err := readFull(r, header[:72], 0); err != nil {
+		return nil, err
+	}
+	if binary.BigEndian.Uint32(header[0:]) != qcow2Magic {
+		return nil, fmt.Errorf("invalid QCOW2 magic %#x", header[:4])
+	}
+
+	version := binary.BigEndian.Uint32(header[4:])
+	switch version {
+	case 2:
+	case 3:
+		if err := readFull(r, header[72:], 72); err != nil {
+			return nil, err
+		}
+		if incompatible := binary.BigEndian.Uint64(header[72:]); incompatible&^qcow2IncompatDirty != 0 {
+			return nil, fmt.Errorf("%w: QCOW2 incompatible features %#x", ErrUnsupported, incompatible)
+		}
+	default:
+		return nil, fmt.Errorf("%w: QCOW2 version %d", ErrUnsupported, version)
+	}
+
+	if binary.BigEndian.Uint64(header[8:]) != 0 {
+		return nil, fmt.Errorf("%w: QCOW2 images with a backing file", ErrUnsupported)
+	}
+	if binary.BigEndian.Uint32(header[32:]) != 0 {
+		return nil, fmt.Errorf("%w: encrypted QCOW2 images", ErrUnsupported)
+	}
+	clusterBits := uint(binary.BigEndian.Uint32(header[20:]))
+	if clusterBits < qcow2MinClusterBits || clusterBits > qcow2MaxClusterBits {
+		return nil, fmt.Errorf("invalid QCOW2 cluster size 2^%d", clusterBits)
+	}
+
+	image := &qcow2Image{
+		r:           r,
+		fileSize:    fileSize,
+		size:        int64(binary.BigEndian.Uint64(header[24:])),
+		clusterBits: clusterBits,
+		clusterSize: 1 << clusterBits,
+	}
+
+	l1Size := int64(binary.BigEndian.Uint32(header[36:]))
+	if l1Size*8 > qcow2MaxL1Size || l1Size < ceilDiv(image.size, image.clusterSize*image.l2Entries()) {
+		return nil, fmt.Errorf("invalid QCOW2 L1 table size %d", l1Size)
+	}
+	raw := make([]byte, l1Size*8)
+	if err := readFull(r, raw, int64(binary.BigEndian.Uint64(header[40:]))); err != nil {
+		return nil, err
+	}
+	image.l1 = make([]uint64, l1Size)
+	for i := range image.l1 {
+		image.l1[i] = binary.BigEndian.Uint64(raw[i*8:])
+	}
+
+	return image, nil
+}
+
+func (i *qcow2Image) l2Entries() int64 {
+	return i.clusterSize / 8
+}
+
+// entry returns the L2 entry describing the given virtual cluster.
+// Callers must hold i.mu.
+func (i *qcow2Image) entry(cluster int64) (uint64, error) {
+	l1Entry := i.l1[cluster/i.l2Entries()] & qcow2OffsetMask
+	if l1Entry == 0 {
+		return 0, nil
+	}
+
+	if l1Entry != i.l2Offset || i.l2 == nil {
+		raw := make([]byte, i.clusterSize)
+		if err := readFull(i.r, raw, int64(l1Entry)); err != nil {
+			return 0, err
+		}
+		l2 := make([]uint64, i.l2Entries())
+		for j := range l2 {
+			l2[j] = binary.BigEndian.Uint64(raw[j*8:])
+		}
+		i.l2Offset, i.l2 = l1Entry, l2
+	}
+
+	return i.l2[cluster%i.l2Entries()], nil
+}
+
+// decompress returns the content of a compressed cluster.
+// Callers must hold i.mu.
+func (i *qcow2Image) decompress(entry uint64) ([]byte, error) {
+	if entry == i.cachedEntry && i.cachedBuffer != nil {
+		return i.cachedBuffer, nil
+	}
+
+	sizeShift := 62 - (i.clusterBits - 8)
+	offset := int64(entry & (1<<sizeShift - 1))
+	sectors := int64(entry>>sizeShift&(1<<(i.clusterBits-8)-1)) + 1
+	compressedSize := min(sectors*sectorSize-offset%sectorSize, i.fileSize-offset)
+	if compressedSize <= 0 {
+		return nil, fmt.Errorf("invalid QCOW2 compressed cluster at offset %d", offset)
+	}
+
+	compressed := make([]byte, compressedSize)
+	if err := readFull(i.r, compressed, offset); err != nil {
+		return nil, err
+	}
+	cluster := make([]byte, i.clusterSize)
+	if _, err := io.ReadFull(flate.NewReader(bytes.NewReader(compressed)), cluster); err != nil {
+		return nil, fmt.Errorf("decompressing QCOW2 cluster at offset %d: %w", offset, err)
+	}
+
+	i.cachedEntry, i.cachedBuffer = entry, cluster
+	return cluster, nil
+}
+
+func (i *qcow2Image) ReadAt(p []byte, off int64) (int, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return readVirtual(p, off, i.size, i.clusterSize, func(buf []byte, index, offset int64) error {
+		entry, err := i.entry(index)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case entry&qcow2FlagCompressed != 0:
+			cluster, err := i.decompress(entry)
+			if err != nil {
+				return err
+			}
+			copy(buf, cluster[offset:])
+			return nil
+		case entry&qcow2OffsetMask == 0 || entry&qcow2FlagZero != 0:
+			clear(buf)
+			return nil
+		default:
+			return readFull(i.r, buf, int64(entry&qcow2OffsetMask)+offset)
+		}
+	})
+}
+
+func (i *qcow2Image) Size() int64 {
+	return i.size
+}
+
+func (i *qcow2Image) Allocated(offset, length int64) (bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return anyUnit(offset, length, i.clusterSize, func(index int64) (bool, error) {
+		entry, err := i.entry(index)
+		if err != nil {
+			return false, err
+		}
+		if entry&qcow2FlagCompressed != 0 {
+			return true, nil
+		}
+		return entry&qcow2OffsetMask != 0 && entry&qcow2FlagZero == 0, nil
+	})
+}
+
+// writeQCOW2 writes a version 2 QCOW2 image of the raw stream. As the stream
+// can't be seeked, the metadata is written up front and every cluster is
+// allocated: the output is as large as the virtual disk.
+func writeQCOW2(out io.Writer, raw io.Reader, size int64) error {
+	const clusterSize = 1 << qcow2WriteCluster
+	const l2Entries = clusterSize / 8
+
+	dataClusters := ceilDiv(size, clusterSize)
+	l2Tables := ceilDiv(dataClusters, l2Entries)
+	l1Clusters := max(ceilDiv(l2Tables*8, clusterSize), 1)
+
+	// The refcount structures also need refcounts: iterate to a fixed point.
+	var refcountBlocks, refcountTableClusters, total int64
+	for {
+		total = 1 + refcountTableClusters + refcountBlocks + l1Clusters + l2Tables + dataClusters
+		blocks := ceilDiv(total, qcow2RefcountEntries)
+		tableClusters := ceilDiv(blocks*8, clusterSize)
+		if blocks == refcountBlocks && tableClusters == refcountTableClusters {
+			break
+		}
+		refcountBlocks, refcountTableClusters = blocks, tableClusters
+	}
+
+	refcountTableOffset := int64(clusterSize)
+	refcountBlocksOffset := refcountTableOffset + refcountTableClusters*clusterSize
+	l1Offset := refcountBlocksOffset + refcountBlocks*clusterSize
+	l2Offset := l1Offset + l1Clusters*clusterSize
+	dataOffset := l2Offset + l2Tables*clusterSize
+
+	bw := bufio.NewWriterSize(out, clusterSize)
+	w := &countingWriter{w: bw}
+	be := binary.BigEndian
+
+	header := make([]byte, 72)
+	be.PutUint32(header[0:], qcow2Magic)
+	be.PutUint32(header[4:], 2)
+	be.PutUint32(header[20:], qcow2WriteCluster)
+	be.PutUint64(header[24:], uint64(size))
+	be.PutUint32(header[36:], uint32(l2Tables))
+	be.PutUint64(header[40:], uint64(l1Offset))
+	be.PutUint64(header[48:], uint64(refcountTableOffset))
+	be.PutUint32(header[56:], uint32(refcountTableClusters))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if err := w.pad(clusterSize); err != nil {
+		return err
+	}
+
+	entry := make([]byte, 8)
+	for i := range refcountBlocks {
+		be.PutUint64(entry, uint64(refcountBlocksOffset+i*clusterSize))
+		if _, err := w.Write(entry); err != nil {
+			return err
+		}
+	}
+	if err := w.pad(clusterSize); err != nil {
+		return err
+	}
+
+	refcount := []byte{0, 1}
+	for range total {
+		if _, err := w.Write(refcount); err != nil {
+			return err
+		}
+	}
+	if err := w.pad(clusterSize); err != nil {
+		return err
+	}
+
+	for i := range l2Tables {
+		be.PutUint64(entry, uint64(l2Offset+i*clusterSize)|qcow2FlagCopied)
+		if _, err := w.Write(entry); err != nil {
+			return err
+		}
+	}
+	// An empty disk still gets its L1 cluster.
+	if err := zeroes(w, l2Offset-w.pos); err != nil {
+		return err
+	}
+
+	for i := range dataClusters {
+		be.PutUint64(entry, uint64(dataOffset+i*clusterSize)|qcow2FlagCopied)
+		if _, err := w.Write(entry); err != nil {
+			return err
+		}
+	}
+	if err := w.pad(clusterSize); err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(w, raw, size); err != nil {
+		return errShortRaw(err)
+	}
+	if err := w.pad(clusterSize); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
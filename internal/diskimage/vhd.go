@@ -0,0 +1,248 @@
+package diskimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// VHD layout constants, see the Microsoft "Virtual Hard Disk Image Format
+// Specification". All fields are big-endian.
+const (
+	vhdFooterSize    = 512
+	vhdHeaderSize    = 1024
+	vhdBlockSize     = 2 << 20
+	vhdBitmapSize    = sectorSize // one bit per sector of a 2 MiB block, padded to a sector
+	vhdUnusedEntry   = 0xFFFFFFFF
+	vhdMaxSize       = 2040 << 30
+	vhdDiskFixed     = 2
+	vhdDiskDynamic   = 3
+	vhdFooterCookie  = "conectix"
+	vhdHeaderCookie  = "cxsparse"
+	vhdFormatVersion = 0x00010000
+)
+
+// vhdEpoch is the reference of VHD timestamps.
+var vhdEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ToVHD returns a dynamic VHD stream of img and its exact length, so it can be
+// uploaded with a known Content-Length. Only the 2 MiB blocks that contain
+// allocated data are written. The stream also supports random access, which
+// allows resuming an interrupted upload.
+func ToVHD(img Image) (*io.SectionReader, int64, error) {
+	size := img.Size()
+	if size%sectorSize != 0 {
+		return nil, 0, fmt.Errorf("virtual size %d is not a multiple of %d", size, sectorSize)
+	}
+	if size > vhdMaxSize {
+		return nil, 0, fmt.Errorf("virtual size %d exceeds the VHD limit of %d", size, int64(vhdMaxSize))
+	}
+
+	blocks := ceilDiv(size, vhdBlockSize)
+	bat := bytes.Repeat([]byte{0xFF}, int(alignUp(blocks*4, sectorSize)))
+	bitmap := bytes.Repeat([]byte{0xFF}, vhdBitmapSize)
+	footer := vhdFooter(size)
+
+	var stream concatReader
+	stream.add(bytes.NewReader(footer), vhdFooterSize)
+	stream.add(bytes.NewReader(vhdDynamicHeader(blocks)), vhdHeaderSize)
+	stream.add(bytes.NewReader(bat), int64(len(bat)))
+	for i := range blocks {
+		start := i * vhdBlockSize
+		length := min(vhdBlockSize, size-start)
+		allocated, err := img.Allocated(start, length)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !allocated {
+			continue
+		}
+
+		binary.BigEndian.PutUint32(bat[i*4:], uint32(stream.size/sectorSize))
+		stream.add(bytes.NewReader(bitmap), vhdBitmapSize)
+		stream.add(io.NewSectionReader(img, start, length), length)
+		if length < vhdBlockSize {
+			stream.add(zeroReader{}, vhdBlockSize-length)
+		}
+	}
+	stream.add(bytes.NewReader(footer), vhdFooterSize)
+
+	return io.NewSectionReader(&stream, 0, stream.size), stream.size, nil
+}
+
+func vhdFooter(size int64) []byte {
+	footer := make([]byte, vhdFooterSize)
+	copy(footer[0:], vhdFooterCookie)
+	binary.BigEndian.PutUint32(footer[8:], 2) // features: reserved bit, always set
+	binary.BigEndian.PutUint32(footer[12:], vhdFormatVersion)
+	binary.BigEndian.PutUint64(footer[16:], vhdFooterSize)
+	binary.BigEndian.PutUint32(footer[24:], uint32(time.Since(vhdEpoch)/time.Second))
+	copy(footer[28:], "xogo")
+	binary.BigEndian.PutUint32(footer[32:], vhdFormatVersion)
+	copy(footer[36:], "Wi2k")
+	binary.BigEndian.PutUint64(footer[40:], uint64(size))
+	binary.BigEndian.PutUint64(footer[48:], uint64(size))
+	binary.BigEndian.PutUint32(footer[56:], vhdGeometry(size))
+	binary.BigEndian.PutUint32(footer[60:], vhdDiskDynamic)
+	id := uuid.Must(uuid.NewV4())
+	copy(footer[68:], id.Bytes())
+	binary.BigEndian.PutUint32(footer[64:], vhdChecksum(footer))
+	return footer
+}
+
+func vhdDynamicHeader(blocks int64) []byte {
+	header := make([]byte, vhdHeaderSize)
+	copy(header[0:], vhdHeaderCookie)
+	binary.BigEndian.PutUint64(header[8:], 0xFFFFFFFFFFFFFFFF)
+	binary.BigEndian.PutUint64(header[16:], vhdFooterSize+vhdHeaderSize)
+	binary.BigEndian.PutUint32(header[24:], vhdFormatVersion)
+	binary.BigEndian.PutUint32(header[28:], uint32(blocks))
+	binary.BigEndian.PutUint32(header[32:], vhdBlockSize)
+	binary.BigEndian.PutUint32(header[36:], vhdChecksum(header))
+	return header
+}
+
+// vhdChecksum is the one's complement of the sum of all bytes, the checksum
+// field itself (zero while computing) excluded.
+func vhdChecksum(p []byte) uint32 {
+	var sum uint32
+	for _, b := range p {
+		sum += uint32(b)
+	}
+	return ^sum
+}
+
+// vhdGeometry computes the CHS geometry as described in the VHD specification.
+func vhdGeometry(size int64) uint32 {
+	totalSectors := min(size/sectorSize, 65535*16*255)
+
+	var sectorsPerTrack, heads, cylinderTimesHeads int64
+	if totalSectors >= 65535*16*63 {
+		sectorsPerTrack, heads = 255, 16
+		cylinderTimesHeads = totalSectors / sectorsPerTrack
+	} else {
+		sectorsPerTrack = 17
+		cylinderTimesHeads = totalSectors / sectorsPerTrack
+		heads = max((cylinderTimesHeads+1023)/1024, 4)
+		if cylinderTimesHeads >= heads*1024 || heads > 16 {
+			sectorsPerTrack, heads = 31, 16
+			cylinderTimesHeads = totalSectors / sectorsPerTrack
+		}
+		if cylinderTimesHeads >= heads*1024 {
+			sectorsPerTrack, heads = 63, 16
+			cylinderTimesHeads = totalSectors / sectorsPerTrack
+		}
+	}
+	cylinders := cylinderTimesHeads / heads
+
+	return uint32(cylinders)<<16 | uint32(heads)<<8 | uint32(sectorsPerTrack)
+}
+
+type vhdImage struct {
+	r         io.ReaderAt
+	size      int64
+	blockSize int64
+	bat       []uint32
+	// bitmapSize is the size of the sector bitmap preceding each block.
+	bitmapSize int64
+}
+
+func openVHD(r io.ReaderAt, fileSize int64) (Image, error) {
+	if fileSize < vhdFooterSize {
+		return nil, fmt.Errorf("VHD file too small: %d bytes", fileSize)
+	}
+	footer := make([]byte, vhdFooterSize)
+	if err := readFull(r, footer, fileSize-vhdFooterSize); err != nil {
+		return nil, err
+	}
+	if string(footer[:8]) != vhdFooterCookie {
+		return nil, fmt.Errorf("invalid VHD footer cookie %q", footer[:8])
+	}
+	if err := checkVHDChecksum(footer, 64); err != nil {
+		return nil, fmt.Errorf("VHD footer: %w", err)
+	}
+
+	size := int64(binary.BigEndian.Uint64(footer[48:]))
+	switch diskType := binary.BigEndian.Uint32(footer[60:]); diskType {
+	case vhdDiskFixed:
+		if size > fileSize-vhdFooterSize {
+			return nil, fmt.Errorf("fixed VHD truncated: size %d, file %d bytes", size, fileSize)
+		}
+		return &rawImage{r: r, size: size}, nil
+	case vhdDiskDynamic:
+	default:
+		return nil, fmt.Errorf("%w: VHD disk type %d", ErrUnsupported, diskType)
+	}
+
+	header := make([]byte, vhdHeaderSize)
+	if err := readFull(r, header, int64(binary.BigEndian.Uint64(footer[16:]))); err != nil {
+		return nil, err
+	}
+	if string(header[:8]) != vhdHeaderCookie {
+		return nil, fmt.Errorf("invalid VHD header cookie %q", header[:8])
+	}
+	if err := checkVHDChecksum(header, 36); err != nil {
+		return nil, fmt.Errorf("VHD header: %w", err)
+	}
+
+	blockSize := int64(binary.BigEndian.Uint32(header[32:]))
+	entries := int64(binary.BigEndian.Uint32(header[28:]))
+	if blockSize < sectorSize || blockSize%sectorSize != 0 {
+		return nil, fmt.Errorf("invalid VHD block size %d", blockSize)
+	}
+	if entries < ceilDiv(size, blockSize) || entries*4 > fileSize {
+		return nil, fmt.Errorf("invalid VHD block table size %d", entries)
+	}
+
+	raw := make([]byte, entries*4)
+	if err := readFull(r, raw, int64(binary.BigEndian.Uint64(header[16:]))); err != nil {
+		return nil, err
+	}
+	bat := make([]uint32, entries)
+	for i := range bat {
+		bat[i] = binary.BigEndian.Uint32(raw[i*4:])
+	}
+
+	return &vhdImage{
+		r:          r,
+		size:       size,
+		blockSize:  blockSize,
+		bat:        bat,
+		bitmapSize: alignUp(blockSize/sectorSize/8, sectorSize),
+	}, nil
+}
+
+func checkVHDChecksum(p []byte, at int) error {
+	expected := binary.BigEndian.Uint32(p[at:])
+	buf := bytes.Clone(p)
+	binary.BigEndian.PutUint32(buf[at:], 0)
+	if actual := vhdChecksum(buf); actual != expected {
+		return fmt.Errorf("checksum mismatch: expected %#x, got %#x", expected, actual)
+	}
+	return nil
+}
+
+func (i *vhdImage) ReadAt(p []byte, off int64) (int, error) {
+	return readVirtual(p, off, i.size, i.blockSize, func(buf []byte, index, offset int64) error {
+		entry := i.bat[index]
+		if entry == vhdUnusedEntry {
+			clear(buf)
+			return nil
+		}
+		return readFull(i.r, buf, int64(entry)*sectorSize+i.bitmapSize+offset)
+	})
+}
+
+func (i *vhdImage) Size() int64 {
+	return i.size
+}
+
+func (i *vhdImage) Allocated(offset, length int64) (bool, error) {
+	return anyUnit(offset, length, i.blockSize, func(index int64) (bool, error) {
+		return i.bat[index] != vhdUnusedEntry, nil
+	})
+}
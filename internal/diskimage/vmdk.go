@@ -0,0 +1,383 @@
+package diskimage
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// VMDK sparse extent constants, see the VMware "Virtual Disk Format 5.0"
+// specification. All fields are little-endian.
+const (
+	vmdkMagic             = 0x564d444b // "KDMV"
+	vmdkHeaderSize        = sectorSize
+	vmdkGDAtEnd           = 0xFFFFFFFFFFFFFFFF
+	vmdkFlagNewlineTest   = 1 << 0
+	vmdkFlagZeroedGrain   = 1 << 2
+	vmdkFlagCompressed    = 1 << 16
+	vmdkFlagMarkers       = 1 << 17
+	vmdkCompressDeflate   = 1
+	vmdkZeroGrainEntry    = 1
+	vmdkGrainMarkerSize   = 12
+	vmdkMarkerGT          = 1
+	vmdkMarkerGD          = 2
+	vmdkMarkerFooter      = 3
+	vmdkWriteGrainSectors = 128 // 64 KiB grains
+	vmdkWriteGTEs         = 512
+	vmdkDescriptorSectors = 20
+	vmdkWriteOverhead     = 128
+)
+
+type vmdkImage struct {
+	r           io.ReaderAt
+	fileSize    int64
+	size        int64
+	grainSize   int64
+	gtEntries   int64
+	compressed  bool
+	zeroedGrain bool
+	gd          []uint32
+
+	// Cache of the last grain table and decompressed grain.
+	mu           sync.Mutex
+	gtOffset     uint32
+	gt           []uint32
+	cachedEntry  uint32
+	cachedBuffer []byte
+}
+
+func openVMDK(r io.ReaderAt, fileSize int64) (Image, error) {
+	header := make([]byte, vmdkHeaderSize)
+	if err := readFull(r, header, 0); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(header) != vmdkMagic {
+		if bytes.HasPrefix(header, []byte("# Disk DescriptorFile")) {
+			return nil, fmt.Errorf("%w: descriptor-only VMDK, import its flat extent as raw", ErrUnsupported)
+		}
+		return nil, fmt.Errorf("invalid VMDK magic %#x", header[:4])
+	}
+
+	if binary.LittleEndian.Uint64(header[56:]) == vmdkGDAtEnd {
+		// streamOptimized images store the real header in a footer, followed
+		// by the end-of-stream marker.
+		if fileSize < 3*sectorSize {
+			return nil, fmt.Errorf("VMDK file too small: %d bytes", fileSize)
+		}
+		if err := readFull(r, header, fileSize-2*sectorSize); err != nil {
+			return nil, err
+		}
+		if binary.LittleEndian.Uint32(header) != vmdkMagic {
+			return nil, fmt.Errorf("invalid VMDK footer magic %#x", header[:4])
+		}
+	}
+
+	flags := binary.LittleEndian.Uint32(header[8:])
+	image := &vmdkImage{
+		r:           r,
+		fileSize:    fileSize,
+		size:        int64(binary.LittleEndian.Uint64(header[12:])) * sectorSize,
+		grainSize:   int64(binary.LittleEndian.Uint64(header[20:])) * sectorSize,
+		gtEntries:   int64(binary.LittleEndian.Uint32(header[44:])),
+		compressed:  flags&vmdkFlagCompressed != 0,
+		zeroedGrain: flags&vmdkFlagZeroedGrain != 0,
+	}
+	if algorithm := binary.LittleEndian.Uint16(header[77:]); image.compressed && algorithm != vmdkCompressDeflate {
+		return nil, fmt.Errorf("%w: VMDK compression algorithm %d", ErrUnsupported, algorithm)
+	}
+	if image.grainSize < sectorSize || image.gtEntries <= 0 || image.gtEntries*4 > fileSize {
+		return nil, fmt.Errorf("invalid VMDK grain size %d or grain table size %d", image.grainSize, image.gtEntries)
+	}
+
+	gdOffset := binary.LittleEndian.Uint64(header[56:])
+	gdEntries := ceilDiv(ceilDiv(image.size, image.grainSize), image.gtEntries)
+	if gdOffset == vmdkGDAtEnd || gdEntries*4 > fileSize {
+		return nil, fmt.Errorf("invalid VMDK grain directory")
+	}
+	raw := make([]byte, gdEntries*4)
+	if err := readFull(r, raw, int64(gdOffset)*sectorSize); err != nil {
+		return nil, err
+	}
+	image.gd = make([]uint32, gdEntries)
+	for i := range image.gd {
+		image.gd[i] = binary.LittleEndian.Uint32(raw[i*4:])
+	}
+
+	return image, nil
+}
+
+// entry returns the grain table entry of the given grain.
+// Callers must hold i.mu.
+func (i *vmdkImage) entry(grain int64) (uint32, error) {
+	gtOffset := i.gd[grain/i.gtEntries]
+	if gtOffset == 0 {
+		return 0, nil
+	}
+
+	if gtOffset != i.gtOffset || i.gt == nil {
+		raw := make([]byte, i.gtEntries*4)
+		if err := readFull(i.r, raw, int64(gtOffset)*sectorSize); err != nil {
+			return 0, err
+		}
+		gt := make([]uint32, i.gtEntries)
+		for j := range gt {
+			gt[j] = binary.LittleEndian.Uint32(raw[j*4:])
+		}
+		i.gtOffset, i.gt = gtOffset, gt
+	}
+
+	return i.gt[grain%i.gtEntries], nil
+}
+
+func (i *vmdkImage) allocated(entry uint32) bool {
+	return entry != 0 && (entry != vmdkZeroGrainEntry || !i.zeroedGrain)
+}
+
+// decompress returns the content of the compressed grain at the given sector.
+// Callers must hold i.mu.
+func (i *vmdkImage) decompress(entry uint32) ([]byte, error) {
+	if entry == i.cachedEntry && i.cachedBuffer != nil {
+		return i.cachedBuffer, nil
+	}
+
+	offset := int64(entry) * sectorSize
+	marker := make([]byte, vmdkGrainMarkerSize)
+	if err := readFull(i.r, marker, offset); err != nil {
+		return nil, err
+	}
+	size := int64(binary.LittleEndian.Uint32(marker[8:]))
+	if size == 0 || offset+vmdkGrainMarkerSize+size > i.fileSize {
+		return nil, fmt.Errorf("invalid VMDK compressed grain at offset %d", offset)
+	}
+
+	compressed := make([]byte, size)
+	if err := readFull(i.r, compressed, offset+vmdkGrainMarkerSize); err != nil {
+		return nil, err
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing VMDK grain at offset %d: %w", offset, err)
+	}
+	// The last grain of a disk whose size isn't a multiple of the grain
+	// size may be shorter.
+	grain := make([]byte, i.grainSize)
+	if _, err := io.ReadFull(zr, grain); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("decompressing VMDK grain at offset %d: %w", offset, err)
+	}
+
+	i.cachedEntry, i.cachedBuffer = entry, grain
+	return grain, nil
+}
+
+func (i *vmdkImage) ReadAt(p []byte, off int64) (int, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return readVirtual(p, off, i.size, i.grainSize, func(buf []byte, index, offset int64) error {
+		entry, err := i.entry(index)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !i.allocated(entry):
+			clear(buf)
+			return nil
+		case i.compressed:
+			grain, err := i.decompress(entry)
+			if err != nil {
+				return err
+			}
+			copy(buf, grain[offset:])
+			return nil
+		default:
+			return readFull(i.r, buf, int64(entry)*sectorSize+offset)
+		}
+	})
+}
+
+func (i *vmdkImage) Size() int64 {
+	return i.size
+}
+
+func (i *vmdkImage) Allocated(offset, length int64) (bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return anyUnit(offset, length, i.grainSize, func(index int64) (bool, error) {
+		entry, err := i.entry(index)
+		return i.allocated(entry), err
+	})
+}
+
+// vmdkWriter writes the structures of a streamOptimized VMDK.
+type vmdkWriter struct {
+	*countingWriter
+}
+
+// marker writes a metadata marker announcing sectors sectors of kind.
+func (w vmdkWriter) marker(sectors int64, kind uint32) error {
+	marker := make([]byte, sectorSize)
+	binary.LittleEndian.PutUint64(marker[0:], uint64(sectors))
+	binary.LittleEndian.PutUint32(marker[12:], kind)
+	_, err := w.Write(marker)
+	return err
+}
+
+// table writes a marker followed by a grain table or directory and returns
+// its sector.
+func (w vmdkWriter) table(entries []uint32, kind uint32) (uint32, error) {
+	raw := make([]byte, alignUp(int64(len(entries))*4, sectorSize))
+	for i, entry := range entries {
+		binary.LittleEndian.PutUint32(raw[i*4:], entry)
+	}
+	if err := w.marker(int64(len(raw))/sectorSize, kind); err != nil {
+		return 0, err
+	}
+	sector := uint32(w.pos / sectorSize)
+	_, err := w.Write(raw)
+	return sector, err
+}
+
+// grain writes a compressed grain and returns its sector.
+func (w vmdkWriter) grain(data []byte, lba int64, compressed *bytes.Buffer) (uint32, error) {
+	compressed.Reset()
+	zw := zlib.NewWriter(compressed)
+	if _, err := zw.Write(data); err != nil {
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+
+	marker := make([]byte, vmdkGrainMarkerSize)
+	binary.LittleEndian.PutUint64(marker[0:], uint64(lba))
+	binary.LittleEndian.PutUint32(marker[8:], uint32(compressed.Len()))
+
+	sector := uint32(w.pos / sectorSize)
+	if _, err := w.Write(marker); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(compressed.Bytes()); err != nil {
+		return 0, err
+	}
+	return sector, w.pad(sectorSize)
+}
+
+func vmdkHeader(capacity int64, gdOffset uint64) []byte {
+	header := make([]byte, vmdkHeaderSize)
+	le := binary.LittleEndian
+	le.PutUint32(header[0:], vmdkMagic)
+	le.PutUint32(header[4:], 3)
+	le.PutUint32(header[8:], vmdkFlagNewlineTest|vmdkFlagCompressed|vmdkFlagMarkers)
+	le.PutUint64(header[12:], uint64(capacity))
+	le.PutUint64(header[20:], vmdkWriteGrainSectors)
+	le.PutUint64(header[28:], 1)
+	le.PutUint64(header[36:], vmdkDescriptorSectors)
+	le.PutUint32(header[44:], vmdkWriteGTEs)
+	le.PutUint64(header[56:], gdOffset)
+	le.PutUint64(header[64:], vmdkWriteOverhead)
+	copy(header[73:], "\n \r\n")
+	le.PutUint16(header[77:], vmdkCompressDeflate)
+	return header
+}
+
+func vmdkDescriptor(capacity int64) []byte {
+	descriptor := fmt.Sprintf(`# Disk DescriptorFile
+version=1
+CID=%08x
+parentCID=ffffffff
+createType="streamOptimized"
+
+# Extent description
+RW %d SPARSE "disk.vmdk"
+
+# The Disk Data Base
+#DDB
+
+ddb.virtualHWVersion = "4"
+ddb.adapterType = "lsilogic"
+ddb.geometry.cylinders = "%d"
+ddb.geometry.heads = "255"
+ddb.geometry.sectors = "63"
+`, rand.Uint32(), capacity, min(capacity/(255*63), 65535))
+
+	raw := make([]byte, vmdkDescriptorSectors*sectorSize)
+	copy(raw, descriptor)
+	return raw
+}
+
+// writeVMDK writes a streamOptimized VMDK of the raw stream. Grains that only
+// contain zeroes are skipped and the others are compressed.
+func writeVMDK(out io.Writer, raw io.Reader, size int64) error {
+	const grainSize = vmdkWriteGrainSectors * sectorSize
+
+	capacity := size / sectorSize
+	grains := ceilDiv(size, grainSize)
+	gd := make([]uint32, ceilDiv(grains, vmdkWriteGTEs))
+
+	bw := bufio.NewWriter(out)
+	w := vmdkWriter{&countingWriter{w: bw}}
+
+	if _, err := w.Write(vmdkHeader(capacity, vmdkGDAtEnd)); err != nil {
+		return err
+	}
+	if _, err := w.Write(vmdkDescriptor(capacity)); err != nil {
+		return err
+	}
+	if err := zeroes(w, vmdkWriteOverhead*sectorSize-w.pos); err != nil {
+		return err
+	}
+
+	buf := make([]byte, grainSize)
+	var compressed bytes.Buffer
+	gt := make([]uint32, vmdkWriteGTEs)
+	for grain := range grains {
+		data := buf[:min(grainSize, size-grain*grainSize)]
+		if _, err := io.ReadFull(raw, data); err != nil {
+			return errShortRaw(err)
+		}
+		if !isZero(data) {
+			sector, err := w.grain(data, grain*vmdkWriteGrainSectors, &compressed)
+			if err != nil {
+				return err
+			}
+			gt[grain%vmdkWriteGTEs] = sector
+		}
+
+		if grain%vmdkWriteGTEs == vmdkWriteGTEs-1 || grain == grains-1 {
+			if slices.ContainsFunc(gt, func(entry uint32) bool { return entry != 0 }) {
+				sector, err := w.table(gt, vmdkMarkerGT)
+				if err != nil {
+					return err
+				}
+				gd[grain/vmdkWriteGTEs] = sector
+			}
+			clear(gt)
+		}
+	}
+
+	gdSector, err := w.table(gd, vmdkMarkerGD)
+	if err != nil {
+		return err
+	}
+	if err := w.marker(1, vmdkMarkerFooter); err != nil {
+		return err
+	}
+	if _, err := w.Write(vmdkHeader(capacity, uint64(gdSector))); err != nil {
+		return err
+	}
+	// End-of-stream marker.
+	if err := zeroes(w, sectorSize); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
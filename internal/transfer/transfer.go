@@ -0,0 +1,160 @@
+// Package transfer meters VDI content streams: it reports progress, computes
+// the SHA-256 of the content and throttles the bandwidth.
+package transfer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+const defaultProgressInterval = time.Second
+
+// Meter accounts for the bytes of a transfer. A transfer may read the same
+// range of the content several times when a chunk is retried: the checksum
+// covers every byte of the content once, in order, while the rate and the
+// throttling account for all the bytes actually sent.
+type Meter struct {
+	ctx   context.Context
+	opts  payloads.VDITransferOptions
+	total int64
+
+	start      time.Time
+	lastReport time.Time
+	// position is the offset reached in the content.
+	position int64
+	// sent counts every byte read, retries included.
+	sent int64
+
+	hash   hash.Hash
+	hashed int64
+}
+
+// NewMeter starts metering a transfer of total bytes, 0 if unknown.
+func NewMeter(ctx context.Context, total int64, opts payloads.VDITransferOptions) *Meter {
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = defaultProgressInterval
+	}
+
+	m := &Meter{ctx: ctx, opts: opts, total: total, start: time.Now()}
+	m.lastReport = m.start
+	if opts.SHA256 {
+		m.hash = sha256.New()
+	}
+	return m
+}
+
+// Reader meters r, whose content starts at offset in the transferred content.
+func (m *Meter) Reader(r io.Reader, offset int64) io.Reader {
+	m.position = offset
+	return &reader{m: m, r: r, offset: offset}
+}
+
+// CatchUp feeds the checksum with the content up to offset, when the
+// transfer resumes after bytes that have not been read through the meter,
+// e.g. because the server already had them.
+func (m *Meter) CatchUp(src io.ReaderAt, offset int64) error {
+	if m.hash == nil || m.hashed >= offset {
+		return nil
+	}
+	if _, err := io.Copy(m.hash, io.NewSectionReader(src, m.hashed, offset-m.hashed)); err != nil {
+		return fmt.Errorf("computing checksum: %w", err)
+	}
+	m.hashed = offset
+	return nil
+}
+
+// Finish reports the final progress and returns the transfer result.
+func (m *Meter) Finish() (*payloads.VDITransferResult, error) {
+	m.report(true)
+
+	result := &payloads.VDITransferResult{Transferred: m.position}
+	if m.hash != nil {
+		if m.hashed != m.position {
+			return nil, fmt.Errorf("checksum covers %d bytes out of %d", m.hashed, m.position)
+		}
+		result.SHA256 = hex.EncodeToString(m.hash.Sum(nil))
+	}
+	return result, nil
+}
+
+func (m *Meter) account(p []byte, offset int64) {
+	end := offset + int64(len(p))
+	if m.hash != nil && offset <= m.hashed && end > m.hashed {
+		m.hash.Write(p[m.hashed-offset:])
+		m.hashed = end
+	}
+	m.position = end
+	m.sent += int64(len(p))
+}
+
+// throttle sleeps until the average rate is back under the bandwidth limit.
+func (m *Meter) throttle() error {
+	if m.opts.BandwidthLimit <= 0 {
+		return nil
+	}
+
+	expected := time.Duration(float64(m.sent) / float64(m.opts.BandwidthLimit) * float64(time.Second))
+	wait := expected - time.Since(m.start)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-m.ctx.Done():
+		return m.ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (m *Meter) report(final bool) {
+	if m.opts.OnProgress == nil {
+		return
+	}
+	now := time.Now()
+	if !final && now.Sub(m.lastReport) < m.opts.ProgressInterval {
+		return
+	}
+	m.lastReport = now
+
+	progress := payloads.VDITransferProgress{Transferred: m.position, Total: m.total}
+	if elapsed := now.Sub(m.start).Seconds(); elapsed > 0 {
+		progress.Rate = float64(m.sent) / elapsed
+	}
+	if m.total > 0 && progress.Rate > 0 && m.position < m.total {
+		progress.ETA = time.Duration(float64(m.total-m.position) / progress.Rate * float64(time.Second))
+	}
+	m.opts.OnProgress(progress)
+}
+
+type reader struct {
+	m      *Meter
+	r      io.Reader
+	offset int64
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	// Keep reads small enough for the throttling to be smooth.
+	if limit := r.m.opts.BandwidthLimit; limit > 0 {
+		p = p[:min(int64(len(p)), max(limit/10, 1))]
+	}
+
+	n, err := r.r.Read(p)
+	r.m.account(p[:n], r.offset)
+	r.offset += int64(n)
+
+	if throttleErr := r.m.throttle(); throttleErr != nil {
+		return n, throttleErr
+	}
+	r.m.report(false)
+	return n, err
+}
@@ -0,0 +1,104 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+const content = "0123456789abcdefghij"
+
+func sum(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func TestMeter(t *testing.T) {
+	t.Run("checksum and progress", func(t *testing.T) {
+		var reports []payloads.VDITransferProgress
+		m := NewMeter(t.Context(), int64(len(content)), payloads.VDITransferOptions{
+			SHA256:     true,
+			OnProgress: func(p payloads.VDITransferProgress) { reports = append(reports, p) },
+		})
+
+		_, err := io.Copy(io.Discard, m.Reader(strings.NewReader(content), 0))
+		require.NoError(t, err)
+		result, err := m.Finish()
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(len(content)), result.Transferred)
+		assert.Equal(t, sum(content), result.SHA256)
+		require.NotEmpty(t, reports)
+		last := reports[len(reports)-1]
+		assert.Equal(t, int64(len(content)), last.Transferred)
+		assert.Equal(t, int64(len(content)), last.Total)
+		assert.Zero(t, last.ETA)
+	})
+
+	t.Run("retried ranges are hashed once", func(t *testing.T) {
+		m := NewMeter(t.Context(), int64(len(content)), payloads.VDITransferOptions{SHA256: true})
+		src := strings.NewReader(content)
+
+		_, err := io.Copy(io.Discard, m.Reader(io.NewSectionReader(src, 0, 12), 0))
+		require.NoError(t, err)
+		// The server only got 8 bytes: resume from there.
+		_, err = io.Copy(io.Discard, m.Reader(io.NewSectionReader(src, 8, 12), 8))
+		require.NoError(t, err)
+
+		result, err := m.Finish()
+		require.NoError(t, err)
+		assert.Equal(t, sum(content), result.SHA256)
+	})
+
+	t.Run("catch up on content already on the server", func(t *testing.T) {
+		m := NewMeter(t.Context(), int64(len(content)), payloads.VDITransferOptions{SHA256: true})
+		src := strings.NewReader(content)
+
+		require.NoError(t, m.CatchUp(src, 10))
+		_, err := io.Copy(io.Discard, m.Reader(io.NewSectionReader(src, 10, 10), 10))
+		require.NoError(t, err)
+
+		result, err := m.Finish()
+		require.NoError(t, err)
+		assert.Equal(t, sum(content), result.SHA256)
+	})
+
+	t.Run("gap in the checksum", func(t *testing.T) {
+		m := NewMeter(t.Context(), int64(len(content)), payloads.VDITransferOptions{SHA256: true})
+
+		_, err := io.Copy(io.Discard, m.Reader(strings.NewReader(content[10:]), 10))
+		require.NoError(t, err)
+
+		_, err = m.Finish()
+		assert.ErrorContains(t, err, "checksum covers 0 bytes out of 20")
+	})
+
+	t.Run("bandwidth limit", func(t *testing.T) {
+		data := bytes.Repeat([]byte{1}, 2000)
+		m := NewMeter(t.Context(), int64(len(data)), payloads.VDITransferOptions{BandwidthLimit: 10000})
+
+		start := time.Now()
+		_, err := io.Copy(io.Discard, m.Reader(bytes.NewReader(data), 0))
+		require.NoError(t, err)
+
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	})
+
+	t.Run("throttling honors the context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		m := NewMeter(ctx, 0, payloads.VDITransferOptions{BandwidthLimit: 1})
+
+		_, err := io.Copy(io.Discard, m.Reader(strings.NewReader(content), 0))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
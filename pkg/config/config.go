@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,8 +9,22 @@ import (
 	"time"
 
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/middleware"
 )
 
+// Credentials are the credentials to authenticate with: either a token, or a
+// username and a password.
+type Credentials struct {
+	Token    string
+	Username string
+	Password string //gosec:disable G117
+}
+
+// CredentialsFunc returns the credentials to authenticate with. It is called
+// when the client is created, and again each time XO rejects the token, e.g.
+// to fetch a rotated token from a secret manager.
+type CredentialsFunc func(ctx context.Context) (Credentials, error)
+
 type Config struct {
 	Url                string
 	Username           string
@@ -25,6 +40,13 @@ type Config struct {
 	// OutputPaths and ErrorOutputPaths for the logger.
 	LogOutputPaths      []string
 	LogErrorOutputPaths []string
+	// Credentials, when set, is used instead of Token, Username and Password.
+	Credentials CredentialsFunc
+	// Middlewares wrap the REST requests, in order: the first one is the
+	// outermost. See the middleware package for the built-in ones.
+	Middlewares []middleware.Middleware
+	// Transports wrap the HTTP transport of the REST client, in order.
+	Transports []middleware.Transport
 }
 
 var (
@@ -137,14 +159,14 @@ func New() (*Config, error) {
 //
 // The following fields are required:
 // - Url
-// - Token or Username and Password
+// - Token, or Username and Password, or Credentials
 func NewWithValues(config *Config) (*Config, error) {
 
 	if config.Url == "" {
 		return nil, errors.New(errMissingUrl)
 	}
 
-	if config.Token == "" && (config.Username == "" || config.Password == "") {
+	if config.Credentials == nil && config.Token == "" && (config.Username == "" || config.Password == "") {
 		return nil, errors.New(errMissingAuthInfo)
 	}
 
@@ -158,6 +180,9 @@ func NewWithValues(config *Config) (*Config, error) {
 		Username:            config.Username,
 		Password:            config.Password,
 		Token:               config.Token,
+		Credentials:         config.Credentials,
+		Middlewares:         config.Middlewares,
+		Transports:          config.Transports,
 		InsecureSkipVerify:  config.InsecureSkipVerify,
 		RetryMode:           config.RetryMode,
 		RetryMaxTime:        config.RetryMaxTime,
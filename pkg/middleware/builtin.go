@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/redact"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header set by RequestID.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose requests have the ID id, e.g. to use
+// the ID of an incoming request.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the ID of the requests set by WithRequestID or
+// RequestID, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// UserAgent sets the User-Agent header of the requests.
+func UserAgent(userAgent string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("User-Agent", userAgent)
+			return next(ctx, req)
+		}
+	}
+}
+
+// RequestID sets the X-Request-Id header of the requests, to the ID of the
+// context or to a new UUID. The ID is added to the context for the next
+// middlewares, e.g. Logging.
+func RequestID() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			id := RequestIDFromContext(ctx)
+			if id == "" {
+				newID, err := uuid.NewV4()
+				if err != nil {
+					return nil, err
+				}
+				id = newID.String()
+				ctx = WithRequestID(ctx, id)
+			}
+			req.Header.Set(RequestIDHeader, id)
+			return next(ctx, req)
+		}
+	}
+}
+
+// Logging logs the requests at debug level, and the failed ones at warn
+// level, with their redacted params. The results are not logged, as some
+// contain credentials, e.g. the URLs of the remotes.
+func Logging(log *zap.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			fields := []zap.Field{
+				zap.String("method", req.Method),
+				zap.String("endpoint", req.Endpoint),
+				zap.Duration("duration", time.Since(start)),
+			}
+			if req.Params != nil {
+				fields = append(fields, zap.Any("params", redact.Params(req.Params)))
+			}
+			if id := RequestIDFromContext(ctx); id != "" {
+				fields = append(fields, zap.String("requestId", id))
+			}
+			if err != nil {
+				log.Warn("REST request failed", append(fields, zap.Error(err))...)
+				return resp, err
+			}
+			if resp != nil {
+				fields = append(fields, zap.Int("status", resp.StatusCode))
+			}
+			log.Debug("REST request", fields...)
+			return resp, nil
+		}
+	}
+}
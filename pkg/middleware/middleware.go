@@ -0,0 +1,60 @@
+// Package middleware provides the middlewares of the requests of the v2
+// client to the REST API, to add headers, tracing, request signing or audit
+// hooks.
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request is a request of the v2 client to the REST API.
+type Request struct {
+	// Method is the HTTP method, e.g. "GET".
+	Method string
+	// Endpoint is the path relative to the REST API, e.g. "vms/<id>".
+	Endpoint string
+	// Params are the query parameters, or the JSON body for POST, PUT and
+	// PATCH. They are nil for raw requests, e.g. disk imports and exports.
+	Params map[string]any
+	// Result is where the response is decoded, once the next handler
+	// returned. It is nil when the response is ignored or for raw requests.
+	Result any
+	// Header is added to the headers of the HTTP request.
+	Header http.Header
+}
+
+// Response is the response of the REST API to a request.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// Handler sends a request. The response is nil when an error is returned.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps the handler sending the requests. Middlewares run in
+// the order they are configured: the first one is the outermost.
+type Middleware func(next Handler) Handler
+
+// Transport wraps the HTTP transport of the client, e.g. for tracing. Unlike
+// middlewares, transports also see the authentication requests. Transports
+// run in the order they are configured: the first one is the outermost.
+type Transport func(next http.RoundTripper) http.RoundTripper
+
+// Chain returns the handler running the middlewares around handler.
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// ChainTransport returns the transport running the transports around
+// transport.
+func ChainTransport(transport http.RoundTripper, transports ...Transport) http.RoundTripper {
+	for i := len(transports) - 1; i >= 0; i-- {
+		transport = transports[i](transport)
+	}
+	return transport
+}
@@ -0,0 +1,52 @@
+package payloads
+
+import (
+	"slices"
+
+	"github.com/gofrs/uuid"
+)
+
+// ACLRole is the role an ACL gives to its subject on its object.
+type ACLRole string
+
+const (
+	// ACLRoleViewer can see the object.
+	ACLRoleViewer ACLRole = "viewer"
+	// ACLRoleOperator can also operate the object, e.g. start or stop a VM.
+	ACLRoleOperator ACLRole = "operator"
+	// ACLRoleAdmin can also administrate the object, e.g. change a VM's resources.
+	ACLRoleAdmin ACLRole = "admin"
+)
+
+var aclRoles = []ACLRole{ACLRoleViewer, ACLRoleOperator, ACLRoleAdmin}
+
+// Valid reports whether r is one of the XO roles.
+func (r ACLRole) Valid() bool {
+	return slices.Contains(aclRoles, r)
+}
+
+// Includes reports whether r grants at least the permissions of other: each
+// role includes the permissions of the previous ones.
+func (r ACLRole) Includes(other ACLRole) bool {
+	return r.Valid() && other.Valid() && slices.Index(aclRoles, r) >= slices.Index(aclRoles, other)
+}
+
+// ACL grants a role on an object to a user or a group.
+type ACL struct {
+	// ID is computed by XO from the other fields. It is not a UUID.
+	ID string `json:"id"`
+	// Subject is the ID of a user or a group.
+	Subject uuid.UUID `json:"subject"`
+	// Object is the ID of a pool, host, VM, SR, network...
+	Object uuid.UUID `json:"object"`
+	Action ACLRole   `json:"action"`
+}
+
+type ACLParams struct {
+	// Subject is the ID of a user or a group (required)
+	Subject uuid.UUID
+	// Object is the ID of the object the role applies to (required)
+	Object uuid.UUID
+	// Role given to the subject (required)
+	Role ACLRole
+}
@@ -0,0 +1,15 @@
+package payloads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACLRoleIncludes(t *testing.T) {
+	assert.True(t, ACLRoleAdmin.Includes(ACLRoleViewer))
+	assert.True(t, ACLRoleOperator.Includes(ACLRoleOperator))
+	assert.False(t, ACLRoleViewer.Includes(ACLRoleOperator))
+	assert.False(t, ACLRole("owner").Includes(ACLRoleViewer))
+	assert.False(t, ACLRoleAdmin.Includes("owner"))
+}
@@ -0,0 +1,49 @@
+package payloads
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditNullID is the previous ID of the first record of the audit log.
+const AuditNullID = "nullId"
+
+// AuditSubject is the user who performed an audited action.
+type AuditSubject struct {
+	UserID   string `json:"userId,omitempty"`
+	UserIP   string `json:"userIp,omitempty"`
+	UserName string `json:"userName,omitempty"`
+}
+
+// AuditRecord is a record of the XO audit log. Each record is identified by
+// the hash of its content and of the ID of the previous record, which chains
+// them: altering or removing a record breaks the chain.
+//
+// The fields are in the order of the XO records, so that a record marshaled to
+// JSON can be verified again.
+type AuditRecord struct {
+	// Data holds the details of the event, e.g. the method and parameters of
+	// an API call. It is kept as sent by XO as its content is hashed.
+	Data json.RawMessage `json:"data,omitempty"`
+	// Event is the kind of the record, e.g. "apiCall" or "signIn".
+	Event string `json:"event"`
+	// ID is the hash of the record, e.g. "$5$$Zm9v...".
+	ID string `json:"id"`
+	// PreviousID is the ID of the previous record, AuditNullID for the first one.
+	PreviousID string       `json:"previousId"`
+	Subject    AuditSubject `json:"subject"`
+	// Time is the time of the record in milliseconds since the Unix epoch.
+	Time int64 `json:"time"`
+}
+
+// Timestamp returns the time of the record.
+func (r *AuditRecord) Timestamp() time.Time {
+	return time.UnixMilli(r.Time)
+}
+
+// AuditPage is a page of audit records, most recent first.
+type AuditPage struct {
+	Records []*AuditRecord
+	// Next is the cursor of the next (older) page, "" after the last page.
+	Next string
+}
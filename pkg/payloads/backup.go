@@ -0,0 +1,193 @@
+package payloads
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// BackupMode is the kind of a backup job.
+type BackupMode string
+
+const (
+	// BackupModeFull exports full backups of the VMs to remotes. With SRs
+	// instead of remotes, it copies the VMs to the SRs (disaster recovery).
+	BackupModeFull BackupMode = "full"
+	// BackupModeDelta exports incremental backups of the VMs to remotes.
+	BackupModeDelta BackupMode = "delta"
+	// BackupModeContinuousReplication replicates the VMs incrementally to SRs.
+	BackupModeContinuousReplication BackupMode = "continuousReplication"
+	// BackupModeMirror copies the backups of a remote to other remotes.
+	BackupModeMirror BackupMode = "mirror"
+	// BackupModeMetadata exports the metadata of pools and/or of XO to remotes.
+	BackupModeMetadata BackupMode = "metadata"
+)
+
+// BackupRetention is the number of backups kept by a schedule. Zero disables
+// the corresponding kind of backup for the schedule.
+type BackupRetention struct {
+	// Export is the number of backups kept on each remote.
+	Export int
+	// Copy is the number of replicas kept on each SR.
+	Copy int
+	// Snapshot is the number of snapshots kept on the VMs (rolling snapshots).
+	Snapshot int
+}
+
+// BackupSmartSelection selects the VMs of a backup job dynamically, each time
+// it runs. Empty criteria are ignored.
+type BackupSmartSelection struct {
+	// Pools restricts the selection to the VMs of these pools.
+	Pools []uuid.UUID
+	// NotPools excludes the VMs of these pools.
+	NotPools []uuid.UUID
+	// Tags restricts the selection to the VMs having at least one of these tags.
+	Tags []string
+	// NotTags excludes the VMs having one of these tags.
+	NotTags []string
+	// PowerState restricts the selection to the VMs in this power state, e.g. "Running".
+	PowerState string
+}
+
+// BackupSettings are the settings of a backup job applying to all its schedules.
+type BackupSettings struct {
+	// ReportWhen is when a report is sent: "always", "failure" or "never".
+	ReportWhen string
+	// Concurrency is the number of VMs backed up at the same time, 0 for the default.
+	Concurrency int
+	// OfflineSnapshot shuts the VMs down during their snapshot.
+	OfflineSnapshot bool
+	// Other holds the settings not described above, by name.
+	Other map[string]any
+}
+
+// BackupJob describes a backup job. The fields used depend on the mode.
+type BackupJob struct {
+	// ID is generated by XO. It is a UUID.
+	ID   uuid.UUID
+	Name string
+	Mode BackupMode
+	// VMs is the explicit selection of VMs, for the full, delta and continuous
+	// replication modes. It is exclusive with Smart.
+	VMs []uuid.UUID
+	// Smart is the dynamic selection of VMs, exclusive with VMs.
+	Smart *BackupSmartSelection
+	// Remotes are the destination remotes.
+	Remotes []uuid.UUID
+	// SRs are the destination SRs, for the full (disaster recovery) and
+	// continuous replication modes.
+	SRs []uuid.UUID
+	// SourceRemote is the remote whose backups are copied, for the mirror mode.
+	SourceRemote uuid.UUID
+	// Incremental mirrors the incremental backups instead of the full ones.
+	Incremental bool
+	// Pools are the pools whose metadata is exported, for the metadata mode.
+	Pools []uuid.UUID
+	// XOMetadata also exports the configuration of XO, for the metadata mode.
+	XOMetadata bool
+	// Compression of the full backups: "", "native" (gzip) or "zstd".
+	Compression string
+	Settings    BackupSettings
+	// Retention of each schedule of the job, by schedule ID.
+	Retention map[uuid.UUID]BackupRetention
+	// OtherSettings holds the settings XO stores by key, e.g. by VM ID, other
+	// than Settings and Retention. Update sends them back unchanged.
+	OtherSettings map[string]map[string]any
+}
+
+// BackupScheduleParams describes a schedule created with its backup job.
+type BackupScheduleParams struct {
+	// Name of the schedule (optional)
+	Name string
+	// Cron pattern of the schedule, e.g. "0 2 * * *" (required)
+	Cron string
+	// Timezone of the cron pattern, e.g. "Europe/Paris" (optional, defaults to XO's)
+	Timezone string
+	// Enabled schedules run the job automatically
+	Enabled bool
+	// Retention of the backups made by the schedule
+	Retention BackupRetention
+}
+
+// BackupStatus is the result of a backup job run, or of one of its steps.
+type BackupStatus string
+
+const (
+	BackupStatusPending     BackupStatus = "pending"
+	BackupStatusSuccess     BackupStatus = "success"
+	BackupStatusFailure     BackupStatus = "failure"
+	BackupStatusInterrupted BackupStatus = "interrupted"
+	BackupStatusSkipped     BackupStatus = "skipped"
+)
+
+// BackupLog is the log of a backup job run.
+type BackupLog struct {
+	ID         string
+	JobID      uuid.UUID
+	JobName    string
+	ScheduleID uuid.UUID
+	Start      APITime
+	// End is zero while the run is pending.
+	End    APITime
+	Status BackupStatus
+	// Error is the error message of the run, when it failed as a whole.
+	Error string
+	// VMs are the results of the VMs backed up by the run.
+	VMs []BackupVMResult
+}
+
+// BackupVMResult is the result of the backup of a VM during a job run.
+type BackupVMResult struct {
+	VMID   uuid.UUID
+	Start  APITime
+	End    APITime
+	Status BackupStatus
+	// Error is the error message of the backup, or the reason it was skipped.
+	Error string
+	// Size is the number of bytes transferred to the remotes and SRs.
+	Size int64
+}
+
+// RestorePoint is a backup of a VM stored on a remote.
+type RestorePoint struct {
+	// ID identifies the backup on its remote, e.g.
+	// "<remote>/xo-vm-backups/<vm>/20240101T000000Z.json". It is not a UUID.
+	ID       string
+	RemoteID uuid.UUID
+	VMID     uuid.UUID
+	// VMName is the name of the VM when it was backed up.
+	VMName     string
+	Timestamp  APITime
+	Mode       BackupMode
+	JobID      uuid.UUID
+	ScheduleID uuid.UUID
+	// Size is the size of the backup on the remote, in bytes.
+	Size  int64
+	Disks []RestorePointDisk
+}
+
+// RestorePointDisk is a disk of a restore point.
+type RestorePointDisk struct {
+	// ID identifies the disk in the backup, it is used to list its files.
+	ID   string
+	Name string
+	// UUID of the backed up VDI.
+	UUID uuid.UUID
+}
+
+// DiskPartition is a partition of a backed up disk.
+type DiskPartition struct {
+	// ID identifies the partition in the disk, it is empty for disks without
+	// partition table.
+	ID   string
+	Name string
+	// Type is the partition type, e.g. "linux" or "ntfs".
+	Type string
+	Size int64
+}
+
+// BackupFile is an entry of a directory of a backed up partition.
+type BackupFile struct {
+	Name string
+	// Path is the absolute path of the entry in the partition.
+	Path  string
+	IsDir bool
+}
@@ -0,0 +1,55 @@
+package payloads
+
+// CloudConfigType distinguishes the two kinds of templates stored by XO.
+type CloudConfigType string
+
+const (
+	// CloudConfigTypeUser is a cloud-init user-data template.
+	CloudConfigTypeUser CloudConfigType = "user"
+	// CloudConfigTypeNetwork is a cloud-init network-config template.
+	CloudConfigTypeNetwork CloudConfigType = "network"
+)
+
+// CloudConfig is a cloud-init template stored in Xen Orchestra.
+// Templates may contain the placeholders described in CloudConfigVariables.
+type CloudConfig struct {
+	// ID is the XO identifier of the template. It is not a UUID.
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Template string          `json:"template"`
+	Type     CloudConfigType `json:"type,omitempty"`
+}
+
+type CloudConfigCreateParams struct {
+	// Name of the template (required)
+	Name string `json:"name"`
+	// Template content (required)
+	Template string `json:"template"`
+	// Type of the template, CloudConfigTypeUser if empty
+	Type CloudConfigType `json:"-"`
+}
+
+type CloudConfigUpdateParams struct {
+	// Name of the template (optional)
+	Name *string `json:"name,omitempty"`
+	// Template content (optional)
+	Template *string `json:"template,omitempty"`
+}
+
+// CloudConfigVariables are the values substituted when rendering a template,
+// following the XO conventions:
+//   - {name} is replaced by Name
+//   - {index} and % are replaced by Index
+//   - {key} is replaced by Custom[key] for each custom variable
+//
+// A placeholder preceded by a backslash is kept literally, without the backslash.
+// Every unescaped % is replaced: shell commands such as `date +%s` must be
+// written `date +\%s`.
+type CloudConfigVariables struct {
+	// Name is the name label of the VM.
+	Name string
+	// Index is the position of the VM when several VMs are created at once, 0 otherwise.
+	Index int
+	// Custom holds additional variables. Keys must not contain braces.
+	Custom map[string]string
+}
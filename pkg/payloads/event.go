@@ -0,0 +1,37 @@
+package payloads
+
+import "encoding/json"
+
+// ObjectEventKind is the kind of change of an XO object.
+type ObjectEventKind string
+
+const (
+	ObjectEventAdd    ObjectEventKind = "add"
+	ObjectEventUpdate ObjectEventKind = "update"
+	ObjectEventRemove ObjectEventKind = "remove"
+	// ObjectEventSynced is sent once the objects have been listed after each
+	// (re)connection: the events sent before it describe the full state of
+	// the objects matching the filter. It has no object.
+	ObjectEventSynced ObjectEventKind = "synced"
+)
+
+// ObjectEvent is a change of an XO object.
+type ObjectEvent struct {
+	Kind ObjectEventKind
+	Type ResourceType
+	// ID is a string as some XO objects, e.g. the messages, do not have a UUID.
+	ID string
+	// Object is the decoded object, e.g. *VM for the type ResourceTypeVM, or nil
+	// for the types without payload. For a removal, it is the last state of
+	// the object, or nil if it was removed while the connection was lost.
+	Object any
+	// Raw is the object as sent by XO, nil when Object is.
+	Raw json.RawMessage
+}
+
+// ObjectEventFilter restricts the objects whose changes are sent. The zero
+// value matches all the objects.
+type ObjectEventFilter struct {
+	Types []ResourceType
+	IDs   []string
+}
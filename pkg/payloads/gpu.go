@@ -0,0 +1,97 @@
+package payloads
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// PGPU is a physical GPU of a host.
+type PGPU struct {
+	ID   uuid.UUID    `json:"id"`
+	UUID uuid.UUID    `json:"uuid"`
+	Type ResourceType `json:"type"`
+	// PCI is the PCI device of the GPU.
+	PCI      uuid.UUID `json:"pci"`
+	GPUGroup uuid.UUID `json:"gpuGroup"`
+	// Dom0Access is the access of the control domain to the GPU.
+	Dom0Access PCIDom0Access `json:"dom0Access"`
+	// IsSystemDisplayDevice is set for the GPU used by the console of the host.
+	IsSystemDisplayDevice bool        `json:"isSystemDisplayDevice"`
+	SupportedVGPUTypes    []uuid.UUID `json:"supportedVgpuTypes"`
+	EnabledVGPUTypes      []uuid.UUID `json:"enabledVgpuTypes"`
+	// SupportedVGPUMaxCapacities is the number of vGPUs of each supported type
+	// the GPU can run when it runs no other type.
+	SupportedVGPUMaxCapacities map[uuid.UUID]int `json:"supportedVgpuMaxCapcities"`
+	// VGPUs are the vGPUs running on the GPU.
+	VGPUs  []uuid.UUID `json:"vgpus"`
+	Host   uuid.UUID   `json:"$host"`
+	Pool   uuid.UUID   `json:"$pool"`
+	PoolID uuid.UUID   `json:"$poolId"`
+}
+
+// GPUGroupAllocation is how the vGPUs are placed on the GPUs of a group.
+type GPUGroupAllocation string
+
+const (
+	// GPUGroupAllocationBreadthFirst places vGPUs on the least used GPUs.
+	GPUGroupAllocationBreadthFirst GPUGroupAllocation = "breadth_first"
+	// GPUGroupAllocationDepthFirst fills a GPU before using the next one.
+	GPUGroupAllocationDepthFirst GPUGroupAllocation = "depth_first"
+)
+
+// GPUGroup is a group of identical physical GPUs of a pool, on which the
+// vGPUs of VMs are placed.
+type GPUGroup struct {
+	ID                 uuid.UUID          `json:"id"`
+	UUID               uuid.UUID          `json:"uuid"`
+	Type               ResourceType       `json:"type"`
+	NameLabel          string             `json:"name_label"`
+	NameDescription    string             `json:"name_description"`
+	Allocation         GPUGroupAllocation `json:"allocation"`
+	PGPUs              []uuid.UUID        `json:"pgpus"`
+	SupportedVGPUTypes []uuid.UUID        `json:"supportedVgpuTypes"`
+	EnabledVGPUTypes   []uuid.UUID        `json:"enabledVgpuTypes"`
+	VGPUs              []uuid.UUID        `json:"vgpus"`
+	Pool               uuid.UUID          `json:"$pool"`
+	PoolID             uuid.UUID          `json:"$poolId"`
+}
+
+// VGPUType is a kind of virtual GPU, e.g. a NVIDIA profile or the passthrough
+// of a whole GPU.
+type VGPUType struct {
+	ID         uuid.UUID    `json:"id"`
+	UUID       uuid.UUID    `json:"uuid"`
+	Type       ResourceType `json:"type"`
+	VendorName string       `json:"vendorName"`
+	// ModelName is e.g. "GRID T4-4Q", or "passthrough".
+	ModelName string `json:"modelName"`
+	// FramebufferSize is the video memory of the vGPU, in bytes.
+	FramebufferSize int64 `json:"framebufferSize"`
+	MaxHeads        int   `json:"maxHeads"`
+	MaxResolutionX  int   `json:"maxResolutionX"`
+	MaxResolutionY  int   `json:"maxResolutionY"`
+	Experimental    bool  `json:"experimental"`
+	// GPUGroups are the groups on which the type is enabled.
+	GPUGroups []uuid.UUID `json:"gpuGroups"`
+	Pool      uuid.UUID   `json:"$pool"`
+	PoolID    uuid.UUID   `json:"$poolId"`
+}
+
+// VGPU is a virtual GPU of a VM.
+type VGPU struct {
+	ID       uuid.UUID `json:"id"`
+	VGPUType uuid.UUID `json:"vgpuType"`
+	GPUGroup uuid.UUID `json:"gpuGroup"`
+	VM       uuid.UUID `json:"vm"`
+}
+
+// VGPUTypeCapacity is the number of vGPUs of a type a physical GPU can run.
+type VGPUTypeCapacity struct {
+	VGPUType uuid.UUID
+	// Enabled tells whether vGPUs of the type can be placed on the GPU.
+	Enabled bool
+	// Max is the number of vGPUs of the type the GPU runs when it runs no other type.
+	Max int
+	// Remaining is the number of vGPUs of the type the GPU can still run. It is
+	// 0 when the type is disabled or the GPU runs vGPUs of another type.
+	Remaining int
+}
@@ -0,0 +1,11 @@
+package payloads
+
+// InformerOptions configures an informer, the local cache of the XO objects.
+type InformerOptions struct {
+	// Types are the cached object types, among ResourceTypeVM, ResourceTypeSR,
+	// ResourceTypeHost and ResourceTypePool. All of them when empty.
+	Types []ResourceType
+	// MaxObjects bounds the number of cached objects, 0 for no limit. When it
+	// is exceeded, the informer stops and its listers read from XO again.
+	MaxObjects int
+}
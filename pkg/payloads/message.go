@@ -0,0 +1,106 @@
+package payloads
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessagePriority is the priority of a XAPI message, from 1 (the most
+// important) to 5.
+type MessagePriority int
+
+const (
+	// MessagePriorityUnknown is the priority of the messages for which XO does
+	// not report it.
+	MessagePriorityUnknown  MessagePriority = 0
+	MessagePriorityCritical MessagePriority = 1
+	MessagePriorityHigh     MessagePriority = 2
+	MessagePriorityWarning  MessagePriority = 3
+	MessagePriorityLow      MessagePriority = 4
+	MessagePriorityInfo     MessagePriority = 5
+)
+
+// AlarmMessageName is the name of the XAPI messages raised by alarms.
+const AlarmMessageName = "ALARM"
+
+// Message is a XAPI message, e.g. "VM_STARTED" or an alarm, attached to an object.
+type Message struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Body string    `json:"body"`
+	// Time is reported by XO in seconds.
+	Time     time.Time       `json:"-"`
+	Priority MessagePriority `json:"priority,omitempty"`
+	// Object is the UUID of the object the message is about.
+	Object uuid.UUID `json:"$object"`
+	Pool   uuid.UUID `json:"$pool"`
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type message Message
+	aux := struct {
+		*message
+		Time int64 `json:"time"`
+	}{message: (*message)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.Time = time.Unix(aux.Time, 0)
+	return nil
+}
+
+// AlarmObject is the object which triggered an alarm.
+type AlarmObject struct {
+	// Type is the type of the object, e.g. "host", "VM" or "SR".
+	Type ResourceType `json:"type"`
+	UUID uuid.UUID    `json:"uuid"`
+}
+
+// AlarmBody describes the threshold crossed by an alarm.
+type AlarmBody struct {
+	// Name is the monitored metric, e.g. "mem_usage" or "physical_utilisation".
+	Name string `json:"name"`
+	// Value is the value of the metric which triggered the alarm, as reported by XAPI.
+	Value string `json:"value"`
+}
+
+// Alarm is a XAPI message raised when a metric crosses its threshold, e.g. a
+// nearly full SR or a host running out of memory.
+type Alarm struct {
+	ID       uuid.UUID       `json:"id"`
+	Body     AlarmBody       `json:"body"`
+	Time     time.Time       `json:"-"`
+	Priority MessagePriority `json:"priority,omitempty"`
+	Object   AlarmObject     `json:"object"`
+	Pool     uuid.UUID       `json:"$pool"`
+}
+
+func (a *Alarm) UnmarshalJSON(data []byte) error {
+	type alarm Alarm
+	aux := struct {
+		*alarm
+		Time int64 `json:"time"`
+	}{alarm: (*alarm)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.Time = time.Unix(aux.Time, 0)
+	return nil
+}
+
+// MessageFilter selects messages. Zero fields are ignored.
+type MessageFilter struct {
+	// Object restricts the selection to the messages about this object.
+	Object uuid.UUID
+	// Name restricts the selection to the messages with this name, e.g. "VM_STARTED".
+	Name string
+	// Priority restricts the selection to the messages at least this important,
+	// i.e. with a lower or equal priority. Messages of unknown priority are kept.
+	Priority MessagePriority
+	// Since excludes the messages older than this time.
+	Since time.Time
+	// Until excludes the messages from this time on.
+	Until time.Time
+}
@@ -23,3 +23,39 @@ type PBD struct {
 	// OtherConfig holds additional configuration key-value pairs.
 	OtherConfig map[string]string `json:"otherConfig"`
 }
+
+// CreatePBDParams contains the parameters for creating a new PBD.
+type CreatePBDParams struct {
+	// Host is the ID of the host to connect (required)
+	Host uuid.UUID `json:"host"`
+	// SR is the ID of the Storage Repository to connect the host to (required)
+	SR uuid.UUID `json:"SR"`
+	// DeviceConfig holds the SR-type-specific configuration key-value pairs
+	DeviceConfig map[string]string `json:"device_config,omitempty"`
+}
+
+// PBDRepairAction describes what was done for a host when repairing the PBDs of an SR.
+type PBDRepairAction string
+
+const (
+	// PBDRepairActionNone indicates the host already had a plugged PBD.
+	PBDRepairActionNone PBDRepairAction = "none"
+	// PBDRepairActionPlugged indicates an existing but detached PBD was plugged.
+	PBDRepairActionPlugged PBDRepairAction = "plugged"
+	// PBDRepairActionCreated indicates a missing PBD was created and plugged.
+	PBDRepairActionCreated PBDRepairAction = "created"
+	// PBDRepairActionFailed indicates the PBD could not be repaired, see PBDRepairResult.Err.
+	PBDRepairActionFailed PBDRepairAction = "failed"
+)
+
+// PBDRepairResult reports the outcome of a PBD repair for a single host.
+type PBDRepairResult struct {
+	// Host is the ID of the host.
+	Host uuid.UUID `json:"host"`
+	// PBD is the ID of the host's PBD to the SR, uuid.Nil if it could not be created.
+	PBD uuid.UUID `json:"pbd"`
+	// Action is what was done for the host.
+	Action PBDRepairAction `json:"action"`
+	// Err is the reason of the failure when Action is PBDRepairActionFailed.
+	Err error `json:"-"`
+}
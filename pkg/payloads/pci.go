@@ -0,0 +1,43 @@
+package payloads
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// PCI is a PCI device of a host.
+type PCI struct {
+	ID   uuid.UUID    `json:"id"`
+	UUID uuid.UUID    `json:"uuid"`
+	Type ResourceType `json:"type"`
+	// ClassName is the class of the device, e.g. "VGA compatible controller".
+	ClassName  string `json:"class_name"`
+	DeviceName string `json:"device_name"`
+	// PCIID is the address of the device on the host, e.g. "0000:04:00.0".
+	PCIID  string    `json:"pci_id"`
+	Host   uuid.UUID `json:"$host"`
+	Pool   uuid.UUID `json:"$pool"`
+	PoolID uuid.UUID `json:"$poolId"`
+}
+
+// PCIDom0Access tells whether the control domain (dom0) of a host uses a PCI
+// device. A device must be hidden from dom0 to be passed through to a VM, which
+// takes effect when the host reboots.
+type PCIDom0Access string
+
+const (
+	PCIDom0AccessEnabled         PCIDom0Access = "enabled"
+	PCIDom0AccessDisableOnReboot PCIDom0Access = "disable_on_reboot"
+	PCIDom0AccessDisabled        PCIDom0Access = "disabled"
+	PCIDom0AccessEnableOnReboot  PCIDom0Access = "enable_on_reboot"
+)
+
+// Hidden tells whether the device is, or will be after the next reboot of the
+// host, hidden from dom0.
+func (a PCIDom0Access) Hidden() bool {
+	return a == PCIDom0AccessDisabled || a == PCIDom0AccessDisableOnReboot
+}
+
+// RebootRequired tells whether the host must reboot for the access to apply.
+func (a PCIDom0Access) RebootRequired() bool {
+	return a == PCIDom0AccessDisableOnReboot || a == PCIDom0AccessEnableOnReboot
+}
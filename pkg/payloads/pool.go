@@ -137,3 +137,17 @@ type CreateBondedNetworkParams struct {
 	// Bond mode (required)
 	BondMode NetworkBondMode `json:"bondMode"`
 }
+
+// UEFICertificates are the custom Secure Boot certificates of a pool, as
+// authenticated EFI signature lists (.auth files). They are used by the VMs
+// whose UEFI variables are not set up yet. Empty fields are not set.
+type UEFICertificates struct {
+	// PK is the platform key
+	PK []byte
+	// KEK is the key exchange key
+	KEK []byte
+	// DB lists the allowed signatures
+	DB []byte
+	// DBX lists the forbidden signatures
+	DBX []byte
+}
@@ -0,0 +1,200 @@
+package payloads
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/redact"
+)
+
+// RemoteType is the protocol used to reach a remote.
+type RemoteType string
+
+const (
+	// RemoteTypeLocal is a directory of the XO host.
+	RemoteTypeLocal RemoteType = "file"
+	RemoteTypeNFS   RemoteType = "nfs"
+	RemoteTypeSMB   RemoteType = "smb"
+	// RemoteTypeS3 is an S3 compatible object storage.
+	RemoteTypeS3 RemoteType = "s3"
+)
+
+// Remote is a backup repository.
+type Remote struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// URL locates the remote and contains its credentials: use RedactedURL to
+	// display it. It is built with the URL method of LocalRemote, NFSRemote,
+	// SMBRemote or S3Remote.
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+	// Error is the last error XO encountered while using the remote.
+	Error string `json:"error,omitempty"`
+	// Options are the mount options of NFS and SMB remotes.
+	Options string `json:"options,omitempty"`
+	// Proxy is the ID of the backup proxy used to reach the remote, if any.
+	Proxy      *uuid.UUID        `json:"proxy,omitempty"`
+	Benchmarks []RemoteBenchmark `json:"benchmarks,omitempty"`
+}
+
+// Type returns the protocol of the remote.
+func (r *Remote) Type() RemoteType {
+	scheme, _, _ := strings.Cut(r.URL, "://")
+	// S3 over plain HTTP uses the "s3+http" scheme.
+	scheme, _, _ = strings.Cut(scheme, "+")
+	return RemoteType(scheme)
+}
+
+// RedactedURL returns the URL of the remote without its credentials.
+func (r *Remote) RedactedURL() string {
+	return redact.URL(r.URL)
+}
+
+// RemoteBenchmark is the result of a successful test of a remote.
+type RemoteBenchmark struct {
+	Timestamp APITime `json:"timestamp"`
+	// ReadRate and WriteRate are in bytes per second.
+	ReadRate  float64 `json:"readRate"`
+	WriteRate float64 `json:"writeRate"`
+}
+
+type RemoteCreateParams struct {
+	// Name of the remote (required)
+	Name string
+	// URL of the remote, built with LocalRemote, NFSRemote, SMBRemote or S3Remote (required)
+	URL string
+	// Mount options of NFS and SMB remotes (optional)
+	Options string
+	// ID of the backup proxy used to reach the remote (optional)
+	Proxy *uuid.UUID
+}
+
+// RemoteUpdateParams changes the non-nil fields of a remote.
+type RemoteUpdateParams struct {
+	Name    *string
+	URL     *string
+	Options *string
+	Proxy   *uuid.UUID
+}
+
+// RemoteTestResult is the result of a test of a remote: XO writes a file,
+// reads it back and deletes it.
+type RemoteTestResult struct {
+	Success bool
+	// Step is the step that failed: "write", "read" or "delete".
+	Step string
+	// File is the file used by the test.
+	File string
+	// ReadRate and WriteRate are in bytes per second, set on success.
+	ReadRate  float64
+	WriteRate float64
+	// Error is the error reported by XO on failure.
+	Error string
+}
+
+// RemoteUsage is the disk usage of a remote, in bytes.
+type RemoteUsage struct {
+	Size      int64
+	Used      int64
+	Available int64
+}
+
+// LocalRemote is a directory of the XO host.
+type LocalRemote struct {
+	// Absolute path of the directory
+	Path string
+}
+
+func (r LocalRemote) URL() string {
+	return "file://" + r.Path
+}
+
+// NFSRemote is an NFS export.
+type NFSRemote struct {
+	Host string
+	// Port of the NFS server, 0 for the default one
+	Port int
+	// Absolute path of the export
+	Path string
+}
+
+func (r NFSRemote) URL() string {
+	host := r.Host
+	if r.Port != 0 {
+		host += ":" + strconv.Itoa(r.Port)
+	}
+	return "nfs://" + host + ":" + r.Path
+}
+
+// SMBRemote is an SMB/CIFS share.
+type SMBRemote struct {
+	Host string
+	// Share name, without the host
+	Share string
+	// Path of the directory in the share (optional)
+	Path     string
+	Domain   string
+	Username string
+	Password string
+}
+
+func (r SMBRemote) URL() string {
+	// XO separates the share from the path in the share with a NUL character.
+	return "smb://" + encodeURIComponent(r.Username) + ":" + encodeURIComponent(r.Password) + "@" +
+		r.Domain + `\\` + r.Host + `\` + r.Share + "\x00" + r.Path
+}
+
+// S3Remote is a bucket of an S3 compatible object storage.
+type S3Remote struct {
+	// Host of the endpoint, e.g. "s3.us-east-1.amazonaws.com"
+	Host string
+	// Port of the endpoint, 0 for the default one
+	Port int
+	// HTTP connects to the endpoint over plain HTTP instead of HTTPS
+	HTTP bool
+	// AllowUnauthorized accepts self-signed certificates
+	AllowUnauthorized bool
+	Bucket            string
+	// Path of the directory in the bucket (optional)
+	Path            string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func (r S3Remote) URL() string {
+	scheme := "s3"
+	if r.HTTP {
+		scheme = "s3+http"
+	}
+	host := r.Host
+	if r.Port != 0 {
+		host += ":" + strconv.Itoa(r.Port)
+	}
+	path := r.Bucket
+	if p := strings.Trim(r.Path, "/"); p != "" {
+		path += "/" + p
+	}
+
+	query := url.Values{}
+	if r.Region != "" {
+		query.Set("region", r.Region)
+	}
+	if r.AllowUnauthorized {
+		query.Set("allowUnauthorized", "true")
+	}
+	result := scheme + "://" + encodeURIComponent(r.AccessKeyID) + ":" + encodeURIComponent(r.SecretAccessKey) +
+		"@" + host + "/" + path
+	if len(query) > 0 {
+		result += "?" + query.Encode()
+	}
+	return result
+}
+
+// encodeURIComponent escapes a URL component like the JavaScript function of
+// the same name, which XO uses to decode the credentials of the remotes.
+func encodeURIComponent(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
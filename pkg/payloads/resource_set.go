@@ -0,0 +1,73 @@
+package payloads
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// ResourceSetLimitKey identifies a resource limited by a resource set.
+type ResourceSetLimitKey string
+
+const (
+	// ResourceSetLimitCPUs limits the number of vCPUs of the VMs.
+	ResourceSetLimitCPUs ResourceSetLimitKey = "cpus"
+	// ResourceSetLimitMemory limits the maximum memory of the VMs, in bytes.
+	ResourceSetLimitMemory ResourceSetLimitKey = "memory"
+	// ResourceSetLimitDisk limits the size of the disks of the VMs, in bytes.
+	ResourceSetLimitDisk ResourceSetLimitKey = "disk"
+)
+
+// ResourceSetLimit is the quota of a resource. Available is maintained by XO
+// as VMs are created, resized and deleted in the resource set.
+type ResourceSetLimit struct {
+	Total     int64 `json:"total"`
+	Available int64 `json:"available"`
+}
+
+// ResourceSet gives users and groups a quota of resources to create VMs from
+// a set of templates, on a set of SRs and networks.
+type ResourceSet struct {
+	// ID is generated by XO. It is not a UUID.
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Subjects are the IDs of the users and groups allowed to use the resource set.
+	Subjects []uuid.UUID `json:"subjects"`
+	// Objects are the IDs of the templates, SRs and networks the VMs can use.
+	Objects []uuid.UUID `json:"objects"`
+	// Limits only contains the limited resources: the others are unlimited.
+	Limits map[ResourceSetLimitKey]ResourceSetLimit `json:"limits"`
+}
+
+type ResourceSetCreateParams struct {
+	// Name of the resource set (required)
+	Name string
+	// IDs of the users and groups allowed to use the resource set (optional)
+	Subjects []uuid.UUID
+	// IDs of the templates, SRs and networks the VMs can use (optional)
+	Objects []uuid.UUID
+	// Quota of each limited resource (optional)
+	Limits map[ResourceSetLimitKey]int64
+}
+
+// ResourceSetLimitUsage is the consumption of a limited resource.
+type ResourceSetLimitUsage struct {
+	Total     int64
+	Used      int64
+	Available int64
+}
+
+// ResourceSetUsage is the consumption of each limited resource of a resource set.
+type ResourceSetUsage map[ResourceSetLimitKey]ResourceSetLimitUsage
+
+// ResourceSetFit is the prediction of a VM creation in a resource set.
+type ResourceSetFit struct {
+	// Fits is true when the VM can be created without exceeding a limit and
+	// only uses objects of the resource set.
+	Fits bool
+	// Required is the quantity of each resource the VM would consume.
+	Required map[ResourceSetLimitKey]int64
+	// Exceeded lists the limits that would be exceeded.
+	Exceeded []ResourceSetLimitKey
+	// MissingObjects lists the template, SRs and networks used by the VM that
+	// are not part of the resource set.
+	MissingObjects []uuid.UUID
+}
@@ -0,0 +1,40 @@
+package payloads
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Schedule runs an XO job, e.g. a backup job, periodically.
+type Schedule struct {
+	ID    uuid.UUID `json:"id"`
+	JobID uuid.UUID `json:"jobId"`
+	Name  string    `json:"name"`
+	// Cron is the cron pattern of the schedule, e.g. "0 2 * * *".
+	Cron string `json:"cron"`
+	// Timezone of the cron pattern, e.g. "Europe/Paris". Empty for the timezone
+	// of the XO server.
+	Timezone string `json:"timezone,omitempty"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type ScheduleCreateParams struct {
+	// ID of the job run by the schedule (required)
+	JobID uuid.UUID
+	// Name of the schedule (optional)
+	Name string
+	// Cron pattern, with 5 fields or 6 with the seconds first (required)
+	Cron string
+	// Timezone of the cron pattern (optional, defaults to the XO server's)
+	Timezone string
+	// Enabled schedules run their job (optional)
+	Enabled bool
+}
+
+// ScheduleUpdateParams changes the non-nil fields of a schedule.
+type ScheduleUpdateParams struct {
+	JobID    *uuid.UUID
+	Name     *string
+	Cron     *string
+	Timezone *string
+	Enabled  *bool
+}
@@ -0,0 +1,63 @@
+package payloads
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// ServerStatus is the state of the connection of XO to a server.
+type ServerStatus string
+
+const (
+	ServerStatusConnected    ServerStatus = "connected"
+	ServerStatusConnecting   ServerStatus = "connecting"
+	ServerStatusDisconnected ServerStatus = "disconnected"
+)
+
+// Server is a XAPI server, i.e. the master of a pool, XO connects to. Its
+// password is never returned by XO.
+type Server struct {
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+	// Host is the address of the server, with an optional port, e.g. "192.168.1.10:443".
+	Host     string       `json:"host"`
+	Username string       `json:"username"`
+	Status   ServerStatus `json:"status"`
+	// Error is the last connection error, if any.
+	Error string `json:"-"`
+	// Enabled servers are connected when XO starts.
+	Enabled bool `json:"enabled"`
+	// ReadOnly prevents XO from changing the pool.
+	ReadOnly bool `json:"readOnly"`
+	// AllowUnauthorized accepts self-signed certificates.
+	AllowUnauthorized bool `json:"allowUnauthorized"`
+	// PoolID is the ID of the pool of the server, once connected.
+	PoolID uuid.UUID `json:"poolId"`
+}
+
+type ServerCreateParams struct {
+	// Label of the server (optional)
+	Label string
+	// Address of the server, with an optional port (required)
+	Host string
+	// Username of the XAPI account (required)
+	Username string
+	// Password of the XAPI account (required)
+	Password string
+	// ReadOnly prevents XO from changing the pool (optional)
+	ReadOnly bool
+	// AllowUnauthorized accepts self-signed certificates (optional)
+	AllowUnauthorized bool
+	// Disabled adds the server without connecting to it (optional)
+	Disabled bool
+}
+
+// ServerUpdateParams changes the non-nil fields of a server. The changes apply
+// on the next connection.
+type ServerUpdateParams struct {
+	Label             *string
+	Host              *string
+	Username          *string
+	Password          *string
+	ReadOnly          *bool
+	AllowUnauthorized *bool
+}
@@ -40,6 +40,9 @@ const (
 	AllocationStrategyThick AllocationStrategy = "thick"
 )
 
+// SRContentTypeISO is the content type of the SRs storing ISO images.
+const SRContentTypeISO = "iso"
+
 // StorageRepository represents a Storage Repository in Xen Orchestra.
 // An StorageRepository is a storage container that holds VDIs (Virtual Disk Images).
 type StorageRepository struct {
@@ -85,3 +88,43 @@ type StorageRepository struct {
 	// Usage is the number of bytes allocated (virtual size of all VDIs).
 	Usage float64 `json:"usage"`
 }
+
+// SRPBDStatus reports the attachment state of a single PBD of an SR.
+type SRPBDStatus struct {
+	// PBD is the ID of the PBD.
+	PBD uuid.UUID `json:"pbd"`
+	// Host is the ID of the host the PBD connects to the SR.
+	Host uuid.UUID `json:"host"`
+	// Attached indicates whether the PBD is currently plugged.
+	Attached bool `json:"attached"`
+}
+
+// SRHealth is a point-in-time health report of a Storage Repository.
+type SRHealth struct {
+	// SR is the ID of the inspected SR.
+	SR uuid.UUID `json:"sr"`
+	// InMaintenanceMode indicates whether the SR is currently in maintenance mode.
+	InMaintenanceMode bool `json:"inMaintenanceMode"`
+	// PBDs reports the attachment state of each PBD, one per host.
+	PBDs []SRPBDStatus `json:"pbds"`
+	// DetachedPBDs is the number of PBDs that are not currently plugged.
+	DetachedPBDs int `json:"detachedPbds"`
+	// Size is the total capacity of the SR in bytes.
+	Size float64 `json:"size"`
+	// PhysicalUsage is the number of bytes physically used on the underlying storage.
+	PhysicalUsage float64 `json:"physicalUsage"`
+	// VirtualUsage is the number of bytes allocated to VDIs (sum of their virtual sizes).
+	VirtualUsage float64 `json:"virtualUsage"`
+	// PhysicalUsageRatio is PhysicalUsage / Size (0 when the size is unknown).
+	PhysicalUsageRatio float64 `json:"physicalUsageRatio"`
+	// VirtualToPhysicalRatio is VirtualUsage / PhysicalUsage. A value above 1 means
+	// the SR is thin-provisioned beyond what is physically consumed (0 when nothing is used).
+	VirtualToPhysicalRatio float64 `json:"virtualToPhysicalRatio"`
+	// MaxChainDepth is the length of the longest VDI chain found on the SR.
+	MaxChainDepth int `json:"maxChainDepth"`
+	// ChainDepths maps each leaf VDI (a VDI that is not the parent of another one) to its chain depth.
+	ChainDepths map[uuid.UUID]int `json:"chainDepths"`
+	// CoalesceBacklog is the number of parent VDIs with a single child, which are the ones
+	// the storage garbage collector still has to coalesce.
+	CoalesceBacklog int `json:"coalesceBacklog"`
+}
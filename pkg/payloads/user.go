@@ -0,0 +1,116 @@
+package payloads
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+)
+
+// UserPermission is the global permission level of an XO user.
+type UserPermission string
+
+const (
+	// UserPermissionNone gives access to the objects granted by ACLs only.
+	UserPermissionNone  UserPermission = "none"
+	UserPermissionRead  UserPermission = "read"
+	UserPermissionWrite UserPermission = "write"
+	// UserPermissionAdmin gives full access to XO.
+	UserPermissionAdmin UserPermission = "admin"
+)
+
+// User represents a Xen Orchestra user.
+type User struct {
+	ID uuid.UUID `json:"id"`
+	// Email is the login of the user, it is not necessarily an email address.
+	Email       string          `json:"email"`
+	Permission  UserPermission  `json:"permission"`
+	Groups      []uuid.UUID     `json:"groups"`
+	Preferences UserPreferences `json:"preferences"`
+}
+
+// SSHKey is a public key installed by XO on the VMs the user creates.
+type SSHKey struct {
+	Title string `json:"title"`
+	Key   string `json:"key"`
+}
+
+// UserPreferences holds the preferences of a user. Only the SSH keys are
+// typed, the other preferences are kept as is so that updating the SSH keys
+// does not reset them.
+type UserPreferences struct {
+	// SSHKeys are left unchanged when nil, and all removed when empty.
+	SSHKeys []SSHKey `json:"sshKeys,omitempty"`
+	// Other holds the preferences not described above, by name.
+	Other map[string]json.RawMessage `json:"-"`
+}
+
+func (p *UserPreferences) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = UserPreferences{}
+	if keys, ok := raw["sshKeys"]; ok {
+		if err := json.Unmarshal(keys, &p.SSHKeys); err != nil {
+			return err
+		}
+		delete(raw, "sshKeys")
+	}
+	if len(raw) > 0 {
+		p.Other = raw
+	}
+	return nil
+}
+
+func (p UserPreferences) MarshalJSON() ([]byte, error) {
+	raw := make(map[string]any, len(p.Other)+1)
+	for name, value := range p.Other {
+		raw[name] = value
+	}
+	switch {
+	case len(p.SSHKeys) > 0:
+		raw["sshKeys"] = p.SSHKeys
+	case p.SSHKeys != nil:
+		// XO merges the preferences, and only deletes those sent as null.
+		raw["sshKeys"] = nil
+	}
+	return json.Marshal(raw)
+}
+
+type UserCreateParams struct {
+	// Email is the login of the user (required)
+	Email string
+	// Password of the user (required)
+	Password string
+	// Permission of the user, UserPermissionNone if empty
+	Permission UserPermission
+}
+
+type UserUpdateParams struct {
+	Email      *string
+	Password   *string
+	Permission *UserPermission
+	// Preferences replaces all the preferences of the user.
+	Preferences *UserPreferences
+}
+
+// Group represents a Xen Orchestra group of users.
+type Group struct {
+	ID    uuid.UUID   `json:"id"`
+	Name  string      `json:"name"`
+	Users []uuid.UUID `json:"users"`
+	// Provider is set for groups synchronized from an authentication provider such as LDAP.
+	Provider string `json:"provider,omitempty"`
+}
+
+// AuthToken is an authentication token of the current user.
+type AuthToken struct {
+	// ID is the token itself: it grants the permissions of its user to anyone knowing it.
+	ID          string    `json:"id"`
+	Description string    `json:"description,omitempty"`
+	UserID      uuid.UUID `json:"user_id"`
+	CreatedAt   APITime   `json:"created_at,omitempty"`
+	// Expiration is the time after which the token is rejected.
+	Expiration APITime `json:"expiration"`
+}
@@ -1,6 +1,11 @@
 package payloads
 
 import (
+	"encoding/base64"
+	"fmt"
+	"math/bits"
+	"time"
+
 	"github.com/gofrs/uuid"
 )
 
@@ -50,6 +55,10 @@ type VDIFormat string
 const (
 	VDIFormatRaw VDIFormat = "raw"
 	VDIFormatVHD VDIFormat = "vhd"
+	// VDIFormatQCOW2 and VDIFormatVMDK are not handled by the XO API: they are
+	// converted locally to VHD on import and from raw on export.
+	VDIFormatQCOW2 VDIFormat = "qcow2"
+	VDIFormatVMDK  VDIFormat = "vmdk"
 )
 
 type VDIOperation string
@@ -75,6 +84,66 @@ const (
 	VDIOperationUpdate            VDIOperation = "update"
 )
 
+// VDIOnBoot is the behaviour of a VDI when the VM it is attached to boots.
+type VDIOnBoot string
+
+const (
+	// VDIOnBootPersist keeps the changes made to the VDI across reboots (default).
+	VDIOnBootPersist VDIOnBoot = "persist"
+	// VDIOnBootReset discards the changes made to the VDI on every boot.
+	VDIOnBootReset VDIOnBoot = "reset"
+)
+
+// VDIUpdateParams contains the updatable fields of a VDI.
+// Only the fields that are set are sent to the API.
+type VDIUpdateParams struct {
+	NameLabel       *string           `json:"name_label,omitempty"`
+	NameDescription *string           `json:"name_description,omitempty"`
+	OtherConfig     map[string]string `json:"other_config,omitempty"`
+	ReadOnly        *bool             `json:"read_only,omitempty"`
+}
+
+// VDITransferProgress is reported periodically during a VDI import or export.
+type VDITransferProgress struct {
+	// Transferred is the number of bytes transferred so far.
+	Transferred int64
+	// Total is the number of bytes to transfer, 0 when unknown.
+	Total int64
+	// Rate is the average transfer rate in bytes per second.
+	Rate float64
+	// ETA is the estimated remaining time, 0 when unknown.
+	ETA time.Duration
+}
+
+// VDITransferOptions tunes a VDI import or export. The zero value streams the
+// content in a single request, without progress reporting nor checksum.
+type VDITransferOptions struct {
+	// OnProgress, if set, is called every ProgressInterval (1s by default)
+	// and once the transfer is complete.
+	OnProgress       func(VDITransferProgress)
+	ProgressInterval time.Duration
+	// SHA256 computes the SHA-256 of the transferred content while streaming.
+	SHA256 bool
+	// BandwidthLimit caps the transfer rate, in bytes per second. 0 means unlimited.
+	BandwidthLimit int64
+	// ChunkSize, for imports only, uploads the content in chunks of this size
+	// using Content-Range requests. A failed chunk is resumed from the last
+	// byte received by the server. Only set it for servers supporting
+	// resumable uploads, e.g. a proxy in front of XO: XO itself does not, and
+	// the import fails.
+	ChunkSize int64
+	// MaxRetries is the number of times a failed chunk is retried.
+	MaxRetries int
+}
+
+// VDITransferResult describes a completed VDI import or export.
+type VDITransferResult struct {
+	// Transferred is the size of the transferred content in bytes.
+	Transferred int64
+	// SHA256 is the hex-encoded SHA-256 of the content, if requested.
+	SHA256 string
+}
+
 type VDICreateParams struct {
 	SRId            uuid.UUID         `json:"srId"`
 	VirtualSize     int64             `json:"virtual_size"`
@@ -88,3 +157,75 @@ type VDICreateParams struct {
 	XenstoreData    map[string]string `json:"xenstore_data,omitempty"`
 	SmConfig        map[string]any    `json:"sm_config,omitempty"`
 }
+
+// CBTBlockSize is the granularity of the changed block tracking bitmap: each
+// bit of the bitmap returned by XAPI covers 64 KiB of the virtual disk.
+const CBTBlockSize int64 = 64 * 1024
+
+// BlockExtent is a contiguous byte range of a virtual disk.
+type BlockExtent struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+// ChangedBlocks is the decoded changed block tracking bitmap between two
+// snapshots of a VDI. Bit i (most significant bit first) of the bitmap is set
+// when the block starting at i*BlockSize has changed.
+type ChangedBlocks struct {
+	BlockSize int64  `json:"block_size"`
+	Bitmap    []byte `json:"bitmap"`
+}
+
+// DecodeChangedBlocks decodes the base64 bitmap returned by XAPI
+// VDI.list_changed_blocks.
+func DecodeChangedBlocks(encoded string) (*ChangedBlocks, error) {
+	bitmap, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid changed blocks bitmap: %w", err)
+	}
+	return &ChangedBlocks{BlockSize: CBTBlockSize, Bitmap: bitmap}, nil
+}
+
+// Blocks returns the number of blocks covered by the bitmap.
+func (c *ChangedBlocks) Blocks() int64 {
+	return int64(len(c.Bitmap)) * 8
+}
+
+// Changed reports whether block i has changed.
+func (c *ChangedBlocks) Changed(i int64) bool {
+	if i < 0 || i >= c.Blocks() {
+		return false
+	}
+	return c.Bitmap[i/8]&(0x80>>(i%8)) != 0
+}
+
+// Count returns the number of changed blocks.
+func (c *ChangedBlocks) Count() int64 {
+	var count int64
+	for _, b := range c.Bitmap {
+		count += int64(bits.OnesCount8(b))
+	}
+	return count
+}
+
+// ChangedBytes returns the amount of data covered by the changed blocks.
+func (c *ChangedBlocks) ChangedBytes() int64 {
+	return c.Count() * c.BlockSize
+}
+
+// Extents merges contiguous changed blocks into byte ranges, in disk order.
+func (c *ChangedBlocks) Extents() []BlockExtent {
+	var extents []BlockExtent
+	for i := int64(0); i < c.Blocks(); i++ {
+		if !c.Changed(i) {
+			continue
+		}
+		offset := i * c.BlockSize
+		if n := len(extents); n > 0 && extents[n-1].Offset+extents[n-1].Length == offset {
+			extents[n-1].Length += c.BlockSize
+			continue
+		}
+		extents = append(extents, BlockExtent{Offset: offset, Length: c.BlockSize})
+	}
+	return extents
+}
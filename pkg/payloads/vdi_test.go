@@ -0,0 +1,43 @@
+package payloads
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeChangedBlocks(t *testing.T) {
+	t.Run("decodes the bitmap and merges contiguous blocks", func(t *testing.T) {
+		// Blocks 0, 1, 2 and 9 changed: 0b11100000 0b01000000
+		encoded := base64.StdEncoding.EncodeToString([]byte{0xE0, 0x40})
+
+		blocks, err := DecodeChangedBlocks(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, CBTBlockSize, blocks.BlockSize)
+		assert.Equal(t, int64(16), blocks.Blocks())
+		assert.Equal(t, int64(4), blocks.Count())
+		assert.Equal(t, 4*CBTBlockSize, blocks.ChangedBytes())
+		assert.True(t, blocks.Changed(0))
+		assert.False(t, blocks.Changed(3))
+		assert.True(t, blocks.Changed(9))
+		assert.False(t, blocks.Changed(16))
+		assert.Equal(t, []BlockExtent{
+			{Offset: 0, Length: 3 * CBTBlockSize},
+			{Offset: 9 * CBTBlockSize, Length: CBTBlockSize},
+		}, blocks.Extents())
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		blocks, err := DecodeChangedBlocks(base64.StdEncoding.EncodeToString([]byte{0, 0}))
+		require.NoError(t, err)
+		assert.Zero(t, blocks.Count())
+		assert.Empty(t, blocks.Extents())
+	})
+
+	t.Run("invalid base64", func(t *testing.T) {
+		_, err := DecodeChangedBlocks("not base64!")
+		assert.Error(t, err)
+	})
+}
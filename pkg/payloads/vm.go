@@ -58,6 +58,8 @@ type VM struct {
 	StartDelay         int               `json:"startDelay,omitempty"`
 	ExpNestedHvm       bool              `json:"expNestedHvm,omitempty"`
 	Boot               Boot              `json:"boot"`
+	SecureBoot         bool              `json:"secureBoot"`
+	VTPMs              []uuid.UUID       `json:"VTPMs,omitempty"`
 	Videoram           Videoram          `json:"videoram,omitempty"`
 	Vga                string            `json:"vga,omitempty"`
 	XenstoreData       map[string]string `json:"xenStoreData,omitempty"`
@@ -112,6 +114,9 @@ type CPUs struct {
 	Max    int `json:"max,omitempty"`
 }
 
+// BootFirmwareUEFI is the firmware of the VMs supporting Secure Boot and vTPMs.
+const BootFirmwareUEFI = "uefi"
+
 type Boot struct {
 	Firmware string `json:"firmware,omitempty"`
 	Order    string `json:"order,omitempty"`
@@ -0,0 +1,294 @@
+package acl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+// parentKeys are the properties of XO objects referencing the objects whose
+// ACLs also apply to them, as evaluated by XO's ACL resolver.
+var parentKeys = []string{"$container", "$pool", "$SR", "$snapshot_of", "$VM"}
+
+type Service struct {
+	// Needed by Check to resolve the subject's groups and permission
+	userService  library.User
+	groupService library.Group
+
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(
+	userService library.User,
+	groupService library.Group,
+	jsonrpcSvc library.JSONRPC,
+	log *logger.Logger,
+) library.ACL {
+	return &Service{
+		userService:  userService,
+		groupService: groupService,
+		jsonrpcSvc:   jsonrpcSvc,
+		log:          log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*payloads.ACL, error) {
+	acls, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, acl := range acls {
+		if acl.ID == id {
+			return acl, nil
+		}
+	}
+	return nil, fmt.Errorf("ACL %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.ACL, error) {
+	var result []*payloads.ACL
+	if err := s.jsonrpcSvc.Call("acl.get", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) Create(ctx context.Context, params payloads.ACLParams) (*payloads.ACL, error) {
+	if err := s.add(params); err != nil {
+		return nil, err
+	}
+
+	// The ID is not returned by the creation.
+	acls, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if acl := find(acls, params); acl != nil {
+		return acl, nil
+	}
+	return nil, fmt.Errorf("ACL giving %s on %s to %s was created but could not be retrieved",
+		params.Role, params.Object, params.Subject)
+}
+
+func (s *Service) SetRole(ctx context.Context, id string, role payloads.ACLRole) (*payloads.ACL, error) {
+	acl, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if acl.Action == role {
+		return acl, nil
+	}
+
+	// Create the new ACL first so that the subject never loses its access.
+	created, err := s.Create(ctx, payloads.ACLParams{Subject: acl.Subject, Object: acl.Object, Role: role})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.remove(params(acl)); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	acl, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.remove(params(acl))
+}
+
+func (s *Service) Grant(ctx context.Context, params []payloads.ACLParams) error {
+	acls, err := s.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range params {
+		if find(acls, p) != nil {
+			continue
+		}
+		if err := s.add(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) Revoke(ctx context.Context, params []payloads.ACLParams) error {
+	acls, err := s.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range params {
+		if find(acls, p) == nil {
+			continue
+		}
+		if err := s.remove(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) Check(
+	ctx context.Context, subject uuid.UUID, object uuid.UUID, action payloads.ACLRole) (bool, error) {
+	if !action.Valid() {
+		return false, fmt.Errorf("unknown role %q", action)
+	}
+
+	subjects, admin, err := s.subjects(ctx, subject)
+	if err != nil {
+		return false, err
+	}
+	if admin {
+		return true, nil
+	}
+
+	acls, err := s.GetAll(ctx)
+	if err != nil {
+		return false, err
+	}
+	// Roles of the subject, by object.
+	roles := make(map[uuid.UUID][]payloads.ACLRole)
+	for _, acl := range acls {
+		if subjects[acl.Subject] {
+			roles[acl.Object] = append(roles[acl.Object], acl.Action)
+		}
+	}
+	if len(roles) == 0 {
+		return false, nil
+	}
+
+	// Walk up from the object to the pool, stopping at the first ACL granting the role.
+	visited := map[uuid.UUID]bool{object: true}
+	queue := []uuid.UUID{object}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, role := range roles[id] {
+			if role.Includes(action) {
+				s.log.Debug("Role granted by ACL", zap.String("subject", subject.String()),
+					zap.String("object", object.String()), zap.String("aclObject", id.String()),
+					zap.String("role", string(role)))
+				return true, nil
+			}
+		}
+
+		obj, err := s.getObject(id)
+		if err != nil {
+			return false, err
+		}
+		if obj == nil {
+			if id == object {
+				return false, fmt.Errorf("object %s not found", object)
+			}
+			continue
+		}
+		for _, key := range parentKeys {
+			parentID, ok := obj[key].(string)
+			if !ok {
+				continue
+			}
+			parent, err := uuid.FromString(parentID)
+			if err != nil || visited[parent] {
+				continue
+			}
+			visited[parent] = true
+			queue = append(queue, parent)
+		}
+	}
+	return false, nil
+}
+
+// subjects returns the subjects whose ACLs apply to subject: a user and its
+// groups, or a group alone. admin is true for admin users, who bypass ACLs.
+func (s *Service) subjects(ctx context.Context, subject uuid.UUID) (map[uuid.UUID]bool, bool, error) {
+	users, err := s.userService.GetAll(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, user := range users {
+		if user.ID != subject {
+			continue
+		}
+		subjects := map[uuid.UUID]bool{user.ID: true}
+		for _, group := range user.Groups {
+			subjects[group] = true
+		}
+		return subjects, user.Permission == payloads.UserPermissionAdmin, nil
+	}
+
+	if _, err := s.groupService.Get(ctx, subject); err != nil {
+		return nil, false, fmt.Errorf("subject %s is neither a user nor a group: %w", subject, err)
+	}
+	return map[uuid.UUID]bool{subject: true}, false, nil
+}
+
+// getObject returns the XO object with the given ID, or nil if it does not exist.
+func (s *Service) getObject(id uuid.UUID) (map[string]any, error) {
+	var result map[string]map[string]any
+	params := map[string]any{
+		"filter": map[string]any{"id": id.String()},
+		"limit":  1,
+	}
+	if err := s.jsonrpcSvc.Call("xo.getAllObjects", params, &result, zap.String("objectID", id.String())); err != nil {
+		return nil, err
+	}
+	return result[id.String()], nil
+}
+
+func (s *Service) add(p payloads.ACLParams) error {
+	if !p.Role.Valid() {
+		return fmt.Errorf("unknown role %q", p.Role)
+	}
+	var result any
+	return s.jsonrpcSvc.Call("acl.add", rpcParams(p), &result, logContext(p)...)
+}
+
+func (s *Service) remove(p payloads.ACLParams) error {
+	var result bool
+	if err := s.jsonrpcSvc.Call("acl.remove", rpcParams(p), &result, logContext(p)...); err != nil {
+		return err
+	}
+	return s.jsonrpcSvc.ValidateResult(result, "ACL removal", logContext(p)...)
+}
+
+func find(acls []*payloads.ACL, p payloads.ACLParams) *payloads.ACL {
+	for _, acl := range acls {
+		if acl.Subject == p.Subject && acl.Object == p.Object && acl.Action == p.Role {
+			return acl
+		}
+	}
+	return nil
+}
+
+func params(acl *payloads.ACL) payloads.ACLParams {
+	return payloads.ACLParams{Subject: acl.Subject, Object: acl.Object, Role: acl.Action}
+}
+
+func rpcParams(p payloads.ACLParams) map[string]any {
+	return map[string]any{
+		"subject": p.Subject.String(),
+		"object":  p.Object.String(),
+		"action":  string(p.Role),
+	}
+}
+
+func logContext(p payloads.ACLParams) []zap.Field {
+	return []zap.Field{
+		zap.String("subject", p.Subject.String()),
+		zap.String("object", p.Object.String()),
+		zap.String("role", string(p.Role)),
+	}
+}
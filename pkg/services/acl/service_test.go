@@ -0,0 +1,231 @@
+package acl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+var (
+	testUserID  = uuid.Must(uuid.FromString("1a000000-0000-0000-0000-000000000001"))
+	testAdminID = uuid.Must(uuid.FromString("1a000000-0000-0000-0000-000000000002"))
+	testGroupID = uuid.Must(uuid.FromString("1b000000-0000-0000-0000-000000000001"))
+	testPoolID  = uuid.Must(uuid.FromString("1c000000-0000-0000-0000-000000000001"))
+	testHostID  = uuid.Must(uuid.FromString("1c000000-0000-0000-0000-000000000002"))
+	testVMID    = uuid.Must(uuid.FromString("1c000000-0000-0000-0000-000000000003"))
+)
+
+type mocks struct {
+	user    *mock.MockUser
+	group   *mock.MockGroup
+	jsonrpc *mock.MockJSONRPC
+}
+
+func setup(t *testing.T) (*Service, mocks) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	ctrl := gomock.NewController(t)
+	m := mocks{
+		user:    mock.NewMockUser(ctrl),
+		group:   mock.NewMockGroup(ctrl),
+		jsonrpc: mock.NewMockJSONRPC(ctrl),
+	}
+	return New(m.user, m.group, m.jsonrpc, log).(*Service), m
+}
+
+func (m mocks) expectACLs(acls ...*payloads.ACL) {
+	m.jsonrpc.EXPECT().Call("acl.get", map[string]any{}, gomock.Any()).SetArg(2, acls).Return(nil)
+}
+
+func (m mocks) expectObject(id uuid.UUID, object map[string]any) {
+	result := map[string]map[string]any{}
+	if object != nil {
+		result[id.String()] = object
+	}
+	m.jsonrpc.EXPECT().Call("xo.getAllObjects", map[string]any{
+		"filter": map[string]any{"id": id.String()},
+		"limit":  1,
+	}, gomock.Any(), gomock.Any()).SetArg(2, result).Return(nil)
+}
+
+func (m mocks) expectUsers() {
+	m.user.EXPECT().GetAll(gomock.Any()).Return([]*payloads.User{
+		{ID: testUserID, Permission: payloads.UserPermissionNone, Groups: []uuid.UUID{testGroupID}},
+		{ID: testAdminID, Permission: payloads.UserPermissionAdmin},
+	}, nil)
+}
+
+func TestCreate(t *testing.T) {
+	svc, m := setup(t)
+	params := payloads.ACLParams{Subject: testUserID, Object: testVMID, Role: payloads.ACLRoleOperator}
+	m.jsonrpc.EXPECT().Call("acl.add", map[string]any{
+		"subject": testUserID.String(),
+		"object":  testVMID.String(),
+		"action":  "operator",
+	}, gomock.Any(), gomock.Any()).Return(nil)
+	m.expectACLs(&payloads.ACL{ID: "acl-1", Subject: testUserID, Object: testVMID, Action: payloads.ACLRoleOperator})
+
+	acl, err := svc.Create(t.Context(), params)
+
+	require.NoError(t, err)
+	assert.Equal(t, "acl-1", acl.ID)
+
+	_, err = svc.Create(t.Context(), payloads.ACLParams{Subject: testUserID, Object: testVMID, Role: "owner"})
+	assert.ErrorContains(t, err, `unknown role "owner"`)
+}
+
+func TestSetRole(t *testing.T) {
+	svc, m := setup(t)
+	viewer := &payloads.ACL{ID: "acl-1", Subject: testUserID, Object: testVMID, Action: payloads.ACLRoleViewer}
+	admin := &payloads.ACL{ID: "acl-2", Subject: testUserID, Object: testVMID, Action: payloads.ACLRoleAdmin}
+	gomock.InOrder(
+		m.jsonrpc.EXPECT().Call("acl.get", gomock.Any(), gomock.Any()).
+			SetArg(2, []*payloads.ACL{viewer}).Return(nil),
+		m.jsonrpc.EXPECT().Call("acl.add", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+		m.jsonrpc.EXPECT().Call("acl.get", gomock.Any(), gomock.Any()).
+			SetArg(2, []*payloads.ACL{viewer, admin}).Return(nil),
+		m.jsonrpc.EXPECT().Call("acl.remove", map[string]any{
+			"subject": testUserID.String(),
+			"object":  testVMID.String(),
+			"action":  "viewer",
+		}, gomock.Any(), gomock.Any()).SetArg(2, true).Return(nil),
+	)
+	m.jsonrpc.EXPECT().ValidateResult(true, "ACL removal", gomock.Any()).Return(nil)
+
+	acl, err := svc.SetRole(t.Context(), "acl-1", payloads.ACLRoleAdmin)
+
+	require.NoError(t, err)
+	assert.Equal(t, admin, acl)
+}
+
+func TestGrantRevoke(t *testing.T) {
+	existing := payloads.ACLParams{Subject: testUserID, Object: testVMID, Role: payloads.ACLRoleViewer}
+	missing := payloads.ACLParams{Subject: testGroupID, Object: testPoolID, Role: payloads.ACLRoleOperator}
+	failing := payloads.ACLParams{Subject: testGroupID, Object: testHostID, Role: payloads.ACLRoleOperator}
+	acls := []*payloads.ACL{
+		{ID: "acl-1", Subject: existing.Subject, Object: existing.Object, Action: existing.Role},
+		{ID: "acl-2", Subject: failing.Subject, Object: failing.Object, Action: failing.Role},
+	}
+
+	t.Run("grant skips existing ACLs and continues on failure", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectACLs(acls[0])
+		m.jsonrpc.EXPECT().Call("acl.add", rpcParams(missing), gomock.Any(), gomock.Any()).Return(nil)
+		m.jsonrpc.EXPECT().Call("acl.add", rpcParams(failing), gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("JSON-RPC call to acl.add failed: no such object"))
+
+		err := svc.Grant(t.Context(), []payloads.ACLParams{existing, failing, missing})
+
+		assert.ErrorContains(t, err, "no such object")
+	})
+
+	t.Run("revoke skips missing ACLs", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectACLs(acls...)
+		for _, p := range []payloads.ACLParams{existing, failing} {
+			m.jsonrpc.EXPECT().Call("acl.remove", rpcParams(p), gomock.Any(), gomock.Any()).
+				SetArg(2, true).Return(nil)
+		}
+		m.jsonrpc.EXPECT().ValidateResult(true, "ACL removal", gomock.Any()).Return(nil).Times(2)
+
+		assert.NoError(t, svc.Revoke(t.Context(), []payloads.ACLParams{existing, missing, failing}))
+	})
+}
+
+func TestCheck(t *testing.T) {
+	vm := map[string]any{"type": "VM", "$container": testHostID.String(), "$pool": testPoolID.String()}
+	host := map[string]any{"type": "host", "$pool": testPoolID.String()}
+	pool := map[string]any{"type": "pool"}
+
+	t.Run("admins bypass ACLs", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectUsers()
+
+		allowed, err := svc.Check(t.Context(), testAdminID, testVMID, payloads.ACLRoleAdmin)
+
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("role on the object itself", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectUsers()
+		m.expectACLs(&payloads.ACL{Subject: testUserID, Object: testVMID, Action: payloads.ACLRoleOperator})
+
+		allowed, err := svc.Check(t.Context(), testUserID, testVMID, payloads.ACLRoleViewer)
+
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("role inherited from the pool through a group", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectUsers()
+		m.expectACLs(&payloads.ACL{Subject: testGroupID, Object: testPoolID, Action: payloads.ACLRoleOperator})
+		m.expectObject(testVMID, vm)
+		m.expectObject(testHostID, host)
+
+		allowed, err := svc.Check(t.Context(), testUserID, testVMID, payloads.ACLRoleOperator)
+
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("inherited role too low", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectUsers()
+		m.expectACLs(&payloads.ACL{Subject: testUserID, Object: testHostID, Action: payloads.ACLRoleViewer})
+		m.expectObject(testVMID, vm)
+		m.expectObject(testHostID, host)
+		m.expectObject(testPoolID, pool)
+
+		allowed, err := svc.Check(t.Context(), testUserID, testVMID, payloads.ACLRoleOperator)
+
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("group subject", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectUsers()
+		m.group.EXPECT().Get(gomock.Any(), testGroupID).Return(&payloads.Group{ID: testGroupID}, nil)
+		m.expectACLs(&payloads.ACL{Subject: testUserID, Object: testVMID, Action: payloads.ACLRoleAdmin})
+
+		allowed, err := svc.Check(t.Context(), testGroupID, testVMID, payloads.ACLRoleViewer)
+
+		require.NoError(t, err)
+		assert.False(t, allowed, "the ACLs of the group members do not apply to the group")
+	})
+
+	t.Run("unknown object", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectUsers()
+		m.expectACLs(&payloads.ACL{Subject: testUserID, Object: testPoolID, Action: payloads.ACLRoleAdmin})
+		m.expectObject(testVMID, nil)
+
+		_, err := svc.Check(t.Context(), testUserID, testVMID, payloads.ACLRoleViewer)
+
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("unknown subject", func(t *testing.T) {
+		svc, m := setup(t)
+		m.expectUsers()
+		m.group.EXPECT().Get(gomock.Any(), testVMID).Return(nil, fmt.Errorf("group not found"))
+
+		_, err := svc.Check(t.Context(), testVMID, testVMID, payloads.ACLRoleViewer)
+
+		assert.ErrorContains(t, err, "neither a user nor a group")
+	})
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Audit {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) GetRecords(_ context.Context, cursor string, limit int) (*payloads.AuditPage, error) {
+	records, err := s.records(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// XO returns all the records older than the cursor.
+	page := &payloads.AuditPage{Records: records}
+	if limit > 0 && len(records) > limit {
+		page.Records = records[:limit]
+		page.Next = records[limit].ID
+	}
+	return page, nil
+}
+
+func (s *Service) Export(_ context.Context, w io.Writer, cursor string) (int, error) {
+	records, err := s.records(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	// Encoder writes a newline after each record.
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for i, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return i, fmt.Errorf("failed to export audit record %s: %w", record.ID, err)
+		}
+	}
+	return len(records), nil
+}
+
+// records retrieves the records from the cursor to the first one, most recent first.
+func (s *Service) records(cursor string) ([]*payloads.AuditRecord, error) {
+	params := map[string]any{}
+	if cursor != "" {
+		params["id"] = cursor
+	}
+
+	var result []*payloads.AuditRecord
+	if err := s.jsonrpcSvc.Call("audit.getRecords", params, &result, zap.String("cursor", cursor)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) Verify(records []*payloads.AuditRecord) error {
+	for i, record := range records {
+		if i > 0 && records[i-1].PreviousID != record.ID {
+			return fmt.Errorf("audit record %s is missing before record %s", records[i-1].PreviousID, records[i-1].ID)
+		}
+		hash, err := recordHash(record)
+		if err != nil {
+			return err
+		}
+		if hash != record.ID {
+			return fmt.Errorf("audit record %s has been altered", record.ID)
+		}
+	}
+	return nil
+}
+
+// algorithms are the hash algorithms of the record IDs, by identifier.
+var algorithms = map[string]func([]byte) []byte{
+	"5": func(data []byte) []byte {
+		sum := sha256.Sum256(data)
+		return sum[:]
+	},
+}
+
+// recordHash computes the ID of a record, like XO: "$<algorithm>$$" followed by
+// the base64 hash of the record without its ID, serialized by JSON.stringify.
+func recordHash(record *payloads.AuditRecord) (string, error) {
+	algorithm, _, ok := strings.Cut(strings.TrimPrefix(record.ID, "$"), "$")
+	hash := algorithms[algorithm]
+	if !strings.HasPrefix(record.ID, "$") || !ok || hash == nil {
+		return "", fmt.Errorf("audit record %s: unsupported ID format", record.ID)
+	}
+
+	// Same fields, in the same order, as the hashed XO records.
+	content := struct {
+		Data       json.RawMessage       `json:"data,omitempty"`
+		Event      string                `json:"event"`
+		PreviousID string                `json:"previousId"`
+		Subject    payloads.AuditSubject `json:"subject"`
+		Time       int64                 `json:"time"`
+	}{record.Data, record.Event, record.PreviousID, record.Subject, record.Time}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(content); err != nil {
+		return "", fmt.Errorf("audit record %s: %w", record.ID, err)
+	}
+	data := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+
+	return "$" + algorithm + "$$" + base64.StdEncoding.EncodeToString(hash(data)), nil
+}
@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+// recordsJSON is a valid chain of records, most recent first, whose IDs were
+// computed like XO does.
+const recordsJSON = `[
+	{"data":{"userId":"a3b4"},"event":"signIn",
+	 "id":"$5$$TZh7hi0jQxLiTlkwpYiUrknK3hfMMU/Rga6sw0SvLns=",
+	 "previousId":"$5$$ixZcoMQCzmKck8j5M/rf7P1YNopo82sh/a5GNjuVpjc=",
+	 "subject":{"userId":"a3b4","userIp":"::1","userName":"admin@admin.net"},"time":1700000060000},
+	{"data":{"method":"vm.start","params":{"id":"f07ab729-c0e8-721c-45ec-f11276377030"}},"event":"apiCall",
+	 "id":"$5$$ixZcoMQCzmKck8j5M/rf7P1YNopo82sh/a5GNjuVpjc=",
+	 "previousId":"nullId",
+	 "subject":{"userId":"a3b4","userIp":"::1","userName":"admin@admin.net"},"time":1700000000000}
+]`
+
+func setup(t *testing.T) (library.Audit, *mock.MockJSONRPC) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(mockJSONRPC, log), mockJSONRPC
+}
+
+// returnJSON decodes data into the result of the call, like the JSON-RPC client does.
+func returnJSON(t *testing.T, data string) func(string, map[string]any, any, ...any) error {
+	return func(_ string, _ map[string]any, result any, _ ...any) error {
+		require.NoError(t, json.Unmarshal([]byte(data), result))
+		return nil
+	}
+}
+
+func decodeRecords(t *testing.T) []*payloads.AuditRecord {
+	t.Helper()
+	var records []*payloads.AuditRecord
+	require.NoError(t, json.Unmarshal([]byte(recordsJSON), &records))
+	return records
+}
+
+func TestGetRecords(t *testing.T) {
+	t.Run("first page", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("audit.getRecords", map[string]any{}, gomock.Any(), gomock.Any()).
+			DoAndReturn(returnJSON(t, recordsJSON))
+
+		page, err := svc.GetRecords(t.Context(), "", 1)
+
+		require.NoError(t, err)
+		require.Len(t, page.Records, 1)
+		assert.Equal(t, "signIn", page.Records[0].Event)
+		assert.Equal(t, "admin@admin.net", page.Records[0].Subject.UserName)
+		assert.Equal(t, int64(1700000060), page.Records[0].Timestamp().Unix())
+		assert.Equal(t, "$5$$ixZcoMQCzmKck8j5M/rf7P1YNopo82sh/a5GNjuVpjc=", page.Next)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		cursor := "$5$$ixZcoMQCzmKck8j5M/rf7P1YNopo82sh/a5GNjuVpjc="
+		mockJSONRPC.EXPECT().Call("audit.getRecords", map[string]any{"id": cursor}, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, _ map[string]any, result any, _ ...any) error {
+				*result.(*[]*payloads.AuditRecord) = decodeRecords(t)[1:]
+				return nil
+			})
+
+		page, err := svc.GetRecords(t.Context(), cursor, 1)
+
+		require.NoError(t, err)
+		require.Len(t, page.Records, 1)
+		assert.Equal(t, payloads.AuditNullID, page.Records[0].PreviousID)
+		assert.Empty(t, page.Next)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	svc, _ := setup(t)
+
+	t.Run("valid chain", func(t *testing.T) {
+		assert.NoError(t, svc.Verify(decodeRecords(t)))
+	})
+
+	t.Run("altered record", func(t *testing.T) {
+		records := decodeRecords(t)
+		records[1].Data = json.RawMessage(`{"method":"vm.delete","params":{"id":"f07ab729-c0e8-721c-45ec-f11276377030"}}`)
+
+		assert.ErrorContains(t, svc.Verify(records), "$5$$ixZcoMQCzmKck8j5M/rf7P1YNopo82sh/a5GNjuVpjc= has been altered")
+	})
+
+	t.Run("missing record", func(t *testing.T) {
+		records := decodeRecords(t)
+		records[0], records[1] = records[1], records[0]
+
+		assert.ErrorContains(t, svc.Verify(records), "audit record nullId is missing")
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		records := decodeRecords(t)[1:]
+		records[0].ID = "$6$$AAAA"
+
+		assert.ErrorContains(t, svc.Verify(records), "unsupported ID format")
+	})
+}
+
+func TestExport(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("audit.getRecords", map[string]any{}, gomock.Any(), gomock.Any()).
+		DoAndReturn(returnJSON(t, recordsJSON))
+
+	var buf bytes.Buffer
+	n, err := svc.Export(t.Context(), &buf, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// The exported records can be verified again.
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	records := make([]*payloads.AuditRecord, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &records[i]))
+	}
+	assert.NoError(t, svc.Verify(records))
+}
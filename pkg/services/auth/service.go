@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+)
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Auth {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) CreateToken(
+	ctx context.Context, description string, expiresIn time.Duration) (*payloads.AuthToken, error) {
+	params := map[string]any{}
+	if description != "" {
+		params["description"] = description
+	}
+	if expiresIn > 0 {
+		params["expiresIn"] = expiresIn.Milliseconds()
+	}
+
+	var token string
+	if err := s.jsonrpcSvc.Call("token.create", params, &token); err != nil {
+		return nil, err
+	}
+
+	// The creation only returns the token: retrieve its other properties.
+	tokens, err := s.ListTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.ID == token {
+			return t, nil
+		}
+	}
+	// Should not happen, but the token is usable anyway.
+	s.log.Warn("Created token not found in the tokens of the current user")
+	return &payloads.AuthToken{ID: token, Description: description}, nil
+}
+
+func (s *Service) ListTokens(_ context.Context) ([]*payloads.AuthToken, error) {
+	var result []*payloads.AuthToken
+	if err := s.jsonrpcSvc.Call("user.getAuthenticationTokens", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) RevokeToken(_ context.Context, id string) error {
+	var result any
+	return s.jsonrpcSvc.Call("token.delete", map[string]any{"token": id}, &result)
+}
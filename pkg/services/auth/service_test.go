@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+const testToken = "Xk7rS1pQ0n9EYc3dZ2vB5wA8uL4mT6hJgF1oR0sNqPi"
+
+func setup(t *testing.T) (library.Auth, *mock.MockJSONRPC) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(mockJSONRPC, log), mockJSONRPC
+}
+
+func TestCreateToken(t *testing.T) {
+	expiration := payloads.APITime(time.UnixMilli(1767225600000))
+
+	t.Run("with expiration", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("token.create", map[string]any{
+			"description": "CI pipeline",
+			"expiresIn":   int64(3600000),
+		}, gomock.Any()).SetArg(2, testToken).Return(nil)
+		mockJSONRPC.EXPECT().Call("user.getAuthenticationTokens", map[string]any{}, gomock.Any()).
+			SetArg(2, []*payloads.AuthToken{
+				{ID: "other"},
+				{ID: testToken, Description: "CI pipeline", Expiration: expiration},
+			}).Return(nil)
+
+		token, err := svc.CreateToken(t.Context(), "CI pipeline", time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, testToken, token.ID)
+		assert.Equal(t, expiration, token.Expiration)
+	})
+
+	t.Run("default expiration", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("token.create", map[string]any{}, gomock.Any()).
+			SetArg(2, testToken).Return(nil)
+		mockJSONRPC.EXPECT().Call("user.getAuthenticationTokens", map[string]any{}, gomock.Any()).
+			SetArg(2, []*payloads.AuthToken{}).Return(nil)
+
+		token, err := svc.CreateToken(t.Context(), "", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, testToken, token.ID)
+	})
+
+	t.Run("call failure", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("token.create", gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("JSON-RPC call to token.create failed"))
+
+		token, err := svc.CreateToken(t.Context(), "CI pipeline", time.Hour)
+
+		assert.Error(t, err)
+		assert.Nil(t, token)
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("token.delete", map[string]any{"token": testToken}, gomock.Any()).Return(nil)
+
+	assert.NoError(t, svc.RevokeToken(t.Context(), testToken))
+}
@@ -0,0 +1,580 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+// XO job types, each one managed by its own set of JSON-RPC methods.
+const (
+	jobTypeVM       = "backup"
+	jobTypeMirror   = "mirrorBackup"
+	jobTypeMetadata = "metadataBackup"
+)
+
+// methodPrefixes are the JSON-RPC namespaces of the job types.
+var methodPrefixes = map[string]string{
+	jobTypeVM:       "backupNg",
+	jobTypeMirror:   "mirrorBackup",
+	jobTypeMetadata: "metadataBackup",
+}
+
+// jobType returns the XO job type of a mode.
+func jobType(mode payloads.BackupMode) (string, error) {
+	switch mode {
+	case payloads.BackupModeFull, payloads.BackupModeDelta, payloads.BackupModeContinuousReplication:
+		return jobTypeVM, nil
+	case payloads.BackupModeMirror:
+		return jobTypeMirror, nil
+	case payloads.BackupModeMetadata:
+		return jobTypeMetadata, nil
+	default:
+		return "", fmt.Errorf("unknown backup mode %q", mode)
+	}
+}
+
+// rpcJob is a backup job as stored by XO. Objects are selected by patterns
+// such as {"id": {"__or": [...]}}, settings are indexed by schedule ID, the
+// settings of the job itself being under the empty key.
+type rpcJob struct {
+	ID           uuid.UUID                 `json:"id"`
+	Type         string                    `json:"type"`
+	Name         string                    `json:"name"`
+	Mode         string                    `json:"mode"`
+	Compression  string                    `json:"compression"`
+	VMs          map[string]any            `json:"vms"`
+	Remotes      map[string]any            `json:"remotes"`
+	SRs          map[string]any            `json:"srs"`
+	Pools        map[string]any            `json:"pools"`
+	SourceRemote uuid.UUID                 `json:"sourceRemote"`
+	XOMetadata   bool                      `json:"xoMetadata"`
+	Settings     map[string]map[string]any `json:"settings"`
+}
+
+// retentionKeys are the settings of the schedules described by BackupRetention.
+var retentionKeys = []string{
+	"exportRetention", "copyRetention", "snapshotRetention", "retentionPoolMetadata", "retentionXoMetadata",
+}
+
+// fromRPC returns the job described by XO. scheduleIDs are the IDs of the
+// schedules, to tell their settings from the settings of the VMs.
+func fromRPC(job *rpcJob, scheduleIDs map[uuid.UUID]bool) (*payloads.BackupJob, error) {
+	result := &payloads.BackupJob{
+		ID:          job.ID,
+		Name:        job.Name,
+		Compression: job.Compression,
+	}
+	var err error
+	if result.Remotes, err = patternIDs(job.Remotes); err != nil {
+		return nil, fmt.Errorf("backup job %s: remotes: %w", job.ID, err)
+	}
+
+	switch job.Type {
+	case jobTypeVM:
+		result.Mode = payloads.BackupMode(job.Mode)
+		if result.SRs, err = patternIDs(job.SRs); err != nil {
+			return nil, fmt.Errorf("backup job %s: SRs: %w", job.ID, err)
+		}
+		if result.Mode == payloads.BackupModeDelta && len(result.SRs) > 0 && len(result.Remotes) == 0 {
+			result.Mode = payloads.BackupModeContinuousReplication
+		}
+		if _, ok := job.VMs["id"]; ok {
+			if result.VMs, err = patternIDs(job.VMs); err != nil {
+				return nil, fmt.Errorf("backup job %s: VMs: %w", job.ID, err)
+			}
+		} else if result.Smart, err = smartSelection(job.VMs); err != nil {
+			return nil, fmt.Errorf("backup job %s: VMs: %w", job.ID, err)
+		}
+	case jobTypeMirror:
+		result.Mode = payloads.BackupModeMirror
+		result.SourceRemote = job.SourceRemote
+		result.Incremental = job.Mode == string(payloads.BackupModeDelta)
+	case jobTypeMetadata:
+		result.Mode = payloads.BackupModeMetadata
+		result.XOMetadata = job.XOMetadata
+		if result.Pools, err = patternIDs(job.Pools); err != nil {
+			return nil, fmt.Errorf("backup job %s: pools: %w", job.ID, err)
+		}
+	default:
+		return nil, fmt.Errorf("backup job %s: unknown type %q", job.ID, job.Type)
+	}
+
+	result.Retention = make(map[uuid.UUID]payloads.BackupRetention)
+	for key, settings := range job.Settings {
+		if key == "" {
+			result.Settings = jobSettings(settings)
+			continue
+		}
+		other := maps.Clone(settings)
+		if scheduleID, err := uuid.FromString(key); err == nil && scheduleIDs[scheduleID] {
+			result.Retention[scheduleID] = retention(settings)
+			for _, name := range retentionKeys {
+				delete(other, name)
+			}
+		}
+		// Settings of something else than a schedule, e.g. a VM, or of a
+		// schedule but not its retention, e.g. its health check.
+		if len(other) > 0 {
+			if result.OtherSettings == nil {
+				result.OtherSettings = make(map[string]map[string]any)
+			}
+			result.OtherSettings[key] = other
+		}
+	}
+	return result, nil
+}
+
+// toRPC returns the parameters of the creation or edition of a job. The
+// retention is only set for the given schedules, by key, the other settings
+// of the job being sent unchanged.
+func toRPC(job *payloads.BackupJob, retentions map[string]payloads.BackupRetention) map[string]any {
+	settings := map[string]any{"": globalSettings(job.Settings)}
+	for key, other := range job.OtherSettings {
+		settings[key] = maps.Clone(other)
+	}
+	for key, r := range retentions {
+		merged, _ := settings[key].(map[string]any)
+		if merged == nil {
+			merged = make(map[string]any)
+		}
+		maps.Copy(merged, retentionSettings(job.Mode, r))
+		settings[key] = merged
+	}
+
+	params := map[string]any{
+		"name":     job.Name,
+		"remotes":  idPattern(job.Remotes),
+		"settings": settings,
+	}
+	switch job.Mode {
+	case payloads.BackupModeMirror:
+		params["mode"] = string(payloads.BackupModeFull)
+		if job.Incremental {
+			params["mode"] = string(payloads.BackupModeDelta)
+		}
+		params["sourceRemote"] = job.SourceRemote.String()
+	case payloads.BackupModeMetadata:
+		params["xoMetadata"] = job.XOMetadata
+		if len(job.Pools) > 0 {
+			params["pools"] = idPattern(job.Pools)
+		}
+	default:
+		params["mode"] = string(job.Mode)
+		if job.Mode == payloads.BackupModeContinuousReplication {
+			params["mode"] = string(payloads.BackupModeDelta)
+		}
+		params["compression"] = job.Compression
+		params["srs"] = idPattern(job.SRs)
+		if job.Smart != nil {
+			params["vms"] = smartPattern(job.Smart)
+		} else {
+			params["vms"] = idPattern(job.VMs)
+		}
+	}
+	return params
+}
+
+// validate checks the consistency of a job before sending it to XO, whose
+// errors are not explicit about the missing fields.
+func validate(job *payloads.BackupJob) error {
+	if job.Name == "" {
+		return fmt.Errorf("backup job name is required")
+	}
+	if _, err := jobType(job.Mode); err != nil {
+		return err
+	}
+
+	switch job.Mode {
+	case payloads.BackupModeFull:
+		if len(job.Remotes) == 0 && len(job.SRs) == 0 {
+			return fmt.Errorf("full backup job requires remotes or SRs")
+		}
+	case payloads.BackupModeDelta:
+		if len(job.Remotes) == 0 {
+			return fmt.Errorf("delta backup job requires remotes")
+		}
+	case payloads.BackupModeContinuousReplication:
+		if len(job.SRs) == 0 {
+			return fmt.Errorf("continuous replication job requires SRs")
+		}
+	case payloads.BackupModeMirror:
+		if job.SourceRemote == uuid.Nil || len(job.Remotes) == 0 {
+			return fmt.Errorf("mirror backup job requires a source remote and remotes")
+		}
+		if slices.Contains(job.Remotes, job.SourceRemote) {
+			return fmt.Errorf("mirror backup job can't mirror remote %s to itself", job.SourceRemote)
+		}
+		return nil
+	case payloads.BackupModeMetadata:
+		if len(job.Pools) == 0 && !job.XOMetadata {
+			return fmt.Errorf("metadata backup job requires pools or XO metadata")
+		}
+		if len(job.Remotes) == 0 {
+			return fmt.Errorf("metadata backup job requires remotes")
+		}
+		return nil
+	}
+
+	switch {
+	case len(job.VMs) > 0 && job.Smart != nil:
+		return fmt.Errorf("backup job can't select VMs both explicitly and with smart mode")
+	case len(job.VMs) == 0 && job.Smart == nil:
+		return fmt.Errorf("backup job requires VMs or a smart selection")
+	}
+	return nil
+}
+
+// idPattern returns the XO pattern matching the objects with the given IDs.
+func idPattern(ids []uuid.UUID) map[string]any {
+	if len(ids) == 1 {
+		return map[string]any{"id": ids[0].String()}
+	}
+	return map[string]any{"id": map[string]any{"__or": uuidStrings(ids)}}
+}
+
+// patternIDs returns the IDs matched by an XO pattern built by idPattern.
+func patternIDs(pattern map[string]any) ([]uuid.UUID, error) {
+	if len(pattern) == 0 {
+		return nil, nil
+	}
+	values, err := orValues(pattern["id"])
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected ID %v", value)
+		}
+		id, err := uuid.FromString(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// orValues returns the values of a {"__or": [...]} pattern, or the value
+// itself when it is not such a pattern.
+func orValues(pattern any) ([]any, error) {
+	switch p := pattern.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		values, ok := p["__or"].([]any)
+		if !ok {
+			return nil, fmt.Errorf("unsupported pattern %v", p)
+		}
+		return values, nil
+	default:
+		return []any{p}, nil
+	}
+}
+
+// smartPattern returns the XO pattern matching the VMs of a smart selection.
+// As the tags of a VM are an array, each tag is matched by an array pattern.
+func smartPattern(smart *payloads.BackupSmartSelection) map[string]any {
+	pattern := map[string]any{"type": "VM"}
+	if smart.PowerState != "" {
+		pattern["power_state"] = smart.PowerState
+	}
+	if p := inclusionPattern(uuidStrings(smart.Pools), uuidStrings(smart.NotPools), false); p != nil {
+		pattern["$pool"] = p
+	}
+	if p := inclusionPattern(smart.Tags, smart.NotTags, true); p != nil {
+		pattern["tags"] = p
+	}
+	return pattern
+}
+
+func inclusionPattern(values []string, excluded []string, arrays bool) map[string]any {
+	or := func(values []string) map[string]any {
+		items := make([]any, len(values))
+		for i, v := range values {
+			if arrays {
+				items[i] = []string{v}
+			} else {
+				items[i] = v
+			}
+		}
+		return map[string]any{"__or": items}
+	}
+	switch {
+	case len(values) > 0 && len(excluded) > 0:
+		return map[string]any{"__and": []any{or(values), map[string]any{"__not": or(excluded)}}}
+	case len(values) > 0:
+		return or(values)
+	case len(excluded) > 0:
+		return map[string]any{"__not": or(excluded)}
+	default:
+		return nil
+	}
+}
+
+// smartSelection parses a pattern built by smartPattern.
+func smartSelection(pattern map[string]any) (*payloads.BackupSmartSelection, error) {
+	smart := &payloads.BackupSmartSelection{}
+	smart.PowerState, _ = pattern["power_state"].(string)
+
+	pools, notPools, err := parseInclusion(pattern["$pool"])
+	if err != nil {
+		return nil, fmt.Errorf("pools: %w", err)
+	}
+	for _, p := range pools {
+		id, err := uuid.FromString(p)
+		if err != nil {
+			return nil, err
+		}
+		smart.Pools = append(smart.Pools, id)
+	}
+	for _, p := range notPools {
+		id, err := uuid.FromString(p)
+		if err != nil {
+			return nil, err
+		}
+		smart.NotPools = append(smart.NotPools, id)
+	}
+
+	if smart.Tags, smart.NotTags, err = parseInclusion(pattern["tags"]); err != nil {
+		return nil, fmt.Errorf("tags: %w", err)
+	}
+	return smart, nil
+}
+
+func parseInclusion(pattern any) (values []string, excluded []string, err error) {
+	p, ok := pattern.(map[string]any)
+	if !ok {
+		if pattern != nil {
+			return nil, nil, fmt.Errorf("unsupported pattern %v", pattern)
+		}
+		return nil, nil, nil
+	}
+	if and, ok := p["__and"].([]any); ok {
+		for _, sub := range and {
+			v, e, err := parseInclusion(sub)
+			if err != nil {
+				return nil, nil, err
+			}
+			values = append(values, v...)
+			excluded = append(excluded, e...)
+		}
+		return values, excluded, nil
+	}
+	if not, ok := p["__not"]; ok {
+		excluded, _, err = parseInclusion(not)
+		return nil, excluded, err
+	}
+	items, err := orValues(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, item := range items {
+		// Tags are matched by arrays of tags, all of which the VM must have.
+		if arr, ok := item.([]any); ok {
+			for _, v := range arr {
+				if s, ok := v.(string); ok {
+					values = append(values, s)
+				}
+			}
+			continue
+		}
+		s, ok := item.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected value %v", item)
+		}
+		values = append(values, s)
+	}
+	return values, nil, nil
+}
+
+func jobSettings(settings map[string]any) payloads.BackupSettings {
+	result := payloads.BackupSettings{}
+	other := maps.Clone(settings)
+	if v, ok := other["reportWhen"].(string); ok {
+		result.ReportWhen = v
+		delete(other, "reportWhen")
+	}
+	if v, ok := other["concurrency"].(float64); ok {
+		result.Concurrency = int(v)
+		delete(other, "concurrency")
+	}
+	if v, ok := other["offlineSnapshot"].(bool); ok {
+		result.OfflineSnapshot = v
+		delete(other, "offlineSnapshot")
+	}
+	if len(other) > 0 {
+		result.Other = other
+	}
+	return result
+}
+
+func globalSettings(settings payloads.BackupSettings) map[string]any {
+	result := maps.Clone(settings.Other)
+	if result == nil {
+		result = make(map[string]any)
+	}
+	if settings.ReportWhen != "" {
+		result["reportWhen"] = settings.ReportWhen
+	}
+	if settings.Concurrency > 0 {
+		result["concurrency"] = settings.Concurrency
+	}
+	result["offlineSnapshot"] = settings.OfflineSnapshot
+	return result
+}
+
+func retention(settings map[string]any) payloads.BackupRetention {
+	value := func(key string) int {
+		v, _ := settings[key].(float64)
+		return int(v)
+	}
+	return payloads.BackupRetention{
+		// Metadata jobs have a retention per kind of metadata, both set to the same value by the SDK.
+		Export:   max(value("exportRetention"), value("retentionPoolMetadata"), value("retentionXoMetadata")),
+		Copy:     value("copyRetention"),
+		Snapshot: value("snapshotRetention"),
+	}
+}
+
+func retentionSettings(mode payloads.BackupMode, r payloads.BackupRetention) map[string]any {
+	switch mode {
+	case payloads.BackupModeMetadata:
+		return map[string]any{"retentionPoolMetadata": r.Export, "retentionXoMetadata": r.Export}
+	case payloads.BackupModeMirror:
+		return map[string]any{"exportRetention": r.Export}
+	default:
+		return map[string]any{
+			"exportRetention":   r.Export,
+			"copyRetention":     r.Copy,
+			"snapshotRetention": r.Snapshot,
+		}
+	}
+}
+
+// rpcTask is a step of a backup run. The run itself is the root task.
+type rpcTask struct {
+	ID         string           `json:"id"`
+	Message    string           `json:"message"`
+	Data       rpcTaskData      `json:"data"`
+	JobID      uuid.UUID        `json:"jobId"`
+	JobName    string           `json:"jobName"`
+	ScheduleID uuid.UUID        `json:"scheduleId"`
+	Start      payloads.APITime `json:"start"`
+	End        payloads.APITime `json:"end"`
+	Status     string           `json:"status"`
+	Result     json.RawMessage  `json:"result"`
+	Tasks      []rpcTask        `json:"tasks"`
+}
+
+type rpcTaskData struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type rpcTaskResult struct {
+	Message string `json:"message"`
+	Size    int64  `json:"size"`
+}
+
+func (t *rpcTask) result() rpcTaskResult {
+	var result rpcTaskResult
+	// The result of some tasks is not an object, it is ignored.
+	_ = json.Unmarshal(t.Result, &result)
+	return result
+}
+
+// transferred returns the number of bytes transferred by a task and its subtasks.
+func (t *rpcTask) transferred() int64 {
+	if t.Message == "transfer" {
+		return t.result().Size
+	}
+	var size int64
+	for i := range t.Tasks {
+		size += t.Tasks[i].transferred()
+	}
+	return size
+}
+
+func logFromRPC(run *rpcTask) *payloads.BackupLog {
+	log := &payloads.BackupLog{
+		ID:         run.ID,
+		JobID:      run.JobID,
+		JobName:    run.JobName,
+		ScheduleID: run.ScheduleID,
+		Start:      run.Start,
+		End:        run.End,
+		Status:     payloads.BackupStatus(run.Status),
+	}
+	if log.Status == payloads.BackupStatusFailure {
+		log.Error = run.result().Message
+	}
+	for i := range run.Tasks {
+		task := &run.Tasks[i]
+		if task.Data.Type != "VM" {
+			continue
+		}
+		vm := payloads.BackupVMResult{
+			Start:  task.Start,
+			End:    task.End,
+			Status: payloads.BackupStatus(task.Status),
+			Size:   task.transferred(),
+		}
+		vm.VMID, _ = uuid.FromString(task.Data.ID)
+		if vm.Status == payloads.BackupStatusFailure || vm.Status == payloads.BackupStatusSkipped {
+			vm.Error = task.result().Message
+		}
+		log.VMs = append(log.VMs, vm)
+	}
+	return log
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
+
+// rpcRestorePoint is the metadata of a VM backup, as returned by XO.
+type rpcRestorePoint struct {
+	ID         string           `json:"id"`
+	JobID      uuid.UUID        `json:"jobId"`
+	ScheduleID uuid.UUID        `json:"scheduleId"`
+	Mode       string           `json:"mode"`
+	Size       int64            `json:"size"`
+	Timestamp  payloads.APITime `json:"timestamp"`
+	VM         struct {
+		NameLabel string `json:"name_label"`
+	} `json:"vm"`
+	Disks []struct {
+		ID   string    `json:"id"`
+		Name string    `json:"name"`
+		UUID uuid.UUID `json:"uuid"`
+	} `json:"disks"`
+}
+
+func restorePointFromRPC(remoteID uuid.UUID, vmID uuid.UUID, point *rpcRestorePoint) *payloads.RestorePoint {
+	result := &payloads.RestorePoint{
+		ID:         point.ID,
+		RemoteID:   remoteID,
+		VMID:       vmID,
+		VMName:     point.VM.NameLabel,
+		Timestamp:  point.Timestamp,
+		Mode:       payloads.BackupMode(point.Mode),
+		JobID:      point.JobID,
+		ScheduleID: point.ScheduleID,
+		Size:       point.Size,
+	}
+	for _, disk := range point.Disks {
+		result.Disks = append(result.Disks, payloads.RestorePointDisk{ID: disk.ID, Name: disk.Name, UUID: disk.UUID})
+	}
+	return result
+}
@@ -0,0 +1,365 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/cron"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	// Needed by Restore to start the restored VM
+	vmService   library.VM
+	taskService library.Task
+
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(vm library.VM, task library.Task, jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Backup {
+	return &Service{
+		vmService:   vm,
+		taskService: task,
+		jsonrpcSvc:  jsonrpcSvc,
+		log:         log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.BackupJob, error) {
+	jobs, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+	return nil, fmt.Errorf("backup job %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.BackupJob, error) {
+	schedules, err := s.allSchedules()
+	if err != nil {
+		return nil, err
+	}
+	scheduleIDs := make(map[uuid.UUID]bool, len(schedules))
+	for _, schedule := range schedules {
+		scheduleIDs[schedule.ID] = true
+	}
+
+	var jobs []*payloads.BackupJob
+	for _, kind := range []string{jobTypeVM, jobTypeMirror, jobTypeMetadata} {
+		var result []*rpcJob
+		if err := s.jsonrpcSvc.Call(methodPrefixes[kind]+".getAllJobs", map[string]any{}, &result); err != nil {
+			return nil, err
+		}
+		for _, raw := range result {
+			// The type is missing from the jobs of old XO versions.
+			if raw.Type == "" {
+				raw.Type = kind
+			}
+			job, err := fromRPC(raw, scheduleIDs)
+			if err != nil {
+				// A job this SDK does not understand must not hide the others.
+				s.log.Warn("Skipping backup job", zap.String("jobID", raw.ID.String()), zap.Error(err))
+				continue
+			}
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (s *Service) Create(ctx context.Context, job *payloads.BackupJob,
+	schedules []payloads.BackupScheduleParams) (*payloads.BackupJob, error) {
+	if err := validate(job); err != nil {
+		return nil, err
+	}
+	kind, _ := jobType(job.Mode)
+
+	// XO creates the schedules with the job, and replaces their temporary
+	// keys by their IDs in the settings.
+	retentions := make(map[string]payloads.BackupRetention, len(schedules))
+	rpcSchedules := make(map[string]any, len(schedules))
+	for i, schedule := range schedules {
+		if _, err := cron.Parse(schedule.Cron, schedule.Timezone); err != nil {
+			return nil, fmt.Errorf("schedule %d: %w", i, err)
+		}
+		key := "schedule" + strconv.Itoa(i)
+		retentions[key] = schedule.Retention
+		rpcSchedule := map[string]any{
+			"cron":    schedule.Cron,
+			"enabled": schedule.Enabled,
+			"name":    schedule.Name,
+		}
+		if schedule.Timezone != "" {
+			rpcSchedule["timezone"] = schedule.Timezone
+		}
+		rpcSchedules[key] = rpcSchedule
+	}
+	params := toRPC(job, retentions)
+	params["schedules"] = rpcSchedules
+
+	var id uuid.UUID
+	if err := s.jsonrpcSvc.Call(methodPrefixes[kind]+".createJob", params, &id,
+		zap.String("name", job.Name), zap.String("mode", string(job.Mode))); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *Service) Update(ctx context.Context, job *payloads.BackupJob) (*payloads.BackupJob, error) {
+	if err := validate(job); err != nil {
+		return nil, err
+	}
+	current, err := s.Get(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	kind, _ := jobType(job.Mode)
+	currentKind, _ := jobType(current.Mode)
+	if kind != currentKind {
+		return nil, fmt.Errorf("can't change the mode of backup job %s from %s to %s",
+			job.ID, current.Mode, job.Mode)
+	}
+
+	retentions := make(map[string]payloads.BackupRetention, len(job.Retention))
+	for scheduleID, r := range job.Retention {
+		retentions[scheduleID.String()] = r
+	}
+	params := toRPC(job, retentions)
+	params["id"] = job.ID.String()
+
+	var result any
+	if err := s.jsonrpcSvc.Call(methodPrefixes[kind]+".editJob", params, &result,
+		zap.String("jobID", job.ID.String())); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, job.ID)
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	kind, _ := jobType(job.Mode)
+
+	var result any
+	return s.jsonrpcSvc.Call(methodPrefixes[kind]+".deleteJob", map[string]any{"id": id.String()}, &result,
+		zap.String("jobID", id.String()))
+}
+
+func (s *Service) RunNow(ctx context.Context, id uuid.UUID, scheduleID uuid.UUID) error {
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	kind, _ := jobType(job.Mode)
+
+	// A run needs a schedule, which gives the retention of its backups.
+	schedules, err := s.schedules(id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(schedules) == 0:
+		return fmt.Errorf("backup job %s has no schedule", id)
+	case scheduleID == uuid.Nil:
+		scheduleID = schedules[0]
+	case !slices.Contains(schedules, scheduleID):
+		return fmt.Errorf("schedule %s does not belong to backup job %s", scheduleID, id)
+	}
+
+	params := map[string]any{"id": id.String(), "schedule": scheduleID.String()}
+	var result any
+	if err := s.jsonrpcSvc.Call(methodPrefixes[kind]+".runJob", params, &result,
+		zap.String("jobID", id.String()), zap.String("scheduleID", scheduleID.String())); err != nil {
+		return err
+	}
+	s.log.Info("Backup job started", zap.String("jobID", id.String()), zap.String("name", job.Name))
+	return nil
+}
+
+func (s *Service) GetLogs(_ context.Context, jobID uuid.UUID) ([]*payloads.BackupLog, error) {
+	var result map[string]*rpcTask
+	if err := s.jsonrpcSvc.Call("backupNg.getAllLogs", map[string]any{}, &result,
+		zap.String("jobID", jobID.String())); err != nil {
+		return nil, err
+	}
+
+	var logs []*payloads.BackupLog
+	for id, run := range result {
+		if run.JobID != jobID {
+			continue
+		}
+		if run.ID == "" {
+			run.ID = id
+		}
+		logs = append(logs, logFromRPC(run))
+	}
+	slices.SortFunc(logs, func(a, b *payloads.BackupLog) int {
+		return b.Start.Time().Compare(a.Start.Time())
+	})
+	return logs, nil
+}
+
+func (s *Service) ListRestorePoints(
+	_ context.Context, remoteID uuid.UUID, vmFilter string) ([]*payloads.RestorePoint, error) {
+	var result map[string]map[string][]*rpcRestorePoint
+	params := map[string]any{"remotes": []string{remoteID.String()}}
+	if err := s.jsonrpcSvc.Call("backupNg.listVmBackups", params, &result,
+		zap.String("remoteID", remoteID.String())); err != nil {
+		return nil, err
+	}
+
+	filter := strings.ToLower(vmFilter)
+	var points []*payloads.RestorePoint
+	for vm, backups := range result[remoteID.String()] {
+		vmID, err := uuid.FromString(vm)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected VM ID %q in the backups of remote %s: %w", vm, remoteID, err)
+		}
+		for _, backup := range backups {
+			if filter != "" && vm != filter && !strings.Contains(strings.ToLower(backup.VM.NameLabel), filter) {
+				continue
+			}
+			points = append(points, restorePointFromRPC(remoteID, vmID, backup))
+		}
+	}
+	slices.SortFunc(points, func(a, b *payloads.RestorePoint) int {
+		return b.Timestamp.Time().Compare(a.Timestamp.Time())
+	})
+	return points, nil
+}
+
+func (s *Service) Restore(
+	ctx context.Context, restorePointID string, targetSR uuid.UUID, startAfter bool) (uuid.UUID, error) {
+	logContext := []zap.Field{zap.String("restorePointID", restorePointID), zap.String("srID", targetSR.String())}
+
+	// XO answers once the VM is restored, without a task to follow. Canceling
+	// ctx stops waiting, but not the restoration.
+	var vmID uuid.UUID
+	params := map[string]any{"id": restorePointID, "sr": targetSR.String()}
+	if err := s.jsonrpcSvc.CallContext(ctx, "backupNg.importVmBackup", params, &vmID, logContext...); err != nil {
+		return uuid.Nil, err
+	}
+	s.log.Info("VM restored", append(logContext, zap.String("vmID", vmID.String()))...)
+	if !startAfter {
+		return vmID, nil
+	}
+
+	taskID, err := s.vmService.Start(ctx, vmID, nil)
+	if err != nil {
+		return vmID, fmt.Errorf("VM %s restored but failed to start: %w", vmID, err)
+	}
+	task, err := s.taskService.Wait(ctx, taskID)
+	if err != nil {
+		return vmID, fmt.Errorf("VM %s restored but failed to start: %w", vmID, err)
+	}
+	if task.Status != payloads.Success {
+		return vmID, fmt.Errorf("VM %s restored but failed to start: %s", vmID, task.Result.Message)
+	}
+	return vmID, nil
+}
+
+func (s *Service) ListPartitions(
+	_ context.Context, remoteID uuid.UUID, diskID string) ([]*payloads.DiskPartition, error) {
+	var result []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+		Size int64  `json:"size"`
+	}
+	params := map[string]any{"remote": remoteID.String(), "disk": diskPath(remoteID, diskID)}
+	if err := s.jsonrpcSvc.Call("backupNg.listPartitions", params, &result,
+		zap.String("remoteID", remoteID.String()), zap.String("diskID", diskID)); err != nil {
+		return nil, err
+	}
+
+	partitions := make([]*payloads.DiskPartition, len(result))
+	for i, p := range result {
+		partitions[i] = &payloads.DiskPartition{ID: p.ID, Name: p.Name, Type: p.Type, Size: p.Size}
+	}
+	return partitions, nil
+}
+
+func (s *Service) ListFiles(_ context.Context, remoteID uuid.UUID, diskID string, partitionID string,
+	path string) ([]*payloads.BackupFile, error) {
+	// XO lists the content of directories, whose paths end with a slash.
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path %q must be absolute", path)
+	}
+
+	params := map[string]any{
+		"remote": remoteID.String(),
+		"disk":   diskPath(remoteID, diskID),
+		"path":   path,
+	}
+	if partitionID != "" {
+		params["partition"] = partitionID
+	}
+	var result map[string]any
+	if err := s.jsonrpcSvc.Call("backupNg.listFiles", params, &result,
+		zap.String("remoteID", remoteID.String()), zap.String("diskID", diskID), zap.String("path", path)); err != nil {
+		return nil, err
+	}
+
+	// The directories are the names ending with a slash.
+	files := make([]*payloads.BackupFile, 0, len(result))
+	for _, name := range slices.Sorted(maps.Keys(result)) {
+		file := &payloads.BackupFile{Name: strings.TrimSuffix(name, "/"), IsDir: strings.HasSuffix(name, "/")}
+		file.Path = path + file.Name
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// diskPath returns the path of a disk on its remote. The IDs of the disks of
+// the restore points are prefixed by the ID of the remote.
+func diskPath(remoteID uuid.UUID, diskID string) string {
+	return strings.TrimPrefix(diskID, remoteID.String())
+}
+
+// rpcSchedule is the part of an XO schedule needed by the backup jobs.
+type rpcSchedule struct {
+	ID    uuid.UUID `json:"id"`
+	JobID uuid.UUID `json:"jobId"`
+}
+
+// allSchedules returns the schedules of all the jobs, in XO order.
+func (s *Service) allSchedules() ([]rpcSchedule, error) {
+	var result []rpcSchedule
+	if err := s.jsonrpcSvc.Call("schedule.getAll", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// schedules returns the IDs of the schedules of a job, in XO order.
+func (s *Service) schedules(jobID uuid.UUID) ([]uuid.UUID, error) {
+	result, err := s.allSchedules()
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for _, schedule := range result {
+		if schedule.JobID == jobID {
+			ids = append(ids, schedule.ID)
+		}
+	}
+	return ids, nil
+}
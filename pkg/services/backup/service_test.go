@@ -0,0 +1,692 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+var (
+	testJobID      = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e01"))
+	testMirrorID   = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e02"))
+	testMetadataID = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e03"))
+	testSmartID    = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e04"))
+	testScheduleID = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11"))
+	testSchedule2  = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e12"))
+	testRemoteID1  = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21"))
+	testRemoteID2  = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e22"))
+	testSRID       = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e31"))
+	testVMID1      = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41"))
+	testVMID2      = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e42"))
+	testPoolID     = uuid.Must(uuid.FromString("0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e51"))
+)
+
+const vmJobsJSON = `[
+	{
+		"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e01",
+		"type": "backup",
+		"name": "nightly",
+		"mode": "delta",
+		"compression": "",
+		"vms": {"id": {"__or": ["0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41", "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e42"]}},
+		"remotes": {"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21"},
+		"srs": {"id": {"__or": []}},
+		"settings": {
+			"": {"reportWhen": "failure", "concurrency": 2, "offlineSnapshot": false, "timezone": "UTC"},
+			"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11": {
+				"exportRetention": 7, "copyRetention": 0, "snapshotRetention": 1, "fullInterval": 7
+			},
+			"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41": {"exportRetention": 2}
+		}
+	},
+	{
+		"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e04",
+		"type": "backup",
+		"name": "replication",
+		"mode": "delta",
+		"vms": {
+			"type": "VM",
+			"power_state": "Running",
+			"$pool": {"__not": {"__or": ["0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e51"]}},
+			"tags": {"__or": [["prod"], ["critical"]]}
+		},
+		"remotes": {"id": {"__or": []}},
+		"srs": {"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e31"},
+		"settings": {"": {}}
+	}
+]`
+
+const mirrorJobsJSON = `[{
+	"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e02",
+	"type": "mirrorBackup",
+	"name": "offsite",
+	"mode": "delta",
+	"sourceRemote": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21",
+	"remotes": {"id": {"__or": ["0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e22"]}},
+	"settings": {"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11": {"exportRetention": 30}}
+}]`
+
+const metadataJobsJSON = `[{
+	"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e03",
+	"type": "metadataBackup",
+	"name": "config",
+	"pools": {"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e51"},
+	"remotes": {"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e22"},
+	"xoMetadata": true,
+	"settings": {"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11": {"retentionPoolMetadata": 5, "retentionXoMetadata": 5}}
+}]`
+
+func setup(t *testing.T) (library.Backup, *mock.MockJSONRPC) {
+	svc, mockJSONRPC, _, _ := setupWithVM(t)
+	return svc, mockJSONRPC
+}
+
+func setupWithVM(t *testing.T) (library.Backup, *mock.MockJSONRPC, *mock.MockVM, *mock.MockTask) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	ctrl := gomock.NewController(t)
+	mockJSONRPC := mock.NewMockJSONRPC(ctrl)
+	mockVM := mock.NewMockVM(ctrl)
+	mockTask := mock.NewMockTask(ctrl)
+	return New(mockVM, mockTask, mockJSONRPC, log), mockJSONRPC, mockVM, mockTask
+}
+
+// returnJSON decodes data into the result of the call, like the JSON-RPC client does.
+func returnJSON(t *testing.T, data string) func(string, map[string]any, any, ...any) error {
+	return func(_ string, _ map[string]any, result any, _ ...any) error {
+		require.NoError(t, json.Unmarshal([]byte(data), result))
+		return nil
+	}
+}
+
+// schedulesJSON are the schedules of the jobs above.
+const schedulesJSON = `[
+	{"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11", "jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e01"},
+	{"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e12", "jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e02"}
+]`
+
+func expectGetAllJobs(t *testing.T, mockJSONRPC *mock.MockJSONRPC) {
+	mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, schedulesJSON))
+	mockJSONRPC.EXPECT().Call("backupNg.getAllJobs", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, vmJobsJSON))
+	mockJSONRPC.EXPECT().Call("mirrorBackup.getAllJobs", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, mirrorJobsJSON))
+	mockJSONRPC.EXPECT().Call("metadataBackup.getAllJobs", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, metadataJobsJSON))
+}
+
+func TestGetAll(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	expectGetAllJobs(t, mockJSONRPC)
+
+	jobs, err := svc.GetAll(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, jobs, 4)
+
+	delta := jobs[0]
+	assert.Equal(t, payloads.BackupModeDelta, delta.Mode)
+	assert.Equal(t, []uuid.UUID{testVMID1, testVMID2}, delta.VMs)
+	assert.Equal(t, []uuid.UUID{testRemoteID1}, delta.Remotes)
+	assert.Empty(t, delta.SRs)
+	assert.Nil(t, delta.Smart)
+	assert.Equal(t, payloads.BackupSettings{
+		ReportWhen:  "failure",
+		Concurrency: 2,
+		Other:       map[string]any{"timezone": "UTC"},
+	}, delta.Settings)
+	assert.Equal(t, map[uuid.UUID]payloads.BackupRetention{
+		testScheduleID: {Export: 7, Snapshot: 1},
+	}, delta.Retention)
+	assert.Equal(t, map[string]map[string]any{
+		testScheduleID.String(): {"fullInterval": float64(7)},
+		testVMID1.String():      {"exportRetention": float64(2)},
+	}, delta.OtherSettings)
+
+	replication := jobs[1]
+	assert.Equal(t, payloads.BackupModeContinuousReplication, replication.Mode)
+	assert.Equal(t, []uuid.UUID{testSRID}, replication.SRs)
+	assert.Equal(t, &payloads.BackupSmartSelection{
+		NotPools:   []uuid.UUID{testPoolID},
+		Tags:       []string{"prod", "critical"},
+		PowerState: "Running",
+	}, replication.Smart)
+
+	mirror := jobs[2]
+	assert.Equal(t, payloads.BackupModeMirror, mirror.Mode)
+	assert.True(t, mirror.Incremental)
+	assert.Equal(t, testRemoteID1, mirror.SourceRemote)
+	assert.Equal(t, []uuid.UUID{testRemoteID2}, mirror.Remotes)
+	assert.Equal(t, 30, mirror.Retention[testScheduleID].Export)
+
+	metadata := jobs[3]
+	assert.Equal(t, payloads.BackupModeMetadata, metadata.Mode)
+	assert.True(t, metadata.XOMetadata)
+	assert.Equal(t, []uuid.UUID{testPoolID}, metadata.Pools)
+	assert.Equal(t, 5, metadata.Retention[testScheduleID].Export)
+}
+
+func TestGetAllSkipsMalformedJobs(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	// Its remotes use a pattern the SDK does not parse.
+	malformed := `{
+		"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e05",
+		"type": "backup",
+		"name": "malformed",
+		"mode": "full",
+		"vms": {"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41"},
+		"remotes": {"id": {"__and": ["0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21"]}}
+	}`
+	mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, schedulesJSON))
+	mockJSONRPC.EXPECT().Call("backupNg.getAllJobs", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, "["+malformed+","+strings.TrimPrefix(vmJobsJSON, "[")))
+	mockJSONRPC.EXPECT().Call("mirrorBackup.getAllJobs", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, mirrorJobsJSON))
+	mockJSONRPC.EXPECT().Call("metadataBackup.getAllJobs", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, metadataJobsJSON))
+
+	jobs, err := svc.GetAll(t.Context())
+
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	assert.Equal(t, []uuid.UUID{testJobID, testSmartID, testMirrorID, testMetadataID}, ids)
+
+	// The other jobs can still be read.
+	expectGetAllJobs(t, mockJSONRPC)
+	job, err := svc.Get(t.Context(), testMirrorID)
+	require.NoError(t, err)
+	assert.Equal(t, "offsite", job.Name)
+}
+
+func TestCreate(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("backupNg.createJob", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ string, params map[string]any, result any, _ ...any) error {
+			data, err := json.Marshal(params)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{
+				"name": "replication",
+				"mode": "delta",
+				"compression": "",
+				"vms": {
+					"type": "VM",
+					"power_state": "Running",
+					"$pool": {"__not": {"__or": ["0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e51"]}},
+					"tags": {"__or": [["prod"], ["critical"]]}
+				},
+				"remotes": {"id": {"__or": []}},
+				"srs": {"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e31"},
+				"schedules": {
+					"schedule0": {"cron": "0 2 * * *", "enabled": true, "name": "nightly", "timezone": "Europe/Paris"}
+				},
+				"settings": {
+					"": {"reportWhen": "failure", "offlineSnapshot": false},
+					"schedule0": {"exportRetention": 0, "copyRetention": 3, "snapshotRetention": 0}
+				}
+			}`, string(data))
+			*result.(*uuid.UUID) = testSmartID
+			return nil
+		})
+	expectGetAllJobs(t, mockJSONRPC)
+
+	job, err := svc.Create(t.Context(), &payloads.BackupJob{
+		Name: "replication",
+		Mode: payloads.BackupModeContinuousReplication,
+		SRs:  []uuid.UUID{testSRID},
+		Smart: &payloads.BackupSmartSelection{
+			NotPools:   []uuid.UUID{testPoolID},
+			Tags:       []string{"prod", "critical"},
+			PowerState: "Running",
+		},
+		Settings: payloads.BackupSettings{ReportWhen: "failure"},
+	}, []payloads.BackupScheduleParams{{
+		Name:      "nightly",
+		Cron:      "0 2 * * *",
+		Timezone:  "Europe/Paris",
+		Enabled:   true,
+		Retention: payloads.BackupRetention{Copy: 3},
+	}})
+
+	require.NoError(t, err)
+	assert.Equal(t, testSmartID, job.ID)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     payloads.BackupJob
+		wantErr string
+	}{
+		{
+			name: "valid full backup to SRs",
+			job:  payloads.BackupJob{Name: "dr", Mode: payloads.BackupModeFull, SRs: []uuid.UUID{testSRID}, VMs: []uuid.UUID{testVMID1}},
+		},
+		{
+			name:    "missing name",
+			job:     payloads.BackupJob{Mode: payloads.BackupModeFull},
+			wantErr: "name is required",
+		},
+		{
+			name:    "unknown mode",
+			job:     payloads.BackupJob{Name: "job", Mode: "rolling"},
+			wantErr: "unknown backup mode",
+		},
+		{
+			name:    "delta without remote",
+			job:     payloads.BackupJob{Name: "job", Mode: payloads.BackupModeDelta, SRs: []uuid.UUID{testSRID}},
+			wantErr: "requires remotes",
+		},
+		{
+			name: "both explicit and smart selection",
+			job: payloads.BackupJob{Name: "job", Mode: payloads.BackupModeDelta, Remotes: []uuid.UUID{testRemoteID1},
+				VMs: []uuid.UUID{testVMID1}, Smart: &payloads.BackupSmartSelection{Tags: []string{"prod"}}},
+			wantErr: "both explicitly and with smart mode",
+		},
+		{
+			name:    "no VM",
+			job:     payloads.BackupJob{Name: "job", Mode: payloads.BackupModeDelta, Remotes: []uuid.UUID{testRemoteID1}},
+			wantErr: "requires VMs",
+		},
+		{
+			name: "mirror to its source",
+			job: payloads.BackupJob{Name: "job", Mode: payloads.BackupModeMirror, SourceRemote: testRemoteID1,
+				Remotes: []uuid.UUID{testRemoteID1}},
+			wantErr: "to itself",
+		},
+		{
+			name:    "metadata without pools",
+			job:     payloads.BackupJob{Name: "job", Mode: payloads.BackupModeMetadata, Remotes: []uuid.UUID{testRemoteID1}},
+			wantErr: "requires pools or XO metadata",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(&tt.job)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("edit retention", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAllJobs(t, mockJSONRPC)
+		mockJSONRPC.EXPECT().Call("mirrorBackup.editJob", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, params map[string]any, _ any, _ ...any) error {
+				assert.Equal(t, testMirrorID.String(), params["id"])
+				assert.Equal(t, "full", params["mode"])
+				settings := params["settings"].(map[string]any)
+				assert.Equal(t, map[string]any{"exportRetention": 10}, settings[testScheduleID.String()])
+				return nil
+			})
+		expectGetAllJobs(t, mockJSONRPC)
+
+		_, err := svc.Update(t.Context(), &payloads.BackupJob{
+			ID:           testMirrorID,
+			Name:         "offsite",
+			Mode:         payloads.BackupModeMirror,
+			SourceRemote: testRemoteID1,
+			Remotes:      []uuid.UUID{testRemoteID2},
+			Retention:    map[uuid.UUID]payloads.BackupRetention{testScheduleID: {Export: 10}},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps the other settings", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAllJobs(t, mockJSONRPC)
+		job, err := svc.Get(t.Context(), testJobID)
+		require.NoError(t, err)
+
+		expectGetAllJobs(t, mockJSONRPC)
+		mockJSONRPC.EXPECT().Call("backupNg.editJob", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, params map[string]any, _ any, _ ...any) error {
+				data, err := json.Marshal(params["settings"])
+				require.NoError(t, err)
+				assert.JSONEq(t, `{
+					"": {"reportWhen": "failure", "concurrency": 2, "offlineSnapshot": false, "timezone": "UTC"},
+					"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11": {
+						"exportRetention": 10, "copyRetention": 0, "snapshotRetention": 1, "fullInterval": 7
+					},
+					"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41": {"exportRetention": 2}
+				}`, string(data))
+				return nil
+			})
+		expectGetAllJobs(t, mockJSONRPC)
+
+		job.Retention[testScheduleID] = payloads.BackupRetention{Export: 10, Snapshot: 1}
+		_, err = svc.Update(t.Context(), job)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("change of family", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAllJobs(t, mockJSONRPC)
+
+		_, err := svc.Update(t.Context(), &payloads.BackupJob{
+			ID:      testJobID,
+			Name:    "nightly",
+			Mode:    payloads.BackupModeMetadata,
+			Remotes: []uuid.UUID{testRemoteID1},
+			Pools:   []uuid.UUID{testPoolID},
+		})
+
+		assert.ErrorContains(t, err, "can't change the mode")
+	})
+}
+
+func TestRunNow(t *testing.T) {
+	schedulesJSON := `[
+		{"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e12", "jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e02"},
+		{"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11", "jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e01"}
+	]`
+
+	t.Run("first schedule of the job", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAllJobs(t, mockJSONRPC)
+		mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+			DoAndReturn(returnJSON(t, schedulesJSON))
+		mockJSONRPC.EXPECT().Call("backupNg.runJob",
+			map[string]any{"id": testJobID.String(), "schedule": testScheduleID.String()},
+			gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, svc.RunNow(t.Context(), testJobID, uuid.Nil))
+	})
+
+	t.Run("schedule of another job", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAllJobs(t, mockJSONRPC)
+		mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+			DoAndReturn(returnJSON(t, schedulesJSON))
+
+		err := svc.RunNow(t.Context(), testJobID, testSchedule2)
+
+		assert.ErrorContains(t, err, "does not belong")
+	})
+}
+
+func TestGetLogs(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("backupNg.getAllLogs", map[string]any{}, gomock.Any(), gomock.Any()).
+		DoAndReturn(returnJSON(t, `{
+			"1700000000000": {
+				"id": "1700000000000",
+				"message": "backup",
+				"jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e01",
+				"jobName": "nightly",
+				"scheduleId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11",
+				"start": 1700000000000,
+				"end": 1700000600000,
+				"status": "failure",
+				"tasks": [
+					{
+						"id": "a",
+						"message": "backup VM",
+						"data": {"type": "VM", "id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41"},
+						"start": 1700000001000,
+						"end": 1700000300000,
+						"status": "success",
+						"tasks": [
+							{"message": "snapshot", "status": "success", "result": "OpaqueRef:1"},
+							{
+								"message": "export",
+								"data": {"type": "remote", "id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21"},
+								"status": "success",
+								"tasks": [
+									{"message": "transfer", "status": "success", "result": {"size": 1024}},
+									{"message": "merge", "status": "success", "result": {"size": 4096}}
+								]
+							}
+						]
+					},
+					{
+						"id": "b",
+						"message": "backup VM",
+						"data": {"type": "VM", "id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e42"},
+						"start": 1700000001000,
+						"end": 1700000002000,
+						"status": "failure",
+						"result": {"message": "VM_BAD_POWER_STATE", "name": "XapiError"}
+					}
+				]
+			},
+			"1600000000000": {
+				"id": "1600000000000",
+				"jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e02",
+				"start": 1600000000000,
+				"status": "success"
+			},
+			"1800000000000": {
+				"id": "1800000000000",
+				"jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e01",
+				"start": 1800000000000,
+				"status": "pending"
+			}
+		}`))
+
+	logs, err := svc.GetLogs(t.Context(), testJobID)
+
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "1800000000000", logs[0].ID)
+	assert.Equal(t, payloads.BackupStatusPending, logs[0].Status)
+	assert.True(t, logs[0].End.Time().IsZero())
+
+	log := logs[1]
+	assert.Equal(t, payloads.BackupStatusFailure, log.Status)
+	assert.Equal(t, testScheduleID, log.ScheduleID)
+	assert.Equal(t, time.UnixMilli(1700000600000), log.End.Time())
+	require.Len(t, log.VMs, 2)
+	assert.Equal(t, payloads.BackupVMResult{
+		VMID:   testVMID1,
+		Start:  payloads.APITime(time.UnixMilli(1700000001000)),
+		End:    payloads.APITime(time.UnixMilli(1700000300000)),
+		Status: payloads.BackupStatusSuccess,
+		Size:   1024,
+	}, log.VMs[0])
+	assert.Equal(t, testVMID2, log.VMs[1].VMID)
+	assert.Equal(t, "VM_BAD_POWER_STATE", log.VMs[1].Error)
+}
+
+func TestListRestorePoints(t *testing.T) {
+	response := `{"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21": {
+		"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41": [
+			{
+				"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21/xo-vm-backups/0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41/20231114T221320Z.json",
+				"jobId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e01",
+				"scheduleId": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e11",
+				"mode": "delta",
+				"size": 2048,
+				"timestamp": 1700000000000,
+				"vm": {"name_label": "web-01"},
+				"disks": [{
+					"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21/xo-vm-backups/0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41/vdis/d.vhd",
+					"name": "web-01 root",
+					"uuid": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e61"
+				}]
+			},
+			{
+				"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21/xo-vm-backups/0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e41/20231115T221320Z.json",
+				"mode": "full",
+				"timestamp": 1700086400000,
+				"vm": {"name_label": "web-01"}
+			}
+		],
+		"0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e42": [
+			{
+				"id": "0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e21/xo-vm-backups/0f9c6a3e-5d1b-4c2a-9f0e-1a2b3c4d5e42/20231114T221320Z.json",
+				"mode": "full",
+				"timestamp": 1700000000000,
+				"vm": {"name_label": "db-01"}
+			}
+		]
+	}}`
+
+	tests := []struct {
+		name   string
+		filter string
+		want   int
+	}{
+		{name: "all VMs", filter: "", want: 3},
+		{name: "by UUID", filter: testVMID2.String(), want: 1},
+		{name: "by name", filter: "WEB", want: 2},
+		{name: "no match", filter: "mail", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockJSONRPC := setup(t)
+			mockJSONRPC.EXPECT().Call("backupNg.listVmBackups",
+				map[string]any{"remotes": []string{testRemoteID1.String()}}, gomock.Any(), gomock.Any()).
+				DoAndReturn(returnJSON(t, response))
+
+			points, err := svc.ListRestorePoints(t.Context(), testRemoteID1, tt.filter)
+
+			require.NoError(t, err)
+			assert.Len(t, points, tt.want)
+		})
+	}
+
+	t.Run("typed restore points, most recent first", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("backupNg.listVmBackups", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(returnJSON(t, response))
+
+		points, err := svc.ListRestorePoints(t.Context(), testRemoteID1, testVMID1.String())
+
+		require.NoError(t, err)
+		require.Len(t, points, 2)
+		assert.Equal(t, payloads.BackupModeFull, points[0].Mode)
+		point := points[1]
+		assert.Equal(t, testRemoteID1, point.RemoteID)
+		assert.Equal(t, testVMID1, point.VMID)
+		assert.Equal(t, "web-01", point.VMName)
+		assert.Equal(t, payloads.BackupModeDelta, point.Mode)
+		assert.Equal(t, testJobID, point.JobID)
+		assert.Equal(t, int64(2048), point.Size)
+		assert.Equal(t, time.UnixMilli(1700000000000), point.Timestamp.Time())
+		require.Len(t, point.Disks, 1)
+		assert.Equal(t, "web-01 root", point.Disks[0].Name)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	const pointID = "remote/xo-vm-backups/vm/20231114T221320Z.json"
+
+	expectImport := func(mockJSONRPC *mock.MockJSONRPC) {
+		mockJSONRPC.EXPECT().CallContext(gomock.Any(), "backupNg.importVmBackup",
+			map[string]any{"id": pointID, "sr": testSRID.String()}, gomock.Any(), gomock.Any()).
+			SetArg(3, testVMID2).Return(nil)
+	}
+
+	t.Run("without start", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectImport(mockJSONRPC)
+
+		vmID, err := svc.Restore(t.Context(), pointID, testSRID, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, testVMID2, vmID)
+	})
+
+	t.Run("started after restore", func(t *testing.T) {
+		svc, mockJSONRPC, mockVM, mockTask := setupWithVM(t)
+		expectImport(mockJSONRPC)
+		mockVM.EXPECT().Start(gomock.Any(), testVMID2, nil).Return("task-1", nil)
+		mockTask.EXPECT().Wait(gomock.Any(), "task-1").Return(&payloads.Task{ID: "task-1", Status: payloads.Success}, nil)
+
+		vmID, err := svc.Restore(t.Context(), pointID, testSRID, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, testVMID2, vmID)
+	})
+
+	t.Run("start failure", func(t *testing.T) {
+		svc, mockJSONRPC, mockVM, mockTask := setupWithVM(t)
+		expectImport(mockJSONRPC)
+		mockVM.EXPECT().Start(gomock.Any(), testVMID2, nil).Return("task-1", nil)
+		mockTask.EXPECT().Wait(gomock.Any(), "task-1").Return(&payloads.Task{
+			ID: "task-1", Status: payloads.Failure, Result: payloads.Result{Message: "HOST_NOT_ENOUGH_FREE_MEMORY"},
+		}, nil)
+
+		vmID, err := svc.Restore(t.Context(), pointID, testSRID, true)
+
+		assert.ErrorContains(t, err, "HOST_NOT_ENOUGH_FREE_MEMORY")
+		assert.Equal(t, testVMID2, vmID, "the restored VM is returned")
+	})
+
+	t.Run("stops waiting when canceled", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().CallContext(gomock.Any(), "backupNg.importVmBackup", gomock.Any(), gomock.Any(),
+			gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ string, _ map[string]any, _ any, _ ...any) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := svc.Restore(ctx, pointID, testSRID, true)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestListFiles(t *testing.T) {
+	diskID := testRemoteID1.String() + "/xo-vm-backups/vm/vdis/d.vhd"
+
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("backupNg.listPartitions",
+		map[string]any{"remote": testRemoteID1.String(), "disk": "/xo-vm-backups/vm/vdis/d.vhd"},
+		gomock.Any(), gomock.Any()).
+		DoAndReturn(returnJSON(t, `[{"id": "1", "name": "root", "type": "linux", "size": 10737418240}]`))
+	mockJSONRPC.EXPECT().Call("backupNg.listFiles", map[string]any{
+		"remote":    testRemoteID1.String(),
+		"disk":      "/xo-vm-backups/vm/vdis/d.vhd",
+		"partition": "1",
+		"path":      "/etc/",
+	}, gomock.Any(), gomock.Any()).
+		DoAndReturn(returnJSON(t, `{"passwd": {}, "ssh/": {}, "hosts": {}}`))
+
+	partitions, err := svc.ListPartitions(t.Context(), testRemoteID1, diskID)
+	require.NoError(t, err)
+	assert.Equal(t, []*payloads.DiskPartition{{ID: "1", Name: "root", Type: "linux", Size: 10737418240}}, partitions)
+
+	files, err := svc.ListFiles(t.Context(), testRemoteID1, diskID, "1", "/etc")
+	require.NoError(t, err)
+	assert.Equal(t, []*payloads.BackupFile{
+		{Name: "hosts", Path: "/etc/hosts"},
+		{Name: "passwd", Path: "/etc/passwd"},
+		{Name: "ssh", Path: "/etc/ssh", IsDir: true},
+	}, files)
+
+	_, err = svc.ListFiles(t.Context(), testRemoteID1, diskID, "1", "etc")
+	assert.ErrorContains(t, err, "must be absolute")
+}
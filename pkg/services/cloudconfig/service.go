@@ -0,0 +1,167 @@
+package cloudconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/cloudinit"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.CloudConfig {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*payloads.CloudConfig, error) {
+	for _, configType := range []payloads.CloudConfigType{payloads.CloudConfigTypeUser, payloads.CloudConfigTypeNetwork} {
+		configs, err := s.GetAll(ctx, configType)
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range configs {
+			if config.ID == id {
+				return config, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cloud config %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context, configType payloads.CloudConfigType) ([]*payloads.CloudConfig, error) {
+	method, err := getAllMethod(configType)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*payloads.CloudConfig
+	if err := s.jsonrpcSvc.Call(method, map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	// The type is not always returned by XO.
+	for _, config := range result {
+		config.Type = configType
+	}
+	return result, nil
+}
+
+func (s *Service) GetByName(
+	ctx context.Context, configType payloads.CloudConfigType, name string) ([]*payloads.CloudConfig, error) {
+	configs, err := s.GetAll(ctx, configType)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*payloads.CloudConfig
+	for _, config := range configs {
+		if config.Name == name {
+			result = append(result, config)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no %s cloud config named %q", configType, name)
+	}
+	return result, nil
+}
+
+func (s *Service) Create(
+	ctx context.Context, params payloads.CloudConfigCreateParams) (*payloads.CloudConfig, error) {
+	configType := params.Type
+	if configType == "" {
+		configType = payloads.CloudConfigTypeUser
+	}
+	method := "cloudConfig.create"
+	switch configType {
+	case payloads.CloudConfigTypeUser:
+	case payloads.CloudConfigTypeNetwork:
+		method = "cloudConfig.createNetworkConfig"
+	default:
+		return nil, fmt.Errorf("unknown cloud config type %q", configType)
+	}
+
+	rpcParams := map[string]any{
+		"name":     params.Name,
+		"template": params.Template,
+	}
+	// XO >= 5.98.0 returns the created object, older versions return true.
+	var result any
+	logContext := zap.String("name", params.Name)
+	if err := s.jsonrpcSvc.Call(method, rpcParams, &result, logContext); err != nil {
+		return nil, err
+	}
+	if created, ok := result.(map[string]any); ok {
+		if id, ok := created["id"].(string); ok && id != "" {
+			return &payloads.CloudConfig{ID: id, Name: params.Name, Template: params.Template, Type: configType}, nil
+		}
+	}
+
+	// Without the ID in the response, look for the template we just created.
+	configs, err := s.GetAll(ctx, configType)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(configs) - 1; i >= 0; i-- {
+		if configs[i].Name == params.Name && configs[i].Template == params.Template {
+			return configs[i], nil
+		}
+	}
+	s.log.Error("Created cloud config not found", logContext)
+	return nil, fmt.Errorf("cloud config %q was created but could not be retrieved", params.Name)
+}
+
+func (s *Service) Update(_ context.Context, id string, params payloads.CloudConfigUpdateParams) error {
+	rpcParams := map[string]any{
+		"id": id,
+	}
+	if params.Name != nil {
+		rpcParams["name"] = *params.Name
+	}
+	if params.Template != nil {
+		rpcParams["template"] = *params.Template
+	}
+
+	var result any
+	return s.jsonrpcSvc.Call("cloudConfig.update", rpcParams, &result, zap.String("cloudConfigID", id))
+}
+
+func (s *Service) Delete(_ context.Context, id string) error {
+	var result any
+	return s.jsonrpcSvc.Call("cloudConfig.delete", map[string]any{"id": id}, &result,
+		zap.String("cloudConfigID", id))
+}
+
+func (s *Service) Render(template string, vars payloads.CloudConfigVariables) string {
+	return cloudinit.Render(template, vars)
+}
+
+func (s *Service) Validate(configType payloads.CloudConfigType, content string) error {
+	switch configType {
+	case payloads.CloudConfigTypeUser:
+		return cloudinit.ValidateUserData(content)
+	case payloads.CloudConfigTypeNetwork:
+		return cloudinit.ValidateNetworkConfig(content)
+	default:
+		return fmt.Errorf("unknown cloud config type %q", configType)
+	}
+}
+
+func getAllMethod(configType payloads.CloudConfigType) (string, error) {
+	switch configType {
+	case payloads.CloudConfigTypeUser:
+		return "cloudConfig.getAll", nil
+	case payloads.CloudConfigTypeNetwork:
+		return "cloudConfig.getAllNetworkConfigs", nil
+	default:
+		return "", fmt.Errorf("unknown cloud config type %q", configType)
+	}
+}
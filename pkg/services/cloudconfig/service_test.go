@@ -0,0 +1,175 @@
+package cloudconfig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+func setup(t *testing.T) (library.CloudConfig, *mock.MockJSONRPC) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(mockJSONRPC, log), mockJSONRPC
+}
+
+// expectGetAll makes the mocked JSON-RPC service return configs for method.
+func expectGetAll(mockJSONRPC *mock.MockJSONRPC, method string, configs ...*payloads.CloudConfig) {
+	mockJSONRPC.EXPECT().Call(method, map[string]any{}, gomock.Any()).
+		SetArg(2, configs).Return(nil)
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("cloud configs", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(mockJSONRPC, "cloudConfig.getAll", &payloads.CloudConfig{ID: "a1", Name: "base"})
+
+		configs, err := svc.GetAll(t.Context(), payloads.CloudConfigTypeUser)
+
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		assert.Equal(t, "a1", configs[0].ID)
+		assert.Equal(t, payloads.CloudConfigTypeUser, configs[0].Type)
+	})
+
+	t.Run("network configs", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(mockJSONRPC, "cloudConfig.getAllNetworkConfigs", &payloads.CloudConfig{ID: "n1", Name: "dhcp"})
+
+		configs, err := svc.GetAll(t.Context(), payloads.CloudConfigTypeNetwork)
+
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		assert.Equal(t, payloads.CloudConfigTypeNetwork, configs[0].Type)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		_, err := svc.GetAll(t.Context(), "vendor")
+
+		assert.ErrorContains(t, err, `unknown cloud config type "vendor"`)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("searches network configs too", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(mockJSONRPC, "cloudConfig.getAll", &payloads.CloudConfig{ID: "a1"})
+		expectGetAll(mockJSONRPC, "cloudConfig.getAllNetworkConfigs", &payloads.CloudConfig{ID: "n1"})
+
+		config, err := svc.Get(t.Context(), "n1")
+
+		require.NoError(t, err)
+		assert.Equal(t, payloads.CloudConfigTypeNetwork, config.Type)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(mockJSONRPC, "cloudConfig.getAll")
+		expectGetAll(mockJSONRPC, "cloudConfig.getAllNetworkConfigs")
+
+		config, err := svc.Get(t.Context(), "missing")
+
+		assert.ErrorContains(t, err, "cloud config missing not found")
+		assert.Nil(t, config)
+	})
+}
+
+func TestGetByName(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	expectGetAll(mockJSONRPC, "cloudConfig.getAll",
+		&payloads.CloudConfig{ID: "a1", Name: "base"},
+		&payloads.CloudConfig{ID: "a2", Name: "other"},
+		&payloads.CloudConfig{ID: "a3", Name: "base"})
+
+	configs, err := svc.GetByName(t.Context(), payloads.CloudConfigTypeUser, "base")
+
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "a1", configs[0].ID)
+	assert.Equal(t, "a3", configs[1].ID)
+
+	expectGetAll(mockJSONRPC, "cloudConfig.getAll")
+	_, err = svc.GetByName(t.Context(), payloads.CloudConfigTypeUser, "base")
+	assert.ErrorContains(t, err, `no user cloud config named "base"`)
+}
+
+func TestCreate(t *testing.T) {
+	params := payloads.CloudConfigCreateParams{Name: "base", Template: "#cloud-config\nhostname: {name}\n"}
+	rpcParams := map[string]any{"name": params.Name, "template": params.Template}
+
+	t.Run("object response", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("cloudConfig.create", rpcParams, gomock.Any(), gomock.Any()).
+			SetArg(2, any(map[string]any{"id": "a1", "name": params.Name})).Return(nil)
+
+		config, err := svc.Create(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, &payloads.CloudConfig{
+			ID: "a1", Name: params.Name, Template: params.Template, Type: payloads.CloudConfigTypeUser,
+		}, config)
+	})
+
+	t.Run("boolean response of older XO versions", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		networkParams := params
+		networkParams.Type = payloads.CloudConfigTypeNetwork
+		mockJSONRPC.EXPECT().Call("cloudConfig.createNetworkConfig", rpcParams, gomock.Any(), gomock.Any()).
+			SetArg(2, any(true)).Return(nil)
+		expectGetAll(mockJSONRPC, "cloudConfig.getAllNetworkConfigs",
+			&payloads.CloudConfig{ID: "n0", Name: params.Name, Template: "other"},
+			&payloads.CloudConfig{ID: "n1", Name: params.Name, Template: params.Template})
+
+		config, err := svc.Create(t.Context(), networkParams)
+
+		require.NoError(t, err)
+		assert.Equal(t, "n1", config.ID)
+	})
+
+	t.Run("call failure", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("cloudConfig.create", rpcParams, gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("JSON-RPC call to cloudConfig.create failed"))
+
+		config, err := svc.Create(t.Context(), params)
+
+		assert.Error(t, err)
+		assert.Nil(t, config)
+	})
+}
+
+func TestUpdateDelete(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	template := "#cloud-config\n"
+	mockJSONRPC.EXPECT().Call("cloudConfig.update", map[string]any{"id": "a1", "template": template},
+		gomock.Any(), gomock.Any()).Return(nil)
+	mockJSONRPC.EXPECT().Call("cloudConfig.delete", map[string]any{"id": "a1"}, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	assert.NoError(t, svc.Update(t.Context(), "a1", payloads.CloudConfigUpdateParams{Template: &template}))
+	assert.NoError(t, svc.Delete(t.Context(), "a1"))
+}
+
+func TestRenderAndValidate(t *testing.T) {
+	svc, _ := setup(t)
+
+	userData := svc.Render("#cloud-config\nhostname: {name}-%\n", payloads.CloudConfigVariables{Name: "web", Index: 2})
+	assert.Equal(t, "#cloud-config\nhostname: web-2\n", userData)
+	assert.NoError(t, svc.Validate(payloads.CloudConfigTypeUser, userData))
+
+	assert.ErrorContains(t, svc.Validate(payloads.CloudConfigTypeNetwork, "version: 1\n"), "config: is required")
+	assert.ErrorContains(t, svc.Validate("vendor", ""), "unknown cloud config type")
+}
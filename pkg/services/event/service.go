@@ -0,0 +1,308 @@
+package event
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	v1 "github.com/vatesfr/xenorchestra-go-sdk/client"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+const (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+var errDisconnected = errors.New("websocket connection lost")
+
+// objectTypes are the payloads of the XO object types.
+var objectTypes = map[payloads.ResourceType]func() any{
+	payloads.ResourceTypeVM:       func() any { return &payloads.VM{} },
+	payloads.ResourceTypeVDI:      func() any { return &payloads.VDI{} },
+	payloads.ResourceTypeVBD:      func() any { return &payloads.VBD{} },
+	payloads.ResourceTypePBD:      func() any { return &payloads.PBD{} },
+	payloads.ResourceTypeSR:       func() any { return &payloads.StorageRepository{} },
+	payloads.ResourceTypeHost:     func() any { return &payloads.Host{} },
+	payloads.ResourceTypePool:     func() any { return &payloads.Pool{} },
+	payloads.ResourceTypeNetwork:  func() any { return &payloads.Network{} },
+	payloads.ResourceTypePCI:      func() any { return &payloads.PCI{} },
+	payloads.ResourceTypePGPU:     func() any { return &payloads.PGPU{} },
+	payloads.ResourceTypeGPUGroup: func() any { return &payloads.GPUGroup{} },
+	payloads.ResourceTypeVGPUType: func() any { return &payloads.VGPUType{} },
+	payloads.ResourceTypeVGPU:     func() any { return &payloads.VGPU{} },
+}
+
+// connection is the part of the v1 client used by the subscriptions.
+type connection interface {
+	CallContext(ctx context.Context, method string, params, result any) error
+	DisconnectNotify() <-chan struct{}
+	Close() error
+}
+
+// Service subscribes to the "all" notifications that XO sends on the JSON-RPC
+// websocket when objects change. Each subscription has its own connection, so
+// that closing it does not affect the other JSON-RPC calls.
+type Service struct {
+	dial       func(notify v1.NotificationHandler) (connection, error)
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	log        *logger.Logger
+}
+
+func New(config v1.Config, log *logger.Logger) library.Event {
+	return &Service{
+		dial: func(notify v1.NotificationHandler) (connection, error) {
+			config.NotificationHandler = notify
+			client, err := v1.NewClient(config)
+			if err != nil {
+				return nil, err
+			}
+			return client.(*v1.Client), nil
+		},
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+		log:        log,
+	}
+}
+
+func (s *Service) Subscribe(
+	ctx context.Context,
+	filter payloads.ObjectEventFilter,
+) (<-chan payloads.ObjectEvent, error) {
+	sub := &subscription{
+		filter: filter,
+		known:  make(map[string]knownObject),
+		ready:  make(chan struct{}, 1),
+		events: make(chan payloads.ObjectEvent),
+		log:    s.log,
+	}
+	// The first connection is not retried, so that invalid credentials or
+	// URL are reported to the caller.
+	conn, err := s.dial(sub.enqueue)
+	if err != nil {
+		s.log.Error("Failed to subscribe to object events", zap.Error(err))
+		return nil, err
+	}
+	go s.run(ctx, sub, conn)
+	return sub.events, nil
+}
+
+func (s *Service) run(ctx context.Context, sub *subscription, conn connection) {
+	defer close(sub.events)
+	for {
+		err := sub.serve(ctx, conn)
+		_ = conn.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warn("Object events subscription interrupted, reconnecting", zap.Error(err))
+		if conn = s.reconnect(ctx, sub); conn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials until it succeeds, with an exponential backoff, or returns
+// nil when ctx is done.
+func (s *Service) reconnect(ctx context.Context, sub *subscription) connection {
+	backoff := s.minBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		conn, err := s.dial(sub.enqueue)
+		if err == nil {
+			s.log.Info("Object events subscription reconnected")
+			return conn
+		}
+		s.log.Warn("Failed to reconnect the object events subscription",
+			zap.Duration("retryIn", backoff), zap.Error(err))
+		backoff = min(2*backoff, s.maxBackoff)
+	}
+}
+
+// notification is the params of the "all" notification.
+type notification struct {
+	// Type is "enter" for added or updated objects, "exit" for removed ones.
+	Type  string                     `json:"type"`
+	Items map[string]json.RawMessage `json:"items"`
+}
+
+// knownObject is what is kept of the objects sent: a hash is enough to tell
+// whether they changed, and keeps the memory footprint small.
+type knownObject struct {
+	Type payloads.ResourceType
+	Sum  [sha256.Size]byte
+}
+
+type subscription struct {
+	filter payloads.ObjectEventFilter
+	// known are the objects sent, to tell additions from updates and to find
+	// the objects removed while disconnected.
+	known map[string]knownObject
+
+	// The notifications are queued as the handler must not block the read
+	// loop of the connection, which also reads the responses of the calls.
+	mu    sync.Mutex
+	queue []notification
+	ready chan struct{}
+
+	events chan payloads.ObjectEvent
+	log    *logger.Logger
+}
+
+func (sub *subscription) enqueue(method string, params json.RawMessage) {
+	if method != "all" {
+		return
+	}
+	var n notification
+	if err := json.Unmarshal(params, &n); err != nil {
+		sub.log.Warn("Failed to decode object notification", zap.Error(err))
+		return
+	}
+	sub.mu.Lock()
+	sub.queue = append(sub.queue, n)
+	sub.mu.Unlock()
+	select {
+	case sub.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (sub *subscription) dequeue() []notification {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	queue := sub.queue
+	sub.queue = nil
+	return queue
+}
+
+// serve resynchronizes the objects, then sends their changes until the
+// connection is lost or ctx is done.
+func (sub *subscription) serve(ctx context.Context, conn connection) error {
+	// The notifications left from a lost connection are superseded by the
+	// objects listed below.
+	sub.dequeue()
+	var objects map[string]json.RawMessage
+	if err := conn.CallContext(ctx, "xo.getAllObjects", sub.listParams(), &objects); err != nil {
+		return err
+	}
+	if !sub.resync(ctx, objects) {
+		return ctx.Err()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-conn.DisconnectNotify():
+			return errDisconnected
+		case <-sub.ready:
+			for _, n := range sub.dequeue() {
+				for id, raw := range n.Items {
+					if !sub.apply(ctx, n.Type == "exit", id, raw) {
+						return ctx.Err()
+					}
+				}
+			}
+		}
+	}
+}
+
+func (sub *subscription) listParams() map[string]any {
+	if len(sub.filter.Types) != 1 {
+		return map[string]any{}
+	}
+	return map[string]any{"filter": map[string]any{"type": sub.filter.Types[0]}}
+}
+
+// resync sends the differences between the known objects and the current
+// ones, then a synced event. It returns false if ctx is done.
+func (sub *subscription) resync(ctx context.Context, objects map[string]json.RawMessage) bool {
+	for id, object := range sub.known {
+		if _, ok := objects[id]; !ok {
+			delete(sub.known, id)
+			event := payloads.ObjectEvent{Kind: payloads.ObjectEventRemove, Type: object.Type, ID: id}
+			if !sub.send(ctx, event) {
+				return false
+			}
+		}
+	}
+	for id, raw := range objects {
+		if !sub.apply(ctx, false, id, raw) {
+			return false
+		}
+	}
+	return sub.send(ctx, payloads.ObjectEvent{Kind: payloads.ObjectEventSynced})
+}
+
+// apply sends the change of an object, if it matches the filter and changed.
+// It returns false if ctx is done.
+func (sub *subscription) apply(ctx context.Context, removed bool, id string, raw json.RawMessage) bool {
+	var header struct {
+		Type payloads.ResourceType `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		sub.log.Warn("Failed to decode object", zap.String("id", id), zap.Error(err))
+		return true
+	}
+	if !sub.matches(header.Type, id) {
+		return true
+	}
+
+	event := payloads.ObjectEvent{Type: header.Type, ID: id, Raw: raw}
+	previous, known := sub.known[id]
+	current := knownObject{Type: header.Type, Sum: sha256.Sum256(raw)}
+	switch {
+	case removed && !known:
+		return true
+	case removed:
+		delete(sub.known, id)
+		event.Kind = payloads.ObjectEventRemove
+	case !known:
+		sub.known[id] = current
+		event.Kind = payloads.ObjectEventAdd
+	case previous == current:
+		return true
+	default:
+		sub.known[id] = current
+		event.Kind = payloads.ObjectEventUpdate
+	}
+
+	if newObject, ok := objectTypes[header.Type]; ok {
+		object := newObject()
+		if err := json.Unmarshal(raw, object); err != nil {
+			sub.log.Warn("Failed to decode object",
+				zap.String("id", id), zap.String("type", string(header.Type)), zap.Error(err))
+		} else {
+			event.Object = object
+		}
+	}
+	return sub.send(ctx, event)
+}
+
+func (sub *subscription) matches(objectType payloads.ResourceType, id string) bool {
+	if len(sub.filter.Types) > 0 && !slices.Contains(sub.filter.Types, objectType) {
+		return false
+	}
+	return len(sub.filter.IDs) == 0 || slices.Contains(sub.filter.IDs, id)
+}
+
+func (sub *subscription) send(ctx context.Context, event payloads.ObjectEvent) bool {
+	select {
+	case sub.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
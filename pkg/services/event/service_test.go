@@ -0,0 +1,233 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "github.com/vatesfr/xenorchestra-go-sdk/client"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+const (
+	vmID1   = "00000000-0000-0000-0000-000000000001"
+	vmID2   = "00000000-0000-0000-0000-000000000002"
+	vmID3   = "00000000-0000-0000-0000-000000000003"
+	hostID1 = "10000000-0000-0000-0000-000000000001"
+)
+
+func vm(id, name string) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"id":%q,"type":"VM","name_label":%q}`, id, name))
+}
+
+func host(id string) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"id":%q,"type":"host"}`, id))
+}
+
+// fakeConn is a websocket connection to XO, whose objects are fixed.
+type fakeConn struct {
+	objects   map[string]json.RawMessage
+	params    map[string]any
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *fakeConn) CallContext(_ context.Context, method string, params, result any) error {
+	if method != "xo.getAllObjects" {
+		return fmt.Errorf("unexpected method %s", method)
+	}
+	c.params = params.(map[string]any)
+	data, err := json.Marshal(c.objects)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (c *fakeConn) DisconnectNotify() <-chan struct{} {
+	return c.closed
+}
+
+func (c *fakeConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+// fakeXO returns the connections in order, and keeps the notification
+// handler of the last one.
+type fakeXO struct {
+	mu     sync.Mutex
+	conns  []*fakeConn
+	dials  int
+	notify v1.NotificationHandler
+}
+
+func (x *fakeXO) dial(notify v1.NotificationHandler) (connection, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.dials >= len(x.conns) {
+		return nil, errors.New("connection refused")
+	}
+	conn := x.conns[x.dials]
+	x.dials++
+	x.notify = notify
+	return conn, nil
+}
+
+func (x *fakeXO) send(t *testing.T, kind string, items ...json.RawMessage) {
+	params := notification{Type: kind, Items: make(map[string]json.RawMessage)}
+	for _, item := range items {
+		var header struct{ ID string }
+		require.NoError(t, json.Unmarshal(item, &header))
+		params.Items[header.ID] = item
+	}
+	data, err := json.Marshal(params)
+	require.NoError(t, err)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.notify("all", data)
+}
+
+func newConn(objects ...json.RawMessage) *fakeConn {
+	conn := &fakeConn{objects: make(map[string]json.RawMessage), closed: make(chan struct{})}
+	for _, object := range objects {
+		var header struct{ ID string }
+		_ = json.Unmarshal(object, &header)
+		conn.objects[header.ID] = object
+	}
+	return conn
+}
+
+func setup(t *testing.T, conns ...*fakeConn) (*Service, *fakeXO) {
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	require.NoError(t, err)
+	xo := &fakeXO{conns: conns}
+	return &Service{
+		dial:       xo.dial,
+		minBackoff: time.Millisecond,
+		maxBackoff: time.Millisecond,
+		log:        log,
+	}, xo
+}
+
+// receive returns the next n events, in a deterministic order for the events
+// of a resync.
+func receive(t *testing.T, events <-chan payloads.ObjectEvent, n int) map[string]payloads.ObjectEvent {
+	t.Helper()
+	received := make(map[string]payloads.ObjectEvent)
+	for range n {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "channel closed")
+			received[event.ID] = event
+		case <-time.After(time.Second):
+			require.FailNow(t, "timeout waiting for events", "received %v", received)
+		}
+	}
+	return received
+}
+
+func TestSubscribe(t *testing.T) {
+	svc, xo := setup(t, newConn(vm(vmID1, "web"), host(hostID1)))
+
+	events, err := svc.Subscribe(t.Context(), payloads.ObjectEventFilter{})
+	require.NoError(t, err)
+
+	initial := receive(t, events, 3)
+	assert.Equal(t, payloads.ObjectEventAdd, initial[vmID1].Kind)
+	assert.Equal(t, payloads.ResourceTypeVM, initial[vmID1].Type)
+	require.IsType(t, &payloads.VM{}, initial[vmID1].Object)
+	assert.Equal(t, "web", initial[vmID1].Object.(*payloads.VM).NameLabel)
+	assert.IsType(t, &payloads.Host{}, initial[hostID1].Object)
+	assert.Equal(t, payloads.ObjectEventSynced, initial[""].Kind)
+
+	// Unchanged objects are not sent again.
+	xo.send(t, "enter", vm(vmID1, "web"))
+	xo.send(t, "enter", vm(vmID1, "db"))
+	event := receive(t, events, 1)[vmID1]
+	assert.Equal(t, payloads.ObjectEventUpdate, event.Kind)
+	assert.Equal(t, "db", event.Object.(*payloads.VM).NameLabel)
+
+	xo.send(t, "enter", vm(vmID2, "new"))
+	assert.Equal(t, payloads.ObjectEventAdd, receive(t, events, 1)[vmID2].Kind)
+
+	xo.send(t, "exit", vm(vmID2, "new"))
+	event = receive(t, events, 1)[vmID2]
+	assert.Equal(t, payloads.ObjectEventRemove, event.Kind)
+	assert.Equal(t, "new", event.Object.(*payloads.VM).NameLabel)
+}
+
+func TestSubscribeFilter(t *testing.T) {
+	conn := newConn(vm(vmID1, "web"), vm(vmID2, "db"), host(hostID1))
+	svc, xo := setup(t, conn)
+
+	events, err := svc.Subscribe(t.Context(), payloads.ObjectEventFilter{
+		Types: []payloads.ResourceType{payloads.ResourceTypeVM},
+		IDs:   []string{vmID2},
+	})
+	require.NoError(t, err)
+
+	initial := receive(t, events, 2)
+	assert.Contains(t, initial, vmID2)
+	assert.Contains(t, initial, "")
+	assert.Equal(t, map[string]any{"filter": map[string]any{"type": payloads.ResourceTypeVM}}, conn.params)
+
+	xo.send(t, "enter", vm(vmID1, "ignored"), host(hostID1))
+	xo.send(t, "enter", vm(vmID2, "updated"))
+	assert.Equal(t, "updated", receive(t, events, 1)[vmID2].Object.(*payloads.VM).NameLabel)
+}
+
+func TestSubscribeReconnect(t *testing.T) {
+	first := newConn(vm(vmID1, "web"), vm(vmID2, "db"))
+	// While disconnected, vmID1 was updated, vmID2 removed and vmID3 added.
+	second := newConn(vm(vmID1, "web2"), vm(vmID3, "cache"))
+	svc, _ := setup(t, first, second)
+
+	events, err := svc.Subscribe(t.Context(), payloads.ObjectEventFilter{})
+	require.NoError(t, err)
+	receive(t, events, 3)
+
+	_ = first.Close()
+
+	resync := receive(t, events, 4)
+	assert.Equal(t, payloads.ObjectEventUpdate, resync[vmID1].Kind)
+	assert.Equal(t, payloads.ObjectEventRemove, resync[vmID2].Kind)
+	assert.Equal(t, payloads.ResourceTypeVM, resync[vmID2].Type)
+	assert.Nil(t, resync[vmID2].Object)
+	assert.Equal(t, payloads.ObjectEventAdd, resync[vmID3].Kind)
+	assert.Equal(t, payloads.ObjectEventSynced, resync[""].Kind)
+}
+
+func TestSubscribeDialError(t *testing.T) {
+	svc, _ := setup(t)
+
+	_, err := svc.Subscribe(t.Context(), payloads.ObjectEventFilter{})
+
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestSubscribeCancel(t *testing.T) {
+	conn := newConn(vm(vmID1, "web"))
+	svc, _ := setup(t, conn)
+	ctx, cancel := context.WithCancel(t.Context())
+
+	events, err := svc.Subscribe(ctx, payloads.ObjectEventFilter{})
+	require.NoError(t, err)
+	receive(t, events, 2)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		require.FailNow(t, "channel not closed")
+	}
+	<-conn.DisconnectNotify()
+}
@@ -0,0 +1,73 @@
+package gpugroup
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+)
+
+var (
+	testGPUGroupID = uuid.Must(uuid.FromString("8f7e6d5c-4b3a-4291-8f0e-1d2c3b4a5f01"))
+	testPGPUID     = uuid.Must(uuid.FromString("8f7e6d5c-4b3a-4291-8f0e-1d2c3b4a5f02"))
+)
+
+func setupTestServer(t *testing.T) library.GPUGroup {
+	t.Helper()
+	groupJSON := fmt.Sprintf(`{"id": "%s", "type": "gpuGroup", "name_label": "Group of NVIDIA Corporation TU104GL",
+		"allocation": "depth_first", "pgpus": ["%s"]}`, testGPUGroupID, testPGPUID)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v0/gpu-groups", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "*", r.URL.Query().Get("fields"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", groupJSON)
+	})
+	mux.HandleFunc("GET /rest/v0/gpu-groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, groupJSON)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	return New(&client.Client{
+		HttpClient: server.Client(),
+		BaseURL:    &url.URL{Scheme: "http", Host: server.URL[7:], Path: "/rest/v0"},
+		AuthToken:  "test-token",
+	}, log)
+}
+
+func TestGet(t *testing.T) {
+	svc := setupTestServer(t)
+
+	group, err := svc.Get(t.Context(), testGPUGroupID)
+
+	require.NoError(t, err)
+	assert.Equal(t, payloads.GPUGroupAllocationDepthFirst, group.Allocation)
+	assert.Equal(t, []uuid.UUID{testPGPUID}, group.PGPUs)
+}
+
+func TestGetAll(t *testing.T) {
+	svc := setupTestServer(t)
+
+	groups, err := svc.GetAll(t.Context(), 0, "")
+
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, testGPUGroupID, groups[0].ID)
+}
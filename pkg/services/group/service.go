@@ -0,0 +1,91 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Group {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.Group, error) {
+	groups, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.ID == id {
+			return group, nil
+		}
+	}
+	return nil, fmt.Errorf("group %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.Group, error) {
+	var result []*payloads.Group
+	if err := s.jsonrpcSvc.Call("group.getAll", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) Create(ctx context.Context, name string) (*payloads.Group, error) {
+	var id uuid.UUID
+	if err := s.jsonrpcSvc.Call("group.create", map[string]any{"name": name}, &id,
+		zap.String("name", name)); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *Service) Rename(_ context.Context, id uuid.UUID, name string) error {
+	return s.call("group.setName", id, map[string]any{"name": name})
+}
+
+func (s *Service) Delete(_ context.Context, id uuid.UUID) error {
+	return s.call("group.delete", id, nil)
+}
+
+func (s *Service) AddMember(_ context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return s.call("group.addUser", id, map[string]any{"userId": userID.String()})
+}
+
+func (s *Service) RemoveMember(_ context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return s.call("group.removeUser", id, map[string]any{"userId": userID.String()})
+}
+
+func (s *Service) SetMembers(_ context.Context, id uuid.UUID, userIDs []uuid.UUID) error {
+	ids := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = userID.String()
+	}
+	return s.call("group.setUsers", id, map[string]any{"userIds": ids})
+}
+
+// call performs a JSON-RPC call on the group id whose result is not used.
+func (s *Service) call(method string, id uuid.UUID, params map[string]any) error {
+	rpcParams := map[string]any{
+		"id": id.String(),
+	}
+	for name, value := range params {
+		rpcParams[name] = value
+	}
+
+	var result any
+	return s.jsonrpcSvc.Call(method, rpcParams, &result, zap.String("groupID", id.String()))
+}
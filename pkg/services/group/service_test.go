@@ -0,0 +1,112 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+var (
+	testGroupID = uuid.Must(uuid.FromString("5e2d3a41-8c55-4d2e-9e3a-0d2f7c1b9a01"))
+	testUserID1 = uuid.Must(uuid.FromString("5e2d3a41-8c55-4d2e-9e3a-0d2f7c1b9a11"))
+	testUserID2 = uuid.Must(uuid.FromString("5e2d3a41-8c55-4d2e-9e3a-0d2f7c1b9a12"))
+)
+
+func setup(t *testing.T) (library.Group, *mock.MockJSONRPC) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(mockJSONRPC, log), mockJSONRPC
+}
+
+func TestCreateAndGet(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("group.create", map[string]any{"name": "ci"}, gomock.Any(), gomock.Any()).
+		SetArg(2, testGroupID).Return(nil)
+	mockJSONRPC.EXPECT().Call("group.getAll", map[string]any{}, gomock.Any()).
+		SetArg(2, []*payloads.Group{{ID: testGroupID, Name: "ci", Users: []uuid.UUID{}}}).Return(nil)
+
+	group, err := svc.Create(t.Context(), "ci")
+
+	require.NoError(t, err)
+	assert.Equal(t, testGroupID, group.ID)
+	assert.Equal(t, "ci", group.Name)
+
+	mockJSONRPC.EXPECT().Call("group.getAll", map[string]any{}, gomock.Any()).
+		SetArg(2, []*payloads.Group{}).Return(nil)
+	_, err = svc.Get(t.Context(), testGroupID)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestMembers(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		params map[string]any
+		call   func(svc library.Group) error
+	}{
+		{
+			name:   "add member",
+			method: "group.addUser",
+			params: map[string]any{"id": testGroupID.String(), "userId": testUserID1.String()},
+			call: func(svc library.Group) error {
+				return svc.AddMember(t.Context(), testGroupID, testUserID1)
+			},
+		},
+		{
+			name:   "remove member",
+			method: "group.removeUser",
+			params: map[string]any{"id": testGroupID.String(), "userId": testUserID1.String()},
+			call: func(svc library.Group) error {
+				return svc.RemoveMember(t.Context(), testGroupID, testUserID1)
+			},
+		},
+		{
+			name:   "set members",
+			method: "group.setUsers",
+			params: map[string]any{
+				"id":      testGroupID.String(),
+				"userIds": []string{testUserID1.String(), testUserID2.String()},
+			},
+			call: func(svc library.Group) error {
+				return svc.SetMembers(t.Context(), testGroupID, []uuid.UUID{testUserID1, testUserID2})
+			},
+		},
+		{
+			name:   "rename",
+			method: "group.setName",
+			params: map[string]any{"id": testGroupID.String(), "name": "ops"},
+			call: func(svc library.Group) error {
+				return svc.Rename(t.Context(), testGroupID, "ops")
+			},
+		},
+		{
+			name:   "delete",
+			method: "group.delete",
+			params: map[string]any{"id": testGroupID.String()},
+			call: func(svc library.Group) error {
+				return svc.Delete(t.Context(), testGroupID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockJSONRPC := setup(t)
+			mockJSONRPC.EXPECT().Call(tt.method, tt.params, gomock.Any(), gomock.Any()).Return(nil)
+
+			assert.NoError(t, tt.call(svc))
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package informer
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+)
+
+type listers struct {
+	vms   *vmLister
+	srs   *srLister
+	hosts *hostLister
+	pools *poolLister
+}
+
+func (l *listers) VMs() library.VMLister {
+	return l.vms
+}
+
+func (l *listers) SRs() library.SRLister {
+	return l.srs
+}
+
+func (l *listers) Hosts() library.HostLister {
+	return l.hosts
+}
+
+func (l *listers) Pools() library.PoolLister {
+	return l.pools
+}
+
+// lister implements the lookups common to all the types.
+type lister[T any] struct {
+	informer *Service
+	store    *store[T]
+	// cached is false for the types not cached by the informer, which are
+	// always read from XO.
+	cached bool
+}
+
+func newLister[T any](informer *Service, t payloads.ResourceType, store *store[T]) lister[T] {
+	_, cached := informer.caches[t]
+	return lister[T]{
+		informer: informer,
+		store:    store,
+		cached:   cached,
+	}
+}
+
+func (l *lister[T]) synced() bool {
+	return l.cached && l.informer.HasSynced()
+}
+
+func (l *lister[T]) List() []*T {
+	return l.store.list()
+}
+
+func (l *lister[T]) ByTag(tag string) []*T {
+	return l.store.byIndex(indexTag, tag)
+}
+
+func (l *lister[T]) ByPool(poolID uuid.UUID) []*T {
+	return l.store.byIndex(indexPool, poolID.String())
+}
+
+// get returns a cached object, or reads it from XO when it is not cached.
+func (l *lister[T]) get(id uuid.UUID, read func() (*T, error)) (*T, error) {
+	if l.synced() {
+		if object, ok := l.store.get(id); ok {
+			return object, nil
+		}
+	}
+	return read()
+}
+
+// getAll returns the cached objects, or reads them from XO when the cache has
+// not synced or for a filter, which is only evaluated by XO.
+func (l *lister[T]) getAll(limit int, filter string, read func() ([]*T, error)) ([]*T, error) {
+	if !l.synced() || filter != "" {
+		return read()
+	}
+	objects := l.store.list()
+	if limit > 0 && len(objects) > limit {
+		objects = objects[:limit]
+	}
+	return objects, nil
+}
+
+type vmLister struct {
+	lister[payloads.VM]
+	vms library.VMReader
+}
+
+func (l *vmLister) ByPowerState(powerState string) []*payloads.VM {
+	return l.store.byIndex(indexPowerState, powerState)
+}
+
+func (l *vmLister) GetByID(ctx context.Context, id uuid.UUID) (*payloads.VM, error) {
+	return l.get(id, func() (*payloads.VM, error) {
+		return l.vms.GetByID(ctx, id)
+	})
+}
+
+func (l *vmLister) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.VM, error) {
+	return l.getAll(limit, filter, func() ([]*payloads.VM, error) {
+		return l.vms.GetAll(ctx, limit, filter)
+	})
+}
+
+type srLister struct {
+	lister[payloads.StorageRepository]
+	srs library.SRReader
+}
+
+func (l *srLister) Get(ctx context.Context, id uuid.UUID) (*payloads.StorageRepository, error) {
+	return l.get(id, func() (*payloads.StorageRepository, error) {
+		return l.srs.Get(ctx, id)
+	})
+}
+
+func (l *srLister) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.StorageRepository, error) {
+	return l.getAll(limit, filter, func() ([]*payloads.StorageRepository, error) {
+		return l.srs.GetAll(ctx, limit, filter)
+	})
+}
+
+type hostLister struct {
+	lister[payloads.Host]
+	hosts library.HostReader
+}
+
+func (l *hostLister) ByPowerState(powerState string) []*payloads.Host {
+	return l.store.byIndex(indexPowerState, powerState)
+}
+
+func (l *hostLister) Get(ctx context.Context, id uuid.UUID) (*payloads.Host, error) {
+	return l.get(id, func() (*payloads.Host, error) {
+		return l.hosts.Get(ctx, id)
+	})
+}
+
+func (l *hostLister) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.Host, error) {
+	return l.getAll(limit, filter, func() ([]*payloads.Host, error) {
+		return l.hosts.GetAll(ctx, limit, filter)
+	})
+}
+
+type poolLister struct {
+	lister[payloads.Pool]
+	pools library.PoolReader
+}
+
+func (l *poolLister) Get(ctx context.Context, id uuid.UUID) (*payloads.Pool, error) {
+	return l.get(id, func() (*payloads.Pool, error) {
+		return l.pools.Get(ctx, id)
+	})
+}
+
+func (l *poolLister) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.Pool, error) {
+	return l.getAll(limit, filter, func() ([]*payloads.Pool, error) {
+		return l.pools.GetAll(ctx, limit, filter)
+	})
+}
@@ -0,0 +1,262 @@
+package informer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+// supportedTypes are the types an informer can cache.
+var supportedTypes = []payloads.ResourceType{
+	payloads.ResourceTypeVM,
+	payloads.ResourceTypeSR,
+	payloads.ResourceTypeHost,
+	payloads.ResourceTypePool,
+}
+
+var errStopped = errors.New("informer stopped")
+
+// cache is the part of a store used to apply the events, whatever its type.
+type cache interface {
+	set(id uuid.UUID, object any) (any, bool)
+	remove(id uuid.UUID) (any, bool)
+	len() int
+	each(f func(object any))
+}
+
+// Service is an informer, modelled on the informers of the Kubernetes
+// client-go: it caches the objects sent by an object events subscription.
+type Service struct {
+	events  library.Event
+	options payloads.InformerOptions
+	caches  map[payloads.ResourceType]cache
+	listers *listers
+
+	// mu serializes the changes of the caches and the calls of the handlers,
+	// so that a handler added while running does not miss or repeat changes.
+	mu       sync.Mutex
+	handlers []library.EventHandler
+
+	synced     atomic.Bool
+	syncedOnce sync.Once
+	syncedCh   chan struct{}
+	stopped    chan struct{}
+	err        error
+
+	log *logger.Logger
+}
+
+func New(
+	events library.Event,
+	vms library.VMReader,
+	srs library.SRReader,
+	hosts library.HostReader,
+	pools library.PoolReader,
+	options payloads.InformerOptions,
+	log *logger.Logger,
+) (library.Informer, error) {
+	if len(options.Types) == 0 {
+		options.Types = supportedTypes
+	}
+	for _, t := range options.Types {
+		if !slices.Contains(supportedTypes, t) {
+			return nil, fmt.Errorf("informer does not support %s objects", t)
+		}
+	}
+	if options.MaxObjects < 0 {
+		return nil, fmt.Errorf("invalid maximum number of objects %d", options.MaxObjects)
+	}
+
+	s := &Service{
+		events:   events,
+		options:  options,
+		caches:   make(map[payloads.ResourceType]cache),
+		syncedCh: make(chan struct{}),
+		stopped:  make(chan struct{}),
+		log:      log,
+	}
+
+	vmStore := newStore(map[string]indexFunc[payloads.VM]{
+		indexPool:       func(vm *payloads.VM) []string { return []string{vm.PoolID.String()} },
+		indexTag:        func(vm *payloads.VM) []string { return vm.Tags },
+		indexPowerState: func(vm *payloads.VM) []string { return []string{vm.PowerState} },
+	})
+	srStore := newStore(map[string]indexFunc[payloads.StorageRepository]{
+		indexPool: func(sr *payloads.StorageRepository) []string { return []string{sr.Pool.String()} },
+		indexTag:  func(sr *payloads.StorageRepository) []string { return sr.Tags },
+	})
+	hostStore := newStore(map[string]indexFunc[payloads.Host]{
+		indexPool:       func(host *payloads.Host) []string { return []string{host.Pool.String()} },
+		indexTag:        func(host *payloads.Host) []string { return host.Tags },
+		indexPowerState: func(host *payloads.Host) []string { return []string{host.PowerState} },
+	})
+	poolStore := newStore(map[string]indexFunc[payloads.Pool]{
+		indexTag: func(pool *payloads.Pool) []string { return pool.Tags },
+	})
+
+	stores := map[payloads.ResourceType]cache{
+		payloads.ResourceTypeVM:   vmStore,
+		payloads.ResourceTypeSR:   srStore,
+		payloads.ResourceTypeHost: hostStore,
+		payloads.ResourceTypePool: poolStore,
+	}
+	for _, t := range options.Types {
+		s.caches[t] = stores[t]
+	}
+
+	s.listers = &listers{
+		vms:   &vmLister{lister: newLister(s, payloads.ResourceTypeVM, vmStore), vms: vms},
+		srs:   &srLister{lister: newLister(s, payloads.ResourceTypeSR, srStore), srs: srs},
+		hosts: &hostLister{lister: newLister(s, payloads.ResourceTypeHost, hostStore), hosts: hosts},
+		pools: &poolLister{lister: newLister(s, payloads.ResourceTypePool, poolStore), pools: pools},
+	}
+	return s, nil
+}
+
+func (s *Service) Run(ctx context.Context) error {
+	err := s.run(ctx)
+	s.synced.Store(false)
+	s.err = err
+	close(s.stopped)
+	return err
+}
+
+func (s *Service) run(ctx context.Context) error {
+	// Stops the subscription when the cache is full.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := s.events.Subscribe(ctx, payloads.ObjectEventFilter{Types: s.options.Types})
+	if err != nil {
+		return err
+	}
+	for event := range events {
+		if err := s.apply(event); err != nil {
+			s.log.Error("Informer stopped", zap.Error(err))
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *Service) apply(event payloads.ObjectEvent) error {
+	if event.Kind == payloads.ObjectEventSynced {
+		s.synced.Store(true)
+		s.syncedOnce.Do(func() { close(s.syncedCh) })
+		return nil
+	}
+	cache, ok := s.caches[event.Type]
+	if !ok {
+		return nil
+	}
+	id, err := uuid.FromString(event.ID)
+	if err != nil {
+		s.log.Warn("Invalid object ID", zap.String("id", event.ID), zap.Error(err))
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch event.Kind {
+	case payloads.ObjectEventAdd, payloads.ObjectEventUpdate:
+		// Objects that could not be decoded were logged by the subscription.
+		if event.Object == nil {
+			return nil
+		}
+		old, replaced := cache.set(id, event.Object)
+		if !replaced && s.options.MaxObjects > 0 && s.len() > s.options.MaxObjects {
+			cache.remove(id)
+			return fmt.Errorf("more than %d objects to cache", s.options.MaxObjects)
+		}
+		for _, handler := range s.handlers {
+			if replaced {
+				handler.OnUpdate(old, event.Object)
+			} else {
+				handler.OnAdd(event.Object)
+			}
+		}
+	case payloads.ObjectEventRemove:
+		if old, ok := cache.remove(id); ok {
+			for _, handler := range s.handlers {
+				handler.OnDelete(old)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) len() int {
+	n := 0
+	for _, cache := range s.caches {
+		n += cache.len()
+	}
+	return n
+}
+
+func (s *Service) HasSynced() bool {
+	return s.synced.Load()
+}
+
+func (s *Service) WaitForSync(ctx context.Context) error {
+	if s.HasSynced() {
+		return nil
+	}
+	select {
+	case <-s.syncedCh:
+		return nil
+	case <-s.stopped:
+		if s.err != nil {
+			return s.err
+		}
+		return errStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Service) AddEventHandler(handler library.EventHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, handler)
+	for _, t := range s.options.Types {
+		s.caches[t].each(handler.OnAdd)
+	}
+}
+
+func (s *Service) Lister() library.Lister {
+	return s.listers
+}
+
+// EventHandlerFuncs is an EventHandler calling the functions set.
+type EventHandlerFuncs struct {
+	AddFunc    func(object any)
+	UpdateFunc func(oldObject, newObject any)
+	DeleteFunc func(object any)
+}
+
+func (f EventHandlerFuncs) OnAdd(object any) {
+	if f.AddFunc != nil {
+		f.AddFunc(object)
+	}
+}
+
+func (f EventHandlerFuncs) OnUpdate(oldObject, newObject any) {
+	if f.UpdateFunc != nil {
+		f.UpdateFunc(oldObject, newObject)
+	}
+}
+
+func (f EventHandlerFuncs) OnDelete(object any) {
+	if f.DeleteFunc != nil {
+		f.DeleteFunc(object)
+	}
+}
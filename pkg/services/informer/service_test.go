@@ -0,0 +1,235 @@
+package informer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+var (
+	poolID1 = uuid.Must(uuid.FromString("00000000-0000-0000-0000-0000000000a1"))
+	poolID2 = uuid.Must(uuid.FromString("00000000-0000-0000-0000-0000000000a2"))
+	vmID1   = uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000001"))
+	vmID2   = uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000002"))
+	srID1   = uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000101"))
+)
+
+type testInformer struct {
+	library.Informer
+	events chan payloads.ObjectEvent
+	vms    *mock.MockVM
+	srs    *mock.MockSR
+	done   chan error
+}
+
+func setup(t *testing.T, options payloads.InformerOptions) *testInformer {
+	ctrl := gomock.NewController(t)
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	require.NoError(t, err)
+
+	ti := &testInformer{
+		events: make(chan payloads.ObjectEvent),
+		vms:    mock.NewMockVM(ctrl),
+		srs:    mock.NewMockSR(ctrl),
+		done:   make(chan error, 1),
+	}
+	mockEvent := mock.NewMockEvent(ctrl)
+	mockEvent.EXPECT().Subscribe(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter payloads.ObjectEventFilter) (<-chan payloads.ObjectEvent, error) {
+			types := options.Types
+			if len(types) == 0 {
+				types = supportedTypes
+			}
+			assert.ElementsMatch(t, types, filter.Types)
+			return ti.events, nil
+		}).AnyTimes()
+
+	ti.Informer, err = New(mockEvent, ti.vms, ti.srs, mock.NewMockHost(ctrl), mock.NewMockPool(ctrl), options, log)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	go func() { ti.done <- ti.Run(ctx) }()
+	return ti
+}
+
+// send sends events, and returns once they have been applied.
+func (ti *testInformer) send(events ...payloads.ObjectEvent) {
+	for _, event := range events {
+		ti.events <- event
+	}
+	// The informer receives the events one at a time: once this one is
+	// received, the previous ones have been applied.
+	ti.events <- payloads.ObjectEvent{Kind: payloads.ObjectEventSynced}
+}
+
+func vmEvent(kind payloads.ObjectEventKind, vm payloads.VM) payloads.ObjectEvent {
+	return payloads.ObjectEvent{Kind: kind, Type: payloads.ResourceTypeVM, ID: vm.ID.String(), Object: &vm}
+}
+
+var (
+	webVM = payloads.VM{
+		ID:         vmID1,
+		NameLabel:  "web",
+		PowerState: payloads.PowerStateRunning,
+		PoolID:     poolID1,
+		Tags:       []string{"prod", "web"},
+	}
+	dbVM = payloads.VM{
+		ID:         vmID2,
+		NameLabel:  "db",
+		PowerState: payloads.PowerStateHalted,
+		PoolID:     poolID2,
+		Tags:       []string{"prod"},
+	}
+	sr1 = payloads.StorageRepository{ID: srID1, Pool: poolID1, Tags: []string{"fast"}}
+)
+
+func names(vms []*payloads.VM) []string {
+	var names []string
+	for _, vm := range vms {
+		names = append(names, vm.NameLabel)
+	}
+	return names
+}
+
+func TestListers(t *testing.T) {
+	ti := setup(t, payloads.InformerOptions{Types: []payloads.ResourceType{
+		payloads.ResourceTypeVM, payloads.ResourceTypeSR,
+	}})
+	assert.False(t, ti.HasSynced())
+
+	ti.send(
+		vmEvent(payloads.ObjectEventAdd, webVM),
+		vmEvent(payloads.ObjectEventAdd, dbVM),
+		payloads.ObjectEvent{
+			Kind:   payloads.ObjectEventAdd,
+			Type:   payloads.ResourceTypeSR,
+			ID:     srID1.String(),
+			Object: &sr1,
+		},
+	)
+	require.NoError(t, ti.WaitForSync(t.Context()))
+	assert.True(t, ti.HasSynced())
+
+	vms := ti.Lister().VMs()
+	assert.Equal(t, []string{"web", "db"}, names(vms.List()))
+	assert.Equal(t, []string{"db"}, names(vms.ByPool(poolID2)))
+	assert.Equal(t, []string{"web", "db"}, names(vms.ByTag("prod")))
+	assert.Equal(t, []string{"web"}, names(vms.ByPowerState(payloads.PowerStateRunning)))
+	assert.Empty(t, vms.ByTag("unknown"))
+	assert.Len(t, ti.Lister().SRs().ByPool(poolID1), 1)
+
+	// The reads are served by the cache, without calling XO.
+	vm, err := vms.GetByID(t.Context(), vmID2)
+	require.NoError(t, err)
+	assert.Equal(t, "db", vm.NameLabel)
+	all, err := vms.GetAll(t.Context(), 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web"}, names(all))
+
+	// The indexes follow the updates.
+	updated := webVM
+	updated.Tags = []string{"staging"}
+	updated.PowerState = payloads.PowerStateHalted
+	ti.send(vmEvent(payloads.ObjectEventUpdate, updated))
+	assert.Equal(t, []string{"db"}, names(vms.ByTag("prod")))
+	assert.Equal(t, []string{"web"}, names(vms.ByTag("staging")))
+	assert.Equal(t, []string{"web", "db"}, names(vms.ByPowerState(payloads.PowerStateHalted)))
+
+	ti.send(vmEvent(payloads.ObjectEventRemove, dbVM))
+	assert.Equal(t, []string{"web"}, names(vms.List()))
+	assert.Empty(t, vms.ByPool(poolID2))
+}
+
+func TestListersReadXO(t *testing.T) {
+	ti := setup(t, payloads.InformerOptions{Types: []payloads.ResourceType{payloads.ResourceTypeVM}})
+	vms := ti.Lister().VMs()
+
+	// Before the sync.
+	ti.vms.EXPECT().GetByID(gomock.Any(), vmID1).Return(&webVM, nil)
+	vm, err := vms.GetByID(t.Context(), vmID1)
+	require.NoError(t, err)
+	assert.Equal(t, "web", vm.NameLabel)
+
+	ti.send(vmEvent(payloads.ObjectEventAdd, webVM))
+
+	// An object not cached yet.
+	ti.vms.EXPECT().GetByID(gomock.Any(), vmID2).Return(&dbVM, nil)
+	_, err = vms.GetByID(t.Context(), vmID2)
+	require.NoError(t, err)
+
+	// A filter, which only XO evaluates.
+	ti.vms.EXPECT().GetAll(gomock.Any(), 0, "name_label:web").Return([]*payloads.VM{&webVM}, nil)
+	_, err = vms.GetAll(t.Context(), 0, "name_label:web")
+	require.NoError(t, err)
+
+	// A type not cached.
+	ti.srs.EXPECT().Get(gomock.Any(), srID1).Return(&sr1, nil)
+	_, err = ti.Lister().SRs().Get(t.Context(), srID1)
+	require.NoError(t, err)
+}
+
+func TestEventHandlers(t *testing.T) {
+	ti := setup(t, payloads.InformerOptions{})
+	ti.send(vmEvent(payloads.ObjectEventAdd, webVM))
+
+	var added, deleted []string
+	var updated [][2]string
+	ti.AddEventHandler(EventHandlerFuncs{
+		AddFunc: func(object any) {
+			added = append(added, object.(*payloads.VM).NameLabel)
+		},
+		UpdateFunc: func(oldObject, newObject any) {
+			updated = append(updated, [2]string{
+				oldObject.(*payloads.VM).NameLabel, newObject.(*payloads.VM).NameLabel,
+			})
+		},
+		DeleteFunc: func(object any) {
+			deleted = append(deleted, object.(*payloads.VM).NameLabel)
+		},
+	})
+	// The objects already cached are added.
+	assert.Equal(t, []string{"web"}, added)
+
+	renamed := webVM
+	renamed.NameLabel = "frontend"
+	ti.send(
+		vmEvent(payloads.ObjectEventAdd, dbVM),
+		vmEvent(payloads.ObjectEventUpdate, renamed),
+		// Removals after a reconnection have no object.
+		payloads.ObjectEvent{Kind: payloads.ObjectEventRemove, Type: payloads.ResourceTypeVM, ID: vmID1.String()},
+	)
+
+	assert.Equal(t, []string{"web", "db"}, added)
+	assert.Equal(t, [][2]string{{"web", "frontend"}}, updated)
+	assert.Equal(t, []string{"frontend"}, deleted)
+}
+
+func TestMaxObjects(t *testing.T) {
+	ti := setup(t, payloads.InformerOptions{Types: []payloads.ResourceType{payloads.ResourceTypeVM}, MaxObjects: 1})
+
+	ti.events <- vmEvent(payloads.ObjectEventAdd, webVM)
+	ti.events <- vmEvent(payloads.ObjectEventAdd, dbVM)
+
+	err := <-ti.done
+	assert.ErrorContains(t, err, "more than 1 objects to cache")
+	assert.False(t, ti.HasSynced())
+	assert.ErrorIs(t, ti.WaitForSync(t.Context()), err)
+}
+
+func TestNewUnsupportedType(t *testing.T) {
+	_, err := New(nil, nil, nil, nil, nil,
+		payloads.InformerOptions{Types: []payloads.ResourceType{payloads.ResourceTypeVDI}}, nil)
+
+	assert.ErrorContains(t, err, "informer does not support VDI objects")
+}
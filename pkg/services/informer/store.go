@@ -0,0 +1,146 @@
+package informer
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	indexPool       = "pool"
+	indexTag        = "tag"
+	indexPowerState = "powerState"
+)
+
+// indexFunc returns the values of an index for an object.
+type indexFunc[T any] func(object *T) []string
+
+// store holds the objects of a type, with indexes to find them by the values
+// of their fields.
+type store[T any] struct {
+	mu       sync.RWMutex
+	objects  map[uuid.UUID]*T
+	indexers map[string]indexFunc[T]
+	// indexes are the IDs of the objects by index name and value.
+	indexes map[string]map[string]map[uuid.UUID]struct{}
+}
+
+func newStore[T any](indexers map[string]indexFunc[T]) *store[T] {
+	indexes := make(map[string]map[string]map[uuid.UUID]struct{}, len(indexers))
+	for name := range indexers {
+		indexes[name] = make(map[string]map[uuid.UUID]struct{})
+	}
+	return &store[T]{
+		objects:  make(map[uuid.UUID]*T),
+		indexers: indexers,
+		indexes:  indexes,
+	}
+}
+
+// set adds or replaces an object, and returns the replaced one, if any.
+func (s *store[T]) set(id uuid.UUID, object any) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.objects[id]
+	if ok {
+		s.unindex(id, old)
+	}
+	s.objects[id] = object.(*T)
+	s.index(id, object.(*T))
+	if !ok {
+		return nil, false
+	}
+	return old, true
+}
+
+// remove removes an object, and returns it, if any.
+func (s *store[T]) remove(id uuid.UUID) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.objects[id]
+	if !ok {
+		return nil, false
+	}
+	s.unindex(id, old)
+	delete(s.objects, id)
+	return old, true
+}
+
+func (s *store[T]) len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.objects)
+}
+
+func (s *store[T]) each(f func(object any)) {
+	for _, object := range s.list() {
+		f(object)
+	}
+}
+
+func (s *store[T]) get(id uuid.UUID) (*T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	object, ok := s.objects[id]
+	return object, ok
+}
+
+// list returns the objects, sorted by ID.
+func (s *store[T]) list() []*T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]uuid.UUID, 0, len(s.objects))
+	for id := range s.objects {
+		ids = append(ids, id)
+	}
+	return s.sorted(ids)
+}
+
+// byIndex returns the objects with a value of an index, sorted by ID.
+func (s *store[T]) byIndex(name, value string) []*T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]uuid.UUID, 0, len(s.indexes[name][value]))
+	for id := range s.indexes[name][value] {
+		ids = append(ids, id)
+	}
+	return s.sorted(ids)
+}
+
+func (s *store[T]) sorted(ids []uuid.UUID) []*T {
+	slices.SortFunc(ids, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+	objects := make([]*T, len(ids))
+	for i, id := range ids {
+		objects[i] = s.objects[id]
+	}
+	return objects
+}
+
+func (s *store[T]) index(id uuid.UUID, object *T) {
+	for name, indexer := range s.indexers {
+		for _, value := range indexer(object) {
+			ids, ok := s.indexes[name][value]
+			if !ok {
+				ids = make(map[uuid.UUID]struct{})
+				s.indexes[name][value] = ids
+			}
+			ids[id] = struct{}{}
+		}
+	}
+}
+
+func (s *store[T]) unindex(id uuid.UUID, object *T) {
+	for name, indexer := range s.indexers {
+		for _, value := range indexer(object) {
+			delete(s.indexes[name][value], id)
+			// Values are dropped with their last object, so that the indexes do
+			// not grow with values no longer used, e.g. removed tags.
+			if len(s.indexes[name][value]) == 0 {
+				delete(s.indexes[name], value)
+			}
+		}
+	}
+}
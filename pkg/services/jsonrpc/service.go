@@ -1,15 +1,27 @@
 package jsonrpc
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
 	v1 "github.com/vatesfr/xenorchestra-go-sdk/client"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/redact"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
 	"go.uber.org/zap"
 )
 
+// secretResults are the methods whose result is never logged, as it contains
+// credentials.
+var secretResults = map[string]bool{
+	"token.create":                 true,
+	"user.getAuthenticationTokens": true,
+	"remote.create":                true,
+	"remote.get":                   true,
+	"remote.getAll":                true,
+}
+
 // Service wraps a v1 client and provides JSON-RPC functionality.
 type Service struct {
 	client *v1.Client
@@ -19,9 +31,10 @@ type Service struct {
 // LazyService defers v1 client initialization until the first Call().
 type LazyService struct {
 	Service
-	factoryOnce sync.Once
-	factory     func() (*v1.Client, error)
-	initErr     error
+	// factoryMu guards the initialization of the client, which is tried
+	// again by the next call when it fails.
+	factoryMu sync.Mutex
+	factory   func() (*v1.Client, error)
 }
 
 // New creates a JSONRPC service with an already-initialized v1 client.
@@ -33,7 +46,7 @@ func New(client *v1.Client, log *logger.Logger) library.JSONRPC {
 }
 
 // NewLazy creates a JSONRPC service that initializes the v1 client lazily
-// on first Call(). The factory function will be called at most once, thread-safely.
+// on first Call(). The factory function is called thread-safely, until it succeeds.
 func NewLazy(factory func() (*v1.Client, error), log *logger.Logger) library.JSONRPC {
 	return &LazyService{
 		Service: Service{
@@ -45,13 +58,19 @@ func NewLazy(factory func() (*v1.Client, error), log *logger.Logger) library.JSO
 
 // Call performs the actual JSON-RPC call with logging.
 func (s *Service) Call(method string, params map[string]any, result any, logContext ...zap.Field) error {
+	return s.CallContext(context.Background(), method, params, result, logContext...)
+}
+
+// CallContext is like Call, but the call is abandoned once ctx is done.
+func (s *Service) CallContext(
+	ctx context.Context, method string, params map[string]any, result any, logContext ...zap.Field) error {
 	s.log.Debug("Making JSON-RPC call",
 		append([]zap.Field{
 			zap.String("method", method),
-			zap.Any("params", params),
+			zap.Any("params", redact.Params(params)),
 		}, logContext...)...)
 
-	err := s.client.Call(method, params, result)
+	err := s.client.CallContext(ctx, method, params, result)
 	if err != nil {
 		s.log.Error("JSON-RPC call failed",
 			append([]zap.Field{
@@ -61,10 +80,14 @@ func (s *Service) Call(method string, params map[string]any, result any, logCont
 		return fmt.Errorf("JSON-RPC call to %s failed: %w", method, err)
 	}
 
+	loggedResult := result
+	if secretResults[method] {
+		loggedResult = redact.Redacted
+	}
 	s.log.Debug("JSON-RPC call successful",
 		append([]zap.Field{
 			zap.String("method", method),
-			zap.Any("result", result),
+			zap.Any("result", loggedResult),
 		}, logContext...)...)
 
 	return nil
@@ -83,21 +106,38 @@ func (s *Service) ValidateResult(result bool, operation string, logContext ...za
 }
 
 // Call implements library.JSONRPC interface for LazyService.
-// It lazily initializes the v1 client on first call.
+// It lazily initializes the v1 client on first call, or on the next call if
+// the initialization failed, e.g. when XO was not reachable yet.
 func (s *LazyService) Call(method string, params map[string]any, result any, logContext ...zap.Field) error {
-	// Initialize client lazily on first call
-	s.factoryOnce.Do(func() {
-		s.client, s.initErr = s.factory()
-	})
+	return s.CallContext(context.Background(), method, params, result, logContext...)
+}
 
-	if s.initErr != nil {
+// CallContext is like Call, but the call is abandoned once ctx is done.
+func (s *LazyService) CallContext(
+	ctx context.Context, method string, params map[string]any, result any, logContext ...zap.Field) error {
+	if err := s.init(); err != nil {
 		s.log.Error("Failed to initialize v1 client",
 			append([]zap.Field{
 				zap.String("method", method),
-				zap.Error(s.initErr),
+				zap.Error(err),
 			}, logContext...)...)
-		return fmt.Errorf("failed to initialize v1 client for JSON-RPC call to %s: %w", method, s.initErr)
+		return fmt.Errorf("failed to initialize v1 client for JSON-RPC call to %s: %w", method, err)
 	}
 
-	return s.Call(method, params, result, logContext...)
+	return s.Service.CallContext(ctx, method, params, result, logContext...)
+}
+
+// init creates the client, unless an earlier call already did.
+func (s *LazyService) init() error {
+	s.factoryMu.Lock()
+	defer s.factoryMu.Unlock()
+	if s.client != nil {
+		return nil
+	}
+	client, err := s.factory()
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
 }
@@ -168,6 +168,16 @@ func TestCall(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "success-result", result)
 	})
+	t.Run("canceled call", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var result string
+		err := jsonrpcSvc.CallContext(ctx, "success.method", map[string]any{}, &result)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, result)
+	})
 }
 
 func TestValidateResult(t *testing.T) {
@@ -200,3 +210,67 @@ func TestValidateResult(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestLazyCall(t *testing.T) {
+	server, _ := setupJSONRPCTestServer()
+	defer server.Close()
+
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	assert.NoError(t, err)
+
+	t.Run("initializes the client once and forwards the call", func(t *testing.T) {
+		calls := 0
+		lazySvc := NewLazy(func() (*v1.Client, error) {
+			calls++
+			client, err := v1.NewClient(v1.Config{
+				Url:   strings.Replace(server.URL, "http", "ws", 1),
+				Token: fakeXoToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return client.(*v1.Client), nil
+		}, log)
+
+		var result string
+		assert.NoError(t, lazySvc.Call("success.method", map[string]any{}, &result))
+		assert.Equal(t, "success-result", result)
+		assert.NoError(t, lazySvc.Call("success.method", map[string]any{}, &result))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("returns the initialization error", func(t *testing.T) {
+		lazySvc := NewLazy(func() (*v1.Client, error) {
+			return nil, fmt.Errorf("connection refused")
+		}, log)
+
+		var result string
+		err := lazySvc.Call("success.method", map[string]any{}, &result)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+
+	t.Run("initializes the client again after an error", func(t *testing.T) {
+		calls := 0
+		lazySvc := NewLazy(func() (*v1.Client, error) {
+			calls++
+			if calls == 1 {
+				return nil, fmt.Errorf("connection refused")
+			}
+			client, err := v1.NewClient(v1.Config{
+				Url:   strings.Replace(server.URL, "http", "ws", 1),
+				Token: fakeXoToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return client.(*v1.Client), nil
+		}, log)
+
+		var result string
+		assert.ErrorContains(t, lazySvc.Call("success.method", map[string]any{}, &result), "connection refused")
+		assert.NoError(t, lazySvc.Call("success.method", map[string]any{}, &result))
+		assert.Equal(t, "success-result", result)
+		assert.Equal(t, 2, calls)
+	})
+}
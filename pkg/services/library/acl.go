@@ -0,0 +1,67 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/acl.go . ACL
+type ACL interface {
+	// Get retrieves an ACL by its ID.
+	// Parameters:
+	//   - id: XO ID of the ACL
+	// Returns the ACL or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id string) (*payloads.ACL, error)
+
+	// GetAll retrieves all the ACLs.
+	// Returns the ACLs or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.ACL, error)
+
+	// Create grants a role on an object to a user or a group.
+	// Parameters:
+	//   - params: subject, object and role of the ACL
+	// Returns the created ACL or an error if the operation fails.
+	Create(ctx context.Context, params payloads.ACLParams) (*payloads.ACL, error)
+
+	// SetRole changes the role given by an ACL. As XO ACLs can't be modified,
+	// a new ACL is created and the previous one deleted.
+	// Parameters:
+	//   - id: XO ID of the ACL
+	//   - role: new role
+	// Returns the new ACL or an error if the operation fails.
+	SetRole(ctx context.Context, id string, role payloads.ACLRole) (*payloads.ACL, error)
+
+	// Delete deletes an ACL.
+	// Parameters:
+	//   - id: XO ID of the ACL
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id string) error
+
+	// Grant creates several ACLs. The ACLs that already exist are skipped and a
+	// failure does not stop the others from being created.
+	// Parameters:
+	//   - params: the ACLs to create
+	// Returns an error joining the failures, if any.
+	Grant(ctx context.Context, params []payloads.ACLParams) error
+
+	// Revoke deletes several ACLs. The ACLs that do not exist are skipped and a
+	// failure does not stop the others from being deleted.
+	// Parameters:
+	//   - params: the ACLs to delete
+	// Returns an error joining the failures, if any.
+	Revoke(ctx context.Context, params []payloads.ACLParams) error
+
+	// Check reports whether a subject has a role on an object. Like XO, it takes
+	// into account the ACLs of the subject's groups, the admin users, and the ACLs
+	// on the objects containing the object: an operator of a pool operates its
+	// hosts and their VMs, and the SR of a VDI gives access to the VDI.
+	// Parameters:
+	//   - subject: ID of a user or a group
+	//   - object: ID of the object
+	//   - action: role to check
+	// Returns whether the role is granted, or an error if the subject or the object
+	// can't be found or the operation fails.
+	Check(ctx context.Context, subject uuid.UUID, object uuid.UUID, action payloads.ACLRole) (bool, error)
+}
@@ -0,0 +1,37 @@
+package library
+
+import (
+	"context"
+	"io"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/audit.go . Audit
+type Audit interface {
+	// GetRecords retrieves a page of the audit log, most recent first. It needs
+	// the audit plugin to be enabled in XO.
+	// Parameters:
+	//   - cursor: ID of the most recent record of the page, "" for the first page,
+	//     or the Next cursor of the previous page
+	//   - limit: maximum number of records of the page, 0 for all the records
+	// Returns the page or an error if the operation fails.
+	GetRecords(ctx context.Context, cursor string, limit int) (*payloads.AuditPage, error)
+
+	// Verify checks the hash chain of audit records locally: the ID of each
+	// record must be the hash of its content, and the records must follow each
+	// other.
+	// Parameters:
+	//   - records: consecutive records, most recent first, e.g. a page or several
+	//     pages appended
+	// Returns an error describing the first altered or missing record, if any.
+	Verify(records []*payloads.AuditRecord) error
+
+	// Export writes the audit log as NDJSON, one record per line, most recent
+	// first. The exported records can be verified again once decoded.
+	// Parameters:
+	//   - w: destination of the records
+	//   - cursor: ID of the most recent record to export, "" for the whole log
+	// Returns the number of exported records or an error if the operation fails.
+	Export(ctx context.Context, w io.Writer, cursor string) (int, error)
+}
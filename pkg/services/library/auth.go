@@ -0,0 +1,30 @@
+package library
+
+import (
+	"context"
+	"time"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/auth.go . Auth
+type Auth interface {
+	// CreateToken creates an authentication token for the current user, for
+	// example to give a CI job short-lived access to XO. The token is never logged.
+	// Parameters:
+	//   - description: what the token is used for, shown in the XO UI
+	//   - expiresIn: validity of the token, 0 for the XO default (30 days)
+	// Returns the created token, whose ID is the secret to use in config.Config.Token,
+	// or an error if the operation fails.
+	CreateToken(ctx context.Context, description string, expiresIn time.Duration) (*payloads.AuthToken, error)
+
+	// ListTokens retrieves the authentication tokens of the current user.
+	// Returns the tokens or an error if the operation fails.
+	ListTokens(ctx context.Context) ([]*payloads.AuthToken, error)
+
+	// RevokeToken deletes an authentication token: it can't be used anymore.
+	// Parameters:
+	//   - id: the token to revoke
+	// Returns an error if the operation fails.
+	RevokeToken(ctx context.Context, id string) error
+}
@@ -0,0 +1,95 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/backup.go . Backup
+type Backup interface {
+	// Get retrieves a backup job by its ID.
+	// Parameters:
+	//   - id: ID of the job
+	// Returns the job or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.BackupJob, error)
+
+	// GetAll retrieves the backup jobs of all modes.
+	// Returns the jobs or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.BackupJob, error)
+
+	// Create creates a backup job and its schedules. The retention of each
+	// schedule is taken from the schedule parameters, job.Retention is ignored.
+	// Parameters:
+	//   - job: the job to create, its ID is ignored
+	//   - schedules: the schedules running the job
+	// Returns the created job or an error if the job is invalid or the operation fails.
+	Create(ctx context.Context, job *payloads.BackupJob,
+		schedules []payloads.BackupScheduleParams) (*payloads.BackupJob, error)
+
+	// Update replaces the settings of a backup job. The mode can only be changed
+	// to a mode of the same family: full, delta and continuous replication jobs
+	// can't become mirror or metadata jobs.
+	// Parameters:
+	//   - job: the job with its new settings
+	// Returns the updated job or an error if the job is invalid or the operation fails.
+	Update(ctx context.Context, job *payloads.BackupJob) (*payloads.BackupJob, error)
+
+	// Delete deletes a backup job and its schedules. The backups are kept.
+	// Parameters:
+	//   - id: ID of the job
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// RunNow starts a backup job without waiting for its schedule. XO does not
+	// wait for the end of the run: use GetLogs to follow it.
+	// Parameters:
+	//   - id: ID of the job
+	//   - scheduleID: schedule whose retention applies, uuid.Nil for the first schedule of the job
+	// Returns an error if the job has no schedule or the operation fails.
+	RunNow(ctx context.Context, id uuid.UUID, scheduleID uuid.UUID) error
+
+	// GetLogs retrieves the logs of the runs of a backup job, most recent first.
+	// Parameters:
+	//   - jobID: ID of the job
+	// Returns the logs or an error if the operation fails.
+	GetLogs(ctx context.Context, jobID uuid.UUID) ([]*payloads.BackupLog, error)
+
+	// ListRestorePoints lists the VM backups stored on a remote, most recent first.
+	// Parameters:
+	//   - remoteID: ID of the remote
+	//   - vmFilter: UUID of a VM or part of its name, "" for the backups of all the VMs
+	// Returns the restore points or an error if the remote is unreachable or the
+	// operation fails.
+	ListRestorePoints(ctx context.Context, remoteID uuid.UUID, vmFilter string) ([]*payloads.RestorePoint, error)
+
+	// Restore creates a new VM from a restore point, waiting for the end of the restoration.
+	// Canceling ctx stops waiting but XO keeps restoring the VM, which then
+	// appears without its ID being returned.
+	// Parameters:
+	//   - restorePointID: ID of the restore point
+	//   - targetSR: SR on which the disks of the VM are created
+	//   - startAfter: start the VM once restored
+	// Returns the ID of the restored VM, or an error if the operation fails. If
+	// the VM is restored but fails to start, its ID is returned with the error.
+	Restore(ctx context.Context, restorePointID string, targetSR uuid.UUID, startAfter bool) (uuid.UUID, error)
+
+	// ListPartitions lists the partitions of a backed up disk, to restore files.
+	// Parameters:
+	//   - remoteID: ID of the remote
+	//   - diskID: ID of the disk, from RestorePoint.Disks
+	// Returns the partitions or an error if the operation fails.
+	ListPartitions(ctx context.Context, remoteID uuid.UUID, diskID string) ([]*payloads.DiskPartition, error)
+
+	// ListFiles lists a directory of a partition of a backed up disk.
+	// Parameters:
+	//   - remoteID: ID of the remote
+	//   - diskID: ID of the disk, from RestorePoint.Disks
+	//   - partitionID: ID of the partition, "" for a disk without partition table
+	//   - path: absolute path of the directory, e.g. "/etc/"
+	// Returns the entries of the directory, sorted by name, or an error if the
+	// operation fails.
+	ListFiles(ctx context.Context, remoteID uuid.UUID, diskID string, partitionID string,
+		path string) ([]*payloads.BackupFile, error)
+}
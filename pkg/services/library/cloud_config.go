@@ -0,0 +1,68 @@
+package library
+
+import (
+	"context"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/cloud_config.go . CloudConfig
+type CloudConfig interface {
+	// Get retrieves a stored cloud config or network config by its ID.
+	// Parameters:
+	//   - id: XO ID of the template
+	// Returns the template or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id string) (*payloads.CloudConfig, error)
+
+	// GetAll retrieves the stored templates of a given type.
+	// Parameters:
+	//   - configType: CloudConfigTypeUser for cloud configs, CloudConfigTypeNetwork for network configs
+	// Returns the templates or an error if the operation fails.
+	GetAll(ctx context.Context, configType payloads.CloudConfigType) ([]*payloads.CloudConfig, error)
+
+	// GetByName retrieves the stored templates of a given type with the given name.
+	// Names are not unique in XO, several templates may be returned.
+	// Parameters:
+	//   - configType: type of the templates to search
+	//   - name: name of the templates
+	// Returns the matching templates or an error if none matches or the operation fails.
+	GetByName(ctx context.Context, configType payloads.CloudConfigType, name string) ([]*payloads.CloudConfig, error)
+
+	// Create stores a new template. Templates are not validated as they may
+	// contain placeholders: Validate the rendered content instead.
+	// Parameters:
+	//   - params: name, content and type of the template
+	// Returns the created template or an error if the operation fails.
+	Create(ctx context.Context, params payloads.CloudConfigCreateParams) (*payloads.CloudConfig, error)
+
+	// Update changes the name and/or content of a stored template.
+	// Parameters:
+	//   - id: XO ID of the template
+	//   - params: fields to update, nil fields are left unchanged
+	// Returns an error if the operation fails.
+	Update(ctx context.Context, id string, params payloads.CloudConfigUpdateParams) error
+
+	// Delete removes a stored template.
+	// Parameters:
+	//   - id: XO ID of the template
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id string) error
+
+	// Render substitutes the XO placeholders of a template, locally. See
+	// payloads.CloudConfigVariables for the syntax.
+	// Parameters:
+	//   - template: template content
+	//   - vars: values of the placeholders
+	// Returns the rendered content, ready for payloads.CreateVMParams.
+	Render(template string, vars payloads.CloudConfigVariables) string
+
+	// Validate checks a rendered document before it is given to a VM: user data
+	// must be a cloud-config, script, include or MIME multipart document and
+	// network configs must follow the cloud-init version 1 or 2 schema.
+	// Pool.CreateVM runs the same checks on its cloud and network configs.
+	// Parameters:
+	//   - configType: type of the document
+	//   - content: rendered document
+	// Returns an error describing every problem found, or nil if the document is valid.
+	Validate(configType payloads.CloudConfigType, content string) error
+}
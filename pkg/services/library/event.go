@@ -0,0 +1,16 @@
+package library
+
+import (
+	"context"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/event.go . Event
+type Event interface {
+	// Subscribe sends the changes of the objects matching filter until ctx is
+	// done, then closes the channel. The existing objects are first sent as
+	// additions. The subscription reconnects when the connection is lost, and
+	// then sends the changes missed in the meantime.
+	Subscribe(ctx context.Context, filter payloads.ObjectEventFilter) (<-chan payloads.ObjectEvent, error)
+}
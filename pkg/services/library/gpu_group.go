@@ -0,0 +1,24 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/gpu_group.go . GPUGroup
+type GPUGroup interface {
+	// Get retrieves a GPU group by its ID.
+	// Parameters:
+	//   - id: ID of the GPU group
+	// Returns the GPU group or an error if the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.GPUGroup, error)
+
+	// GetAll retrieves GPU groups with configurable limit and filtering.
+	// Parameters:
+	//   - limit: maximum number of GPU groups to return (0 for no limit)
+	//   - filter: filter string for GPU group selection (empty for no filter)
+	// Returns all matching GPU groups or an error if the operation fails.
+	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.GPUGroup, error)
+}
@@ -0,0 +1,61 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/group.go . Group
+type Group interface {
+	// Get retrieves a group by its ID.
+	// Parameters:
+	//   - id: ID of the group
+	// Returns the group or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.Group, error)
+
+	// GetAll retrieves all the groups.
+	// Returns the groups or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.Group, error)
+
+	// Create creates an empty group.
+	// Parameters:
+	//   - name: name of the group
+	// Returns the created group or an error if the operation fails.
+	Create(ctx context.Context, name string) (*payloads.Group, error)
+
+	// Rename changes the name of a group.
+	// Parameters:
+	//   - id: ID of the group
+	//   - name: new name of the group
+	// Returns an error if the operation fails.
+	Rename(ctx context.Context, id uuid.UUID, name string) error
+
+	// Delete deletes a group. Its members are not deleted.
+	// Parameters:
+	//   - id: ID of the group
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddMember adds a user to a group.
+	// Parameters:
+	//   - id: ID of the group
+	//   - userID: ID of the user to add
+	// Returns an error if the operation fails.
+	AddMember(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+
+	// RemoveMember removes a user from a group.
+	// Parameters:
+	//   - id: ID of the group
+	//   - userID: ID of the user to remove
+	// Returns an error if the operation fails.
+	RemoveMember(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+
+	// SetMembers replaces the members of a group.
+	// Parameters:
+	//   - id: ID of the group
+	//   - userIDs: IDs of the users the group must contain
+	// Returns an error if the operation fails.
+	SetMembers(ctx context.Context, id uuid.UUID, userIDs []uuid.UUID) error
+}
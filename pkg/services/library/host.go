@@ -9,9 +9,15 @@ import (
 
 //go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/host.go . Host
 type Host interface {
-	Get(ctx context.Context, id uuid.UUID) (*payloads.Host, error)
-	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.Host, error)
+	HostReader
 
 	Taggable
 	Taskable
 }
+
+// HostReader is the read part of Host, also implemented by the Host lister of
+// the informer.
+type HostReader interface {
+	Get(ctx context.Context, id uuid.UUID) (*payloads.Host, error)
+	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.Host, error)
+}
@@ -0,0 +1,77 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+// Informer is a local cache of the XO objects, filled by an initial listing
+// and kept up to date by the object events.
+//
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/informer.go . Informer
+type Informer interface {
+	// Run fills the cache and keeps it up to date until ctx is done or the
+	// cache exceeds its maximum number of objects. It must be called once.
+	Run(ctx context.Context) error
+	// HasSynced reports whether the cache holds all the objects. It becomes
+	// false again when Run returns.
+	HasSynced() bool
+	// WaitForSync waits until the cache has synced, Run failed or ctx is done.
+	WaitForSync(ctx context.Context) error
+	// AddEventHandler registers a handler called after each change of the
+	// cache. It is first called with the objects already cached.
+	AddEventHandler(handler EventHandler)
+	Lister() Lister
+}
+
+// EventHandler receives the changes of the objects of an informer, e.g.
+// *payloads.VM. The handlers are called one at a time and must not block.
+type EventHandler interface {
+	OnAdd(object any)
+	OnUpdate(oldObject, newObject any)
+	OnDelete(object any)
+}
+
+// Lister gives access to the cached objects. The objects returned are shared
+// by all the callers and must not be modified.
+//
+// The listers also implement the read interfaces of the services: they read
+// the cache once it has synced, and XO otherwise, for objects not cached yet
+// and for filtered listings.
+type Lister interface {
+	VMs() VMLister
+	SRs() SRLister
+	Hosts() HostLister
+	Pools() PoolLister
+}
+
+type VMLister interface {
+	VMReader
+	List() []*payloads.VM
+	ByPool(poolID uuid.UUID) []*payloads.VM
+	ByTag(tag string) []*payloads.VM
+	ByPowerState(powerState string) []*payloads.VM
+}
+
+type SRLister interface {
+	SRReader
+	List() []*payloads.StorageRepository
+	ByPool(poolID uuid.UUID) []*payloads.StorageRepository
+	ByTag(tag string) []*payloads.StorageRepository
+}
+
+type HostLister interface {
+	HostReader
+	List() []*payloads.Host
+	ByPool(poolID uuid.UUID) []*payloads.Host
+	ByTag(tag string) []*payloads.Host
+	ByPowerState(powerState string) []*payloads.Host
+}
+
+type PoolLister interface {
+	PoolReader
+	List() []*payloads.Pool
+	ByTag(tag string) []*payloads.Pool
+}
@@ -1,9 +1,20 @@
 package library
 
-import "go.uber.org/zap"
+import (
+	"context"
 
+	"go.uber.org/zap"
+)
+
+// JSONRPC calls the JSON-RPC API of XO. The services use it for the
+// operations that are not exposed by the REST API yet, and should move to the
+// REST API once they are.
+//
 //go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/jsonrpc.go . JSONRPC
 type JSONRPC interface {
 	Call(method string, params map[string]any, result any, logContext ...zap.Field) error
+	// CallContext is like Call, but the call is abandoned once ctx is done.
+	// XO is not told: the operation keeps running on its side.
+	CallContext(ctx context.Context, method string, params map[string]any, result any, logContext ...zap.Field) error
 	ValidateResult(result bool, operation string, logContext ...zap.Field) error
 }
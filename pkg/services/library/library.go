@@ -1,7 +1,10 @@
 package library
 
 import (
+	"context"
+
 	v1 "github.com/vatesfr/xenorchestra-go-sdk/client"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
 )
 
 type Library interface {
@@ -14,6 +17,26 @@ type Library interface {
 	PBD() PBD
 	SR() SR
 	Network() Network
+	CloudConfig() CloudConfig
+	User() User
+	Group() Group
+	Auth() Auth
+	ACL() ACL
+	ResourceSet() ResourceSet
+	Backup() Backup
+	Remote() Remote
+	Schedule() Schedule
+	Message() Message
+	Audit() Audit
+	Server() Server
+	PCI() PCI
+	PGPU() PGPU
+	GPUGroup() GPUGroup
+	VGPUType() VGPUType
+	// Subscribe sends the changes of the XO objects, see Event.
+	Subscribe(ctx context.Context, filter payloads.ObjectEventFilter) (<-chan payloads.ObjectEvent, error)
+	// NewInformer creates a local cache of the XO objects, see Informer.
+	NewInformer(options payloads.InformerOptions) (Informer, error)
 	// Added to provide access to the v1 client, allowing users to:
 	// 1. Access v1 functionality without initializing a separate client
 	// 2. Use v2 features while maintaining backward compatibility
@@ -0,0 +1,30 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/message.go . Message
+type Message interface {
+	// GetAll retrieves the XAPI messages, alarms included, most recent first.
+	// Parameters:
+	//   - filter: selection of the messages, the zero value for all of them
+	// Returns the messages or an error if the operation fails.
+	GetAll(ctx context.Context, filter payloads.MessageFilter) ([]*payloads.Message, error)
+
+	// GetAlarms retrieves the alarms, most recent first. The name of the filter
+	// is ignored.
+	// Parameters:
+	//   - filter: selection of the alarms, the zero value for all of them
+	// Returns the alarms or an error if the operation fails.
+	GetAlarms(ctx context.Context, filter payloads.MessageFilter) ([]*payloads.Alarm, error)
+
+	// Delete deletes a message or an alarm.
+	// Parameters:
+	//   - id: ID of the message
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
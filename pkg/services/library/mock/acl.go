@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: ACL)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/acl.go . ACL
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockACL is a mock of ACL interface.
+type MockACL struct {
+	ctrl     *gomock.Controller
+	recorder *MockACLMockRecorder
+	isgomock struct{}
+}
+
+// MockACLMockRecorder is the mock recorder for MockACL.
+type MockACLMockRecorder struct {
+	mock *MockACL
+}
+
+// NewMockACL creates a new mock instance.
+func NewMockACL(ctrl *gomock.Controller) *MockACL {
+	mock := &MockACL{ctrl: ctrl}
+	mock.recorder = &MockACLMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockACL) EXPECT() *MockACLMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockACL) Check(ctx context.Context, subject, object uuid.UUID, action payloads.ACLRole) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, subject, object, action)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockACLMockRecorder) Check(ctx, subject, object, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockACL)(nil).Check), ctx, subject, object, action)
+}
+
+// Create mocks base method.
+func (m *MockACL) Create(ctx context.Context, params payloads.ACLParams) (*payloads.ACL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*payloads.ACL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockACLMockRecorder) Create(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockACL)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockACL) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockACLMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockACL)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockACL) Get(ctx context.Context, id string) (*payloads.ACL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.ACL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockACLMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockACL)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockACL) GetAll(ctx context.Context) ([]*payloads.ACL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*payloads.ACL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockACLMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockACL)(nil).GetAll), ctx)
+}
+
+// Grant mocks base method.
+func (m *MockACL) Grant(ctx context.Context, params []payloads.ACLParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockACLMockRecorder) Grant(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockACL)(nil).Grant), ctx, params)
+}
+
+// Revoke mocks base method.
+func (m *MockACL) Revoke(ctx context.Context, params []payloads.ACLParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockACLMockRecorder) Revoke(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockACL)(nil).Revoke), ctx, params)
+}
+
+// SetRole mocks base method.
+func (m *MockACL) SetRole(ctx context.Context, id string, role payloads.ACLRole) (*payloads.ACL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, id, role)
+	ret0, _ := ret[0].(*payloads.ACL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockACLMockRecorder) SetRole(ctx, id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockACL)(nil).SetRole), ctx, id, role)
+}
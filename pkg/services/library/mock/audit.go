@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: Audit)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/audit.go . Audit
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockAudit) Export(ctx context.Context, w io.Writer, cursor string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w, cursor)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockAuditMockRecorder) Export(ctx, w, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockAudit)(nil).Export), ctx, w, cursor)
+}
+
+// GetRecords mocks base method.
+func (m *MockAudit) GetRecords(ctx context.Context, cursor string, limit int) (*payloads.AuditPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecords", ctx, cursor, limit)
+	ret0, _ := ret[0].(*payloads.AuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecords indicates an expected call of GetRecords.
+func (mr *MockAuditMockRecorder) GetRecords(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecords", reflect.TypeOf((*MockAudit)(nil).GetRecords), ctx, cursor, limit)
+}
+
+// Verify mocks base method.
+func (m *MockAudit) Verify(records []*payloads.AuditRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockAuditMockRecorder) Verify(records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockAudit)(nil).Verify), records)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: Auth)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/auth.go . Auth
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuth is a mock of Auth interface.
+type MockAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthMockRecorder is the mock recorder for MockAuth.
+type MockAuthMockRecorder struct {
+	mock *MockAuth
+}
+
+// NewMockAuth creates a new mock instance.
+func NewMockAuth(ctrl *gomock.Controller) *MockAuth {
+	mock := &MockAuth{ctrl: ctrl}
+	mock.recorder = &MockAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method.
+func (m *MockAuth) CreateToken(ctx context.Context, description string, expiresIn time.Duration) (*payloads.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, description, expiresIn)
+	ret0, _ := ret[0].(*payloads.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockAuthMockRecorder) CreateToken(ctx, description, expiresIn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockAuth)(nil).CreateToken), ctx, description, expiresIn)
+}
+
+// ListTokens mocks base method.
+func (m *MockAuth) ListTokens(ctx context.Context) ([]*payloads.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTokens", ctx)
+	ret0, _ := ret[0].([]*payloads.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTokens indicates an expected call of ListTokens.
+func (mr *MockAuthMockRecorder) ListTokens(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTokens", reflect.TypeOf((*MockAuth)(nil).ListTokens), ctx)
+}
+
+// RevokeToken mocks base method.
+func (m *MockAuth) RevokeToken(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockAuthMockRecorder) RevokeToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockAuth)(nil).RevokeToken), ctx, id)
+}
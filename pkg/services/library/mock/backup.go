@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: Backup)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/backup.go . Backup
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackup is a mock of Backup interface.
+type MockBackup struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupMockRecorder is the mock recorder for MockBackup.
+type MockBackupMockRecorder struct {
+	mock *MockBackup
+}
+
+// NewMockBackup creates a new mock instance.
+func NewMockBackup(ctrl *gomock.Controller) *MockBackup {
+	mock := &MockBackup{ctrl: ctrl}
+	mock.recorder = &MockBackupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackup) EXPECT() *MockBackupMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBackup) Create(ctx context.Context, job *payloads.BackupJob, schedules []payloads.BackupScheduleParams) (*payloads.BackupJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job, schedules)
+	ret0, _ := ret[0].(*payloads.BackupJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBackupMockRecorder) Create(ctx, job, schedules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBackup)(nil).Create), ctx, job, schedules)
+}
+
+// Delete mocks base method.
+func (m *MockBackup) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBackupMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBackup)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockBackup) Get(ctx context.Context, id uuid.UUID) (*payloads.BackupJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.BackupJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBackupMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBackup)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockBackup) GetAll(ctx context.Context) ([]*payloads.BackupJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*payloads.BackupJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockBackupMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBackup)(nil).GetAll), ctx)
+}
+
+// GetLogs mocks base method.
+func (m *MockBackup) GetLogs(ctx context.Context, jobID uuid.UUID) ([]*payloads.BackupLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogs", ctx, jobID)
+	ret0, _ := ret[0].([]*payloads.BackupLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogs indicates an expected call of GetLogs.
+func (mr *MockBackupMockRecorder) GetLogs(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockBackup)(nil).GetLogs), ctx, jobID)
+}
+
+// ListFiles mocks base method.
+func (m *MockBackup) ListFiles(ctx context.Context, remoteID uuid.UUID, diskID, partitionID, path string) ([]*payloads.BackupFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", ctx, remoteID, diskID, partitionID, path)
+	ret0, _ := ret[0].([]*payloads.BackupFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockBackupMockRecorder) ListFiles(ctx, remoteID, diskID, partitionID, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockBackup)(nil).ListFiles), ctx, remoteID, diskID, partitionID, path)
+}
+
+// ListPartitions mocks base method.
+func (m *MockBackup) ListPartitions(ctx context.Context, remoteID uuid.UUID, diskID string) ([]*payloads.DiskPartition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPartitions", ctx, remoteID, diskID)
+	ret0, _ := ret[0].([]*payloads.DiskPartition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPartitions indicates an expected call of ListPartitions.
+func (mr *MockBackupMockRecorder) ListPartitions(ctx, remoteID, diskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPartitions", reflect.TypeOf((*MockBackup)(nil).ListPartitions), ctx, remoteID, diskID)
+}
+
+// ListRestorePoints mocks base method.
+func (m *MockBackup) ListRestorePoints(ctx context.Context, remoteID uuid.UUID, vmFilter string) ([]*payloads.RestorePoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRestorePoints", ctx, remoteID, vmFilter)
+	ret0, _ := ret[0].([]*payloads.RestorePoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRestorePoints indicates an expected call of ListRestorePoints.
+func (mr *MockBackupMockRecorder) ListRestorePoints(ctx, remoteID, vmFilter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRestorePoints", reflect.TypeOf((*MockBackup)(nil).ListRestorePoints), ctx, remoteID, vmFilter)
+}
+
+// Restore mocks base method.
+func (m *MockBackup) Restore(ctx context.Context, restorePointID string, targetSR uuid.UUID, startAfter bool) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, restorePointID, targetSR, startAfter)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBackupMockRecorder) Restore(ctx, restorePointID, targetSR, startAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBackup)(nil).Restore), ctx, restorePointID, targetSR, startAfter)
+}
+
+// RunNow mocks base method.
+func (m *MockBackup) RunNow(ctx context.Context, id, scheduleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunNow", ctx, id, scheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunNow indicates an expected call of RunNow.
+func (mr *MockBackupMockRecorder) RunNow(ctx, id, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunNow", reflect.TypeOf((*MockBackup)(nil).RunNow), ctx, id, scheduleID)
+}
+
+// Update mocks base method.
+func (m *MockBackup) Update(ctx context.Context, job *payloads.BackupJob) (*payloads.BackupJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, job)
+	ret0, _ := ret[0].(*payloads.BackupJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBackupMockRecorder) Update(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBackup)(nil).Update), ctx, job)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: CloudConfig)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/cloud_config.go . CloudConfig
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCloudConfig is a mock of CloudConfig interface.
+type MockCloudConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudConfigMockRecorder
+	isgomock struct{}
+}
+
+// MockCloudConfigMockRecorder is the mock recorder for MockCloudConfig.
+type MockCloudConfigMockRecorder struct {
+	mock *MockCloudConfig
+}
+
+// NewMockCloudConfig creates a new mock instance.
+func NewMockCloudConfig(ctrl *gomock.Controller) *MockCloudConfig {
+	mock := &MockCloudConfig{ctrl: ctrl}
+	mock.recorder = &MockCloudConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudConfig) EXPECT() *MockCloudConfigMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCloudConfig) Create(ctx context.Context, params payloads.CloudConfigCreateParams) (*payloads.CloudConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*payloads.CloudConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCloudConfigMockRecorder) Create(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCloudConfig)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockCloudConfig) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCloudConfigMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCloudConfig)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCloudConfig) Get(ctx context.Context, id string) (*payloads.CloudConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.CloudConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCloudConfigMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCloudConfig)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockCloudConfig) GetAll(ctx context.Context, configType payloads.CloudConfigType) ([]*payloads.CloudConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, configType)
+	ret0, _ := ret[0].([]*payloads.CloudConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCloudConfigMockRecorder) GetAll(ctx, configType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCloudConfig)(nil).GetAll), ctx, configType)
+}
+
+// GetByName mocks base method.
+func (m *MockCloudConfig) GetByName(ctx context.Context, configType payloads.CloudConfigType, name string) ([]*payloads.CloudConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, configType, name)
+	ret0, _ := ret[0].([]*payloads.CloudConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockCloudConfigMockRecorder) GetByName(ctx, configType, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockCloudConfig)(nil).GetByName), ctx, configType, name)
+}
+
+// Render mocks base method.
+func (m *MockCloudConfig) Render(template string, vars payloads.CloudConfigVariables) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", template, vars)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockCloudConfigMockRecorder) Render(template, vars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockCloudConfig)(nil).Render), template, vars)
+}
+
+// Update mocks base method.
+func (m *MockCloudConfig) Update(ctx context.Context, id string, params payloads.CloudConfigUpdateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCloudConfigMockRecorder) Update(ctx, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCloudConfig)(nil).Update), ctx, id, params)
+}
+
+// Validate mocks base method.
+func (m *MockCloudConfig) Validate(configType payloads.CloudConfigType, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", configType, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockCloudConfigMockRecorder) Validate(configType, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockCloudConfig)(nil).Validate), configType, content)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: Event)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/event.go . Event
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+	isgomock struct{}
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockEvent) Subscribe(ctx context.Context, filter payloads.ObjectEventFilter) (<-chan payloads.ObjectEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, filter)
+	ret0, _ := ret[0].(<-chan payloads.ObjectEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventMockRecorder) Subscribe(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEvent)(nil).Subscribe), ctx, filter)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: GPUGroup)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/gpu_group.go . GPUGroup
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGPUGroup is a mock of GPUGroup interface.
+type MockGPUGroup struct {
+	ctrl     *gomock.Controller
+	recorder *MockGPUGroupMockRecorder
+	isgomock struct{}
+}
+
+// MockGPUGroupMockRecorder is the mock recorder for MockGPUGroup.
+type MockGPUGroupMockRecorder struct {
+	mock *MockGPUGroup
+}
+
+// NewMockGPUGroup creates a new mock instance.
+func NewMockGPUGroup(ctrl *gomock.Controller) *MockGPUGroup {
+	mock := &MockGPUGroup{ctrl: ctrl}
+	mock.recorder = &MockGPUGroupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGPUGroup) EXPECT() *MockGPUGroupMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockGPUGroup) Get(ctx context.Context, id uuid.UUID) (*payloads.GPUGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.GPUGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGPUGroupMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGPUGroup)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockGPUGroup) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.GPUGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, limit, filter)
+	ret0, _ := ret[0].([]*payloads.GPUGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockGPUGroupMockRecorder) GetAll(ctx, limit, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGPUGroup)(nil).GetAll), ctx, limit, filter)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: Informer)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/informer.go . Informer
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	library "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInformer is a mock of Informer interface.
+type MockInformer struct {
+	ctrl     *gomock.Controller
+	recorder *MockInformerMockRecorder
+	isgomock struct{}
+}
+
+// MockInformerMockRecorder is the mock recorder for MockInformer.
+type MockInformerMockRecorder struct {
+	mock *MockInformer
+}
+
+// NewMockInformer creates a new mock instance.
+func NewMockInformer(ctrl *gomock.Controller) *MockInformer {
+	mock := &MockInformer{ctrl: ctrl}
+	mock.recorder = &MockInformerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInformer) EXPECT() *MockInformerMockRecorder {
+	return m.recorder
+}
+
+// AddEventHandler mocks base method.
+func (m *MockInformer) AddEventHandler(handler library.EventHandler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEventHandler", handler)
+}
+
+// AddEventHandler indicates an expected call of AddEventHandler.
+func (mr *MockInformerMockRecorder) AddEventHandler(handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEventHandler", reflect.TypeOf((*MockInformer)(nil).AddEventHandler), handler)
+}
+
+// HasSynced mocks base method.
+func (m *MockInformer) HasSynced() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSynced")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasSynced indicates an expected call of HasSynced.
+func (mr *MockInformerMockRecorder) HasSynced() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSynced", reflect.TypeOf((*MockInformer)(nil).HasSynced))
+}
+
+// Lister mocks base method.
+func (m *MockInformer) Lister() library.Lister {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lister")
+	ret0, _ := ret[0].(library.Lister)
+	return ret0
+}
+
+// Lister indicates an expected call of Lister.
+func (mr *MockInformerMockRecorder) Lister() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lister", reflect.TypeOf((*MockInformer)(nil).Lister))
+}
+
+// Run mocks base method.
+func (m *MockInformer) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockInformerMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockInformer)(nil).Run), ctx)
+}
+
+// WaitForSync mocks base method.
+func (m *MockInformer) WaitForSync(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForSync", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForSync indicates an expected call of WaitForSync.
+func (mr *MockInformerMockRecorder) WaitForSync(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForSync", reflect.TypeOf((*MockInformer)(nil).WaitForSync), ctx)
+}
@@ -10,6 +10,7 @@
 package mock_library
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -59,6 +60,25 @@ func (mr *MockJSONRPCMockRecorder) Call(method, params, result any, logContext .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockJSONRPC)(nil).Call), varargs...)
 }
 
+// CallContext mocks base method.
+func (m *MockJSONRPC) CallContext(ctx context.Context, method string, params map[string]any, result any, logContext ...zap.Field) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, method, params, result}
+	for _, a := range logContext {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CallContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CallContext indicates an expected call of CallContext.
+func (mr *MockJSONRPCMockRecorder) CallContext(ctx, method, params, result any, logContext ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, method, params, result}, logContext...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallContext", reflect.TypeOf((*MockJSONRPC)(nil).CallContext), varargs...)
+}
+
 // ValidateResult mocks base method.
 func (m *MockJSONRPC) ValidateResult(result bool, operation string, logContext ...zap.Field) error {
 	m.ctrl.T.Helper()
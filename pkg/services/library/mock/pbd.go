@@ -42,6 +42,35 @@ func (m *MockPBD) EXPECT() *MockPBDMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockPBD) Create(ctx context.Context, hostID, srID uuid.UUID, deviceConfig map[string]string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, hostID, srID, deviceConfig)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPBDMockRecorder) Create(ctx, hostID, srID, deviceConfig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPBD)(nil).Create), ctx, hostID, srID, deviceConfig)
+}
+
+// Delete mocks base method.
+func (m *MockPBD) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPBDMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPBD)(nil).Delete), ctx, id)
+}
+
 // Get mocks base method.
 func (m *MockPBD) Get(ctx context.Context, id uuid.UUID) (*payloads.PBD, error) {
 	m.ctrl.T.Helper()
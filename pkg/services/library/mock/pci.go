@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: PCI)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/pci.go . PCI
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPCI is a mock of PCI interface.
+type MockPCI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPCIMockRecorder
+	isgomock struct{}
+}
+
+// MockPCIMockRecorder is the mock recorder for MockPCI.
+type MockPCIMockRecorder struct {
+	mock *MockPCI
+}
+
+// NewMockPCI creates a new mock instance.
+func NewMockPCI(ctrl *gomock.Controller) *MockPCI {
+	mock := &MockPCI{ctrl: ctrl}
+	mock.recorder = &MockPCIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPCI) EXPECT() *MockPCIMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPCI) Get(ctx context.Context, id uuid.UUID) (*payloads.PCI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.PCI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPCIMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPCI)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockPCI) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.PCI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, limit, filter)
+	ret0, _ := ret[0].([]*payloads.PCI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPCIMockRecorder) GetAll(ctx, limit, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPCI)(nil).GetAll), ctx, limit, filter)
+}
+
+// GetDom0Access mocks base method.
+func (m *MockPCI) GetDom0Access(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDom0Access", ctx, id)
+	ret0, _ := ret[0].(payloads.PCIDom0Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDom0Access indicates an expected call of GetDom0Access.
+func (mr *MockPCIMockRecorder) GetDom0Access(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDom0Access", reflect.TypeOf((*MockPCI)(nil).GetDom0Access), ctx, id)
+}
+
+// Hide mocks base method.
+func (m *MockPCI) Hide(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hide", ctx, id)
+	ret0, _ := ret[0].(payloads.PCIDom0Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hide indicates an expected call of Hide.
+func (mr *MockPCIMockRecorder) Hide(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hide", reflect.TypeOf((*MockPCI)(nil).Hide), ctx, id)
+}
+
+// Unhide mocks base method.
+func (m *MockPCI) Unhide(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unhide", ctx, id)
+	ret0, _ := ret[0].(payloads.PCIDom0Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unhide indicates an expected call of Unhide.
+func (mr *MockPCIMockRecorder) Unhide(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unhide", reflect.TypeOf((*MockPCI)(nil).Unhide), ctx, id)
+}
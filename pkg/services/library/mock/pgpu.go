@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: PGPU)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/pgpu.go . PGPU
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPGPU is a mock of PGPU interface.
+type MockPGPU struct {
+	ctrl     *gomock.Controller
+	recorder *MockPGPUMockRecorder
+	isgomock struct{}
+}
+
+// MockPGPUMockRecorder is the mock recorder for MockPGPU.
+type MockPGPUMockRecorder struct {
+	mock *MockPGPU
+}
+
+// NewMockPGPU creates a new mock instance.
+func NewMockPGPU(ctrl *gomock.Controller) *MockPGPU {
+	mock := &MockPGPU{ctrl: ctrl}
+	mock.recorder = &MockPGPUMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPGPU) EXPECT() *MockPGPUMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPGPU) Get(ctx context.Context, id uuid.UUID) (*payloads.PGPU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.PGPU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPGPUMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPGPU)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockPGPU) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.PGPU, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, limit, filter)
+	ret0, _ := ret[0].([]*payloads.PGPU)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPGPUMockRecorder) GetAll(ctx, limit, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPGPU)(nil).GetAll), ctx, limit, filter)
+}
+
+// GetCapacities mocks base method.
+func (m *MockPGPU) GetCapacities(ctx context.Context, id uuid.UUID) ([]payloads.VGPUTypeCapacity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapacities", ctx, id)
+	ret0, _ := ret[0].([]payloads.VGPUTypeCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapacities indicates an expected call of GetCapacities.
+func (mr *MockPGPUMockRecorder) GetCapacities(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapacities", reflect.TypeOf((*MockPGPU)(nil).GetCapacities), ctx, id)
+}
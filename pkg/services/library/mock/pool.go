@@ -217,6 +217,20 @@ func (mr *MockPoolMockRecorder) RollingUpdate(ctx, poolID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdate", reflect.TypeOf((*MockPool)(nil).RollingUpdate), ctx, poolID)
 }
 
+// SetUEFICertificates mocks base method.
+func (m *MockPool) SetUEFICertificates(ctx context.Context, poolID uuid.UUID, certificates payloads.UEFICertificates) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUEFICertificates", ctx, poolID, certificates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUEFICertificates indicates an expected call of SetUEFICertificates.
+func (mr *MockPoolMockRecorder) SetUEFICertificates(ctx, poolID, certificates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUEFICertificates", reflect.TypeOf((*MockPool)(nil).SetUEFICertificates), ctx, poolID, certificates)
+}
+
 // MockPoolAction is a mock of PoolAction interface.
 type MockPoolAction struct {
 	ctrl     *gomock.Controller
@@ -342,3 +356,17 @@ func (mr *MockPoolActionMockRecorder) RollingUpdate(ctx, poolID any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdate", reflect.TypeOf((*MockPoolAction)(nil).RollingUpdate), ctx, poolID)
 }
+
+// SetUEFICertificates mocks base method.
+func (m *MockPoolAction) SetUEFICertificates(ctx context.Context, poolID uuid.UUID, certificates payloads.UEFICertificates) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUEFICertificates", ctx, poolID, certificates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUEFICertificates indicates an expected call of SetUEFICertificates.
+func (mr *MockPoolActionMockRecorder) SetUEFICertificates(ctx, poolID, certificates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUEFICertificates", reflect.TypeOf((*MockPoolAction)(nil).SetUEFICertificates), ctx, poolID, certificates)
+}
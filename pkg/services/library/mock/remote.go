@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: Remote)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/remote.go . Remote
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRemote is a mock of Remote interface.
+type MockRemote struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteMockRecorder is the mock recorder for MockRemote.
+type MockRemoteMockRecorder struct {
+	mock *MockRemote
+}
+
+// NewMockRemote creates a new mock instance.
+func NewMockRemote(ctrl *gomock.Controller) *MockRemote {
+	mock := &MockRemote{ctrl: ctrl}
+	mock.recorder = &MockRemoteMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemote) EXPECT() *MockRemoteMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRemote) Create(ctx context.Context, params payloads.RemoteCreateParams) (*payloads.Remote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*payloads.Remote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRemoteMockRecorder) Create(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRemote)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockRemote) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRemoteMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRemote)(nil).Delete), ctx, id)
+}
+
+// Disable mocks base method.
+func (m *MockRemote) Disable(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockRemoteMockRecorder) Disable(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockRemote)(nil).Disable), ctx, id)
+}
+
+// Enable mocks base method.
+func (m *MockRemote) Enable(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockRemoteMockRecorder) Enable(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockRemote)(nil).Enable), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockRemote) Get(ctx context.Context, id uuid.UUID) (*payloads.Remote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.Remote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRemoteMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRemote)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockRemote) GetAll(ctx context.Context) ([]*payloads.Remote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*payloads.Remote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRemoteMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRemote)(nil).GetAll), ctx)
+}
+
+// Test mocks base method.
+func (m *MockRemote) Test(ctx context.Context, id uuid.UUID) (*payloads.RemoteTestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Test", ctx, id)
+	ret0, _ := ret[0].(*payloads.RemoteTestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Test indicates an expected call of Test.
+func (mr *MockRemoteMockRecorder) Test(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Test", reflect.TypeOf((*MockRemote)(nil).Test), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockRemote) Update(ctx context.Context, id uuid.UUID, params payloads.RemoteUpdateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRemoteMockRecorder) Update(ctx, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRemote)(nil).Update), ctx, id, params)
+}
+
+// Usage mocks base method.
+func (m *MockRemote) Usage(ctx context.Context, id uuid.UUID) (*payloads.RemoteUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, id)
+	ret0, _ := ret[0].(*payloads.RemoteUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockRemoteMockRecorder) Usage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockRemote)(nil).Usage), ctx, id)
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: ResourceSet)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/resource_set.go . ResourceSet
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockResourceSet is a mock of ResourceSet interface.
+type MockResourceSet struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceSetMockRecorder
+	isgomock struct{}
+}
+
+// MockResourceSetMockRecorder is the mock recorder for MockResourceSet.
+type MockResourceSetMockRecorder struct {
+	mock *MockResourceSet
+}
+
+// NewMockResourceSet creates a new mock instance.
+func NewMockResourceSet(ctrl *gomock.Controller) *MockResourceSet {
+	mock := &MockResourceSet{ctrl: ctrl}
+	mock.recorder = &MockResourceSetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceSet) EXPECT() *MockResourceSetMockRecorder {
+	return m.recorder
+}
+
+// AddObject mocks base method.
+func (m *MockResourceSet) AddObject(ctx context.Context, id string, object uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddObject", ctx, id, object)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddObject indicates an expected call of AddObject.
+func (mr *MockResourceSetMockRecorder) AddObject(ctx, id, object any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddObject", reflect.TypeOf((*MockResourceSet)(nil).AddObject), ctx, id, object)
+}
+
+// AddSubject mocks base method.
+func (m *MockResourceSet) AddSubject(ctx context.Context, id string, subject uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubject", ctx, id, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubject indicates an expected call of AddSubject.
+func (mr *MockResourceSetMockRecorder) AddSubject(ctx, id, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubject", reflect.TypeOf((*MockResourceSet)(nil).AddSubject), ctx, id, subject)
+}
+
+// CanFit mocks base method.
+func (m *MockResourceSet) CanFit(ctx context.Context, id string, params payloads.CreateVMParams) (*payloads.ResourceSetFit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanFit", ctx, id, params)
+	ret0, _ := ret[0].(*payloads.ResourceSetFit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanFit indicates an expected call of CanFit.
+func (mr *MockResourceSetMockRecorder) CanFit(ctx, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanFit", reflect.TypeOf((*MockResourceSet)(nil).CanFit), ctx, id, params)
+}
+
+// Create mocks base method.
+func (m *MockResourceSet) Create(ctx context.Context, params payloads.ResourceSetCreateParams) (*payloads.ResourceSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*payloads.ResourceSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockResourceSetMockRecorder) Create(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockResourceSet)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockResourceSet) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockResourceSetMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockResourceSet)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockResourceSet) Get(ctx context.Context, id string) (*payloads.ResourceSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.ResourceSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockResourceSetMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockResourceSet)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockResourceSet) GetAll(ctx context.Context) ([]*payloads.ResourceSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*payloads.ResourceSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockResourceSetMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockResourceSet)(nil).GetAll), ctx)
+}
+
+// RemoveLimit mocks base method.
+func (m *MockResourceSet) RemoveLimit(ctx context.Context, id string, key payloads.ResourceSetLimitKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLimit", ctx, id, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLimit indicates an expected call of RemoveLimit.
+func (mr *MockResourceSetMockRecorder) RemoveLimit(ctx, id, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLimit", reflect.TypeOf((*MockResourceSet)(nil).RemoveLimit), ctx, id, key)
+}
+
+// RemoveObject mocks base method.
+func (m *MockResourceSet) RemoveObject(ctx context.Context, id string, object uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveObject", ctx, id, object)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveObject indicates an expected call of RemoveObject.
+func (mr *MockResourceSetMockRecorder) RemoveObject(ctx, id, object any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveObject", reflect.TypeOf((*MockResourceSet)(nil).RemoveObject), ctx, id, object)
+}
+
+// RemoveSubject mocks base method.
+func (m *MockResourceSet) RemoveSubject(ctx context.Context, id string, subject uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSubject", ctx, id, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSubject indicates an expected call of RemoveSubject.
+func (mr *MockResourceSetMockRecorder) RemoveSubject(ctx, id, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubject", reflect.TypeOf((*MockResourceSet)(nil).RemoveSubject), ctx, id, subject)
+}
+
+// SetLimit mocks base method.
+func (m *MockResourceSet) SetLimit(ctx context.Context, id string, key payloads.ResourceSetLimitKey, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimit", ctx, id, key, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLimit indicates an expected call of SetLimit.
+func (mr *MockResourceSetMockRecorder) SetLimit(ctx, id, key, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimit", reflect.TypeOf((*MockResourceSet)(nil).SetLimit), ctx, id, key, quantity)
+}
+
+// Usage mocks base method.
+func (m *MockResourceSet) Usage(ctx context.Context, id string) (payloads.ResourceSetUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, id)
+	ret0, _ := ret[0].(payloads.ResourceSetUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockResourceSetMockRecorder) Usage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockResourceSet)(nil).Usage), ctx, id)
+}
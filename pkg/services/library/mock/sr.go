@@ -101,6 +101,36 @@ func (mr *MockSRMockRecorder) GetTasks(ctx, id, limit, filter any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockSR)(nil).GetTasks), ctx, id, limit, filter)
 }
 
+// Health mocks base method.
+func (m *MockSR) Health(ctx context.Context, id uuid.UUID) (*payloads.SRHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", ctx, id)
+	ret0, _ := ret[0].(*payloads.SRHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockSRMockRecorder) Health(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockSR)(nil).Health), ctx, id)
+}
+
+// ListISOs mocks base method.
+func (m *MockSR) ListISOs(ctx context.Context, srID uuid.UUID) ([]*payloads.VDI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListISOs", ctx, srID)
+	ret0, _ := ret[0].([]*payloads.VDI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListISOs indicates an expected call of ListISOs.
+func (mr *MockSRMockRecorder) ListISOs(ctx, srID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListISOs", reflect.TypeOf((*MockSR)(nil).ListISOs), ctx, srID)
+}
+
 // ReclaimSpace mocks base method.
 func (m *MockSR) ReclaimSpace(ctx context.Context, id uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +160,21 @@ func (mr *MockSRMockRecorder) RemoveTag(ctx, id, tag any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockSR)(nil).RemoveTag), ctx, id, tag)
 }
 
+// RepairPBDs mocks base method.
+func (m *MockSR) RepairPBDs(ctx context.Context, srID uuid.UUID) ([]payloads.PBDRepairResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairPBDs", ctx, srID)
+	ret0, _ := ret[0].([]payloads.PBDRepairResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairPBDs indicates an expected call of RepairPBDs.
+func (mr *MockSRMockRecorder) RepairPBDs(ctx, srID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairPBDs", reflect.TypeOf((*MockSR)(nil).RepairPBDs), ctx, srID)
+}
+
 // Scan mocks base method.
 func (m *MockSR) Scan(ctx context.Context, id uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -144,3 +189,18 @@ func (mr *MockSRMockRecorder) Scan(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockSR)(nil).Scan), ctx, id)
 }
+
+// SetMaintenanceMode mocks base method.
+func (m *MockSR) SetMaintenanceMode(ctx context.Context, id uuid.UUID, enabled bool) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaintenanceMode", ctx, id, enabled)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMaintenanceMode indicates an expected call of SetMaintenanceMode.
+func (mr *MockSRMockRecorder) SetMaintenanceMode(ctx, id, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaintenanceMode", reflect.TypeOf((*MockSR)(nil).SetMaintenanceMode), ctx, id, enabled)
+}
@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: User)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/user.go . User
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+	isgomock struct{}
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// AddSSHKey mocks base method.
+func (m *MockUser) AddSSHKey(ctx context.Context, id uuid.UUID, key payloads.SSHKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSSHKey", ctx, id, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSSHKey indicates an expected call of AddSSHKey.
+func (mr *MockUserMockRecorder) AddSSHKey(ctx, id, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSSHKey", reflect.TypeOf((*MockUser)(nil).AddSSHKey), ctx, id, key)
+}
+
+// ChangePassword mocks base method.
+func (m *MockUser) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, oldPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUserMockRecorder) ChangePassword(ctx, oldPassword, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUser)(nil).ChangePassword), ctx, oldPassword, newPassword)
+}
+
+// Create mocks base method.
+func (m *MockUser) Create(ctx context.Context, params payloads.UserCreateParams) (*payloads.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*payloads.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserMockRecorder) Create(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockUser) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockUser) Get(ctx context.Context, id uuid.UUID) (*payloads.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUserMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUser)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockUser) GetAll(ctx context.Context) ([]*payloads.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*payloads.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockUserMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUser)(nil).GetAll), ctx)
+}
+
+// GetCurrent mocks base method.
+func (m *MockUser) GetCurrent(ctx context.Context) (*payloads.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrent", ctx)
+	ret0, _ := ret[0].(*payloads.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrent indicates an expected call of GetCurrent.
+func (mr *MockUserMockRecorder) GetCurrent(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrent", reflect.TypeOf((*MockUser)(nil).GetCurrent), ctx)
+}
+
+// RemoveSSHKey mocks base method.
+func (m *MockUser) RemoveSSHKey(ctx context.Context, id uuid.UUID, title string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSSHKey", ctx, id, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSSHKey indicates an expected call of RemoveSSHKey.
+func (mr *MockUserMockRecorder) RemoveSSHKey(ctx, id, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSSHKey", reflect.TypeOf((*MockUser)(nil).RemoveSSHKey), ctx, id, title)
+}
+
+// SetPermission mocks base method.
+func (m *MockUser) SetPermission(ctx context.Context, id uuid.UUID, permission payloads.UserPermission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPermission", ctx, id, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPermission indicates an expected call of SetPermission.
+func (mr *MockUserMockRecorder) SetPermission(ctx, id, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPermission", reflect.TypeOf((*MockUser)(nil).SetPermission), ctx, id, permission)
+}
+
+// Update mocks base method.
+func (m *MockUser) Update(ctx context.Context, id uuid.UUID, params payloads.UserUpdateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserMockRecorder) Update(ctx, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), ctx, id, params)
+}
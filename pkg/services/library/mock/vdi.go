@@ -57,6 +57,21 @@ func (mr *MockVDIMockRecorder) AddTag(ctx, id, tag any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockVDI)(nil).AddTag), ctx, id, tag)
 }
 
+// Clone mocks base method.
+func (m *MockVDI) Clone(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, id)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockVDIMockRecorder) Clone(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockVDI)(nil).Clone), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockVDI) Create(arg0 context.Context, arg1 payloads.VDICreateParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +101,36 @@ func (mr *MockVDIMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVDI)(nil).Delete), ctx, id)
 }
 
+// DisableCBT mocks base method.
+func (m *MockVDI) DisableCBT(ctx context.Context, id uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableCBT", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableCBT indicates an expected call of DisableCBT.
+func (mr *MockVDIMockRecorder) DisableCBT(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableCBT", reflect.TypeOf((*MockVDI)(nil).DisableCBT), ctx, id)
+}
+
+// EnableCBT mocks base method.
+func (m *MockVDI) EnableCBT(ctx context.Context, id uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableCBT", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableCBT indicates an expected call of EnableCBT.
+func (mr *MockVDIMockRecorder) EnableCBT(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableCBT", reflect.TypeOf((*MockVDI)(nil).EnableCBT), ctx, id)
+}
+
 // Export mocks base method.
 func (m *MockVDI) Export(ctx context.Context, id uuid.UUID, format payloads.VDIFormat, fn func(io.Reader) error) error {
 	m.ctrl.T.Helper()
@@ -100,6 +145,35 @@ func (mr *MockVDIMockRecorder) Export(ctx, id, format, fn any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockVDI)(nil).Export), ctx, id, format, fn)
 }
 
+// ExportChangedBlocks mocks base method.
+func (m *MockVDI) ExportChangedBlocks(ctx context.Context, baseSnapshotID, currentSnapshotID uuid.UUID, fn func(payloads.BlockExtent, io.Reader) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportChangedBlocks", ctx, baseSnapshotID, currentSnapshotID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportChangedBlocks indicates an expected call of ExportChangedBlocks.
+func (mr *MockVDIMockRecorder) ExportChangedBlocks(ctx, baseSnapshotID, currentSnapshotID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportChangedBlocks", reflect.TypeOf((*MockVDI)(nil).ExportChangedBlocks), ctx, baseSnapshotID, currentSnapshotID, fn)
+}
+
+// ExportWithOptions mocks base method.
+func (m *MockVDI) ExportWithOptions(ctx context.Context, id uuid.UUID, format payloads.VDIFormat, fn func(io.Reader) error, opts payloads.VDITransferOptions) (*payloads.VDITransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportWithOptions", ctx, id, format, fn, opts)
+	ret0, _ := ret[0].(*payloads.VDITransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportWithOptions indicates an expected call of ExportWithOptions.
+func (mr *MockVDIMockRecorder) ExportWithOptions(ctx, id, format, fn, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportWithOptions", reflect.TypeOf((*MockVDI)(nil).ExportWithOptions), ctx, id, format, fn, opts)
+}
+
 // Get mocks base method.
 func (m *MockVDI) Get(ctx context.Context, id uuid.UUID) (*payloads.VDI, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +233,36 @@ func (mr *MockVDIMockRecorder) Import(ctx, id, format, content, size any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockVDI)(nil).Import), ctx, id, format, content, size)
 }
 
+// ImportWithOptions mocks base method.
+func (m *MockVDI) ImportWithOptions(ctx context.Context, id uuid.UUID, format payloads.VDIFormat, content io.Reader, size int64, opts payloads.VDITransferOptions) (*payloads.VDITransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportWithOptions", ctx, id, format, content, size, opts)
+	ret0, _ := ret[0].(*payloads.VDITransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportWithOptions indicates an expected call of ImportWithOptions.
+func (mr *MockVDIMockRecorder) ImportWithOptions(ctx, id, format, content, size, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportWithOptions", reflect.TypeOf((*MockVDI)(nil).ImportWithOptions), ctx, id, format, content, size, opts)
+}
+
+// ListChangedBlocks mocks base method.
+func (m *MockVDI) ListChangedBlocks(ctx context.Context, baseSnapshotID, currentSnapshotID uuid.UUID) (*payloads.ChangedBlocks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChangedBlocks", ctx, baseSnapshotID, currentSnapshotID)
+	ret0, _ := ret[0].(*payloads.ChangedBlocks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChangedBlocks indicates an expected call of ListChangedBlocks.
+func (mr *MockVDIMockRecorder) ListChangedBlocks(ctx, baseSnapshotID, currentSnapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChangedBlocks", reflect.TypeOf((*MockVDI)(nil).ListChangedBlocks), ctx, baseSnapshotID, currentSnapshotID)
+}
+
 // Migrate mocks base method.
 func (m *MockVDI) Migrate(ctx context.Context, id, srId uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -187,3 +291,62 @@ func (mr *MockVDIMockRecorder) RemoveTag(ctx, id, tag any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockVDI)(nil).RemoveTag), ctx, id, tag)
 }
+
+// Resize mocks base method.
+func (m *MockVDI) Resize(ctx context.Context, id uuid.UUID, size int64, online bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resize", ctx, id, size, online)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resize indicates an expected call of Resize.
+func (mr *MockVDIMockRecorder) Resize(ctx, id, size, online any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resize", reflect.TypeOf((*MockVDI)(nil).Resize), ctx, id, size, online)
+}
+
+// SetOnBoot mocks base method.
+func (m *MockVDI) SetOnBoot(ctx context.Context, id uuid.UUID, mode payloads.VDIOnBoot) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOnBoot", ctx, id, mode)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOnBoot indicates an expected call of SetOnBoot.
+func (mr *MockVDIMockRecorder) SetOnBoot(ctx, id, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOnBoot", reflect.TypeOf((*MockVDI)(nil).SetOnBoot), ctx, id, mode)
+}
+
+// Snapshot mocks base method.
+func (m *MockVDI) Snapshot(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, id)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockVDIMockRecorder) Snapshot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockVDI)(nil).Snapshot), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockVDI) Update(ctx context.Context, id uuid.UUID, params payloads.VDIUpdateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVDIMockRecorder) Update(ctx, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVDI)(nil).Update), ctx, id, params)
+}
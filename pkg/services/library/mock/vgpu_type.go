@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library (interfaces: VGPUType)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination mock/vgpu_type.go . VGPUType
+//
+
+// Package mock_library is a generated GoMock package.
+package mock_library
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	payloads "github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVGPUType is a mock of VGPUType interface.
+type MockVGPUType struct {
+	ctrl     *gomock.Controller
+	recorder *MockVGPUTypeMockRecorder
+	isgomock struct{}
+}
+
+// MockVGPUTypeMockRecorder is the mock recorder for MockVGPUType.
+type MockVGPUTypeMockRecorder struct {
+	mock *MockVGPUType
+}
+
+// NewMockVGPUType creates a new mock instance.
+func NewMockVGPUType(ctrl *gomock.Controller) *MockVGPUType {
+	mock := &MockVGPUType{ctrl: ctrl}
+	mock.recorder = &MockVGPUTypeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVGPUType) EXPECT() *MockVGPUTypeMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockVGPUType) Get(ctx context.Context, id uuid.UUID) (*payloads.VGPUType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*payloads.VGPUType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVGPUTypeMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVGPUType)(nil).Get), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockVGPUType) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.VGPUType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, limit, filter)
+	ret0, _ := ret[0].([]*payloads.VGPUType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockVGPUTypeMockRecorder) GetAll(ctx, limit, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockVGPUType)(nil).GetAll), ctx, limit, filter)
+}
@@ -56,6 +56,21 @@ func (mr *MockVMMockRecorder) AddTag(ctx, id, tag any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockVM)(nil).AddTag), ctx, id, tag)
 }
 
+// AttachPCI mocks base method.
+func (m *MockVM) AttachPCI(ctx context.Context, vmID, pciID uuid.UUID) (payloads.PCIDom0Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachPCI", ctx, vmID, pciID)
+	ret0, _ := ret[0].(payloads.PCIDom0Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachPCI indicates an expected call of AttachPCI.
+func (mr *MockVMMockRecorder) AttachPCI(ctx, vmID, pciID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachPCI", reflect.TypeOf((*MockVM)(nil).AttachPCI), ctx, vmID, pciID)
+}
+
 // CleanReboot mocks base method.
 func (m *MockVM) CleanReboot(ctx context.Context, id uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,21 @@ func (mr *MockVMMockRecorder) Create(ctx, poolID, vm any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVM)(nil).Create), ctx, poolID, vm)
 }
 
+// CreateVTPM mocks base method.
+func (m *MockVM) CreateVTPM(ctx context.Context, vmID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVTPM", ctx, vmID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVTPM indicates an expected call of CreateVTPM.
+func (mr *MockVMMockRecorder) CreateVTPM(ctx, vmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVTPM", reflect.TypeOf((*MockVM)(nil).CreateVTPM), ctx, vmID)
+}
+
 // Delete mocks base method.
 func (m *MockVM) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -115,6 +145,49 @@ func (mr *MockVMMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVM)(nil).Delete), ctx, id)
 }
 
+// DeleteVTPM mocks base method.
+func (m *MockVM) DeleteVTPM(ctx context.Context, vmID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVTPM", ctx, vmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVTPM indicates an expected call of DeleteVTPM.
+func (mr *MockVMMockRecorder) DeleteVTPM(ctx, vmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVTPM", reflect.TypeOf((*MockVM)(nil).DeleteVTPM), ctx, vmID)
+}
+
+// DetachPCI mocks base method.
+func (m *MockVM) DetachPCI(ctx context.Context, vmID, pciID uuid.UUID, unhide bool) (payloads.PCIDom0Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPCI", ctx, vmID, pciID, unhide)
+	ret0, _ := ret[0].(payloads.PCIDom0Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachPCI indicates an expected call of DetachPCI.
+func (mr *MockVMMockRecorder) DetachPCI(ctx, vmID, pciID, unhide any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPCI", reflect.TypeOf((*MockVM)(nil).DetachPCI), ctx, vmID, pciID, unhide)
+}
+
+// EjectCD mocks base method.
+func (m *MockVM) EjectCD(ctx context.Context, vmID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EjectCD", ctx, vmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EjectCD indicates an expected call of EjectCD.
+func (mr *MockVMMockRecorder) EjectCD(ctx, vmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EjectCD", reflect.TypeOf((*MockVM)(nil).EjectCD), ctx, vmID)
+}
+
 // GetAll mocks base method.
 func (m *MockVM) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.VM, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +218,21 @@ func (mr *MockVMMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVM)(nil).GetByID), ctx, id)
 }
 
+// GetCDDrives mocks base method.
+func (m *MockVM) GetCDDrives(ctx context.Context, vmID uuid.UUID) ([]*payloads.VBD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCDDrives", ctx, vmID)
+	ret0, _ := ret[0].([]*payloads.VBD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCDDrives indicates an expected call of GetCDDrives.
+func (mr *MockVMMockRecorder) GetCDDrives(ctx, vmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCDDrives", reflect.TypeOf((*MockVM)(nil).GetCDDrives), ctx, vmID)
+}
+
 // GetTasks mocks base method.
 func (m *MockVM) GetTasks(ctx context.Context, id uuid.UUID, limit int, filter string) ([]*payloads.Task, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +293,20 @@ func (mr *MockVMMockRecorder) HardShutdown(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardShutdown", reflect.TypeOf((*MockVM)(nil).HardShutdown), ctx, id)
 }
 
+// InsertCD mocks base method.
+func (m *MockVM) InsertCD(ctx context.Context, vmID, isoVDIID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCD", ctx, vmID, isoVDIID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCD indicates an expected call of InsertCD.
+func (mr *MockVMMockRecorder) InsertCD(ctx, vmID, isoVDIID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCD", reflect.TypeOf((*MockVM)(nil).InsertCD), ctx, vmID, isoVDIID)
+}
+
 // List mocks base method.
 func (m *MockVM) List(ctx context.Context) ([]*payloads.VM, error) {
 	m.ctrl.T.Helper()
@@ -279,6 +381,20 @@ func (mr *MockVMMockRecorder) Resume(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockVM)(nil).Resume), ctx, id)
 }
 
+// SetSecureBoot mocks base method.
+func (m *MockVM) SetSecureBoot(ctx context.Context, vmID uuid.UUID, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecureBoot", ctx, vmID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSecureBoot indicates an expected call of SetSecureBoot.
+func (mr *MockVMMockRecorder) SetSecureBoot(ctx, vmID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecureBoot", reflect.TypeOf((*MockVM)(nil).SetSecureBoot), ctx, vmID, enabled)
+}
+
 // Snapshot mocks base method.
 func (m *MockVM) Snapshot(ctx context.Context, id uuid.UUID, name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -378,6 +494,21 @@ func (m *MockVMActions) EXPECT() *MockVMActionsMockRecorder {
 	return m.recorder
 }
 
+// AttachPCI mocks base method.
+func (m *MockVMActions) AttachPCI(ctx context.Context, vmID, pciID uuid.UUID) (payloads.PCIDom0Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachPCI", ctx, vmID, pciID)
+	ret0, _ := ret[0].(payloads.PCIDom0Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachPCI indicates an expected call of AttachPCI.
+func (mr *MockVMActionsMockRecorder) AttachPCI(ctx, vmID, pciID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachPCI", reflect.TypeOf((*MockVMActions)(nil).AttachPCI), ctx, vmID, pciID)
+}
+
 // CleanReboot mocks base method.
 func (m *MockVMActions) CleanReboot(ctx context.Context, id uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -408,6 +539,64 @@ func (mr *MockVMActionsMockRecorder) CleanShutdown(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanShutdown", reflect.TypeOf((*MockVMActions)(nil).CleanShutdown), ctx, id)
 }
 
+// CreateVTPM mocks base method.
+func (m *MockVMActions) CreateVTPM(ctx context.Context, vmID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVTPM", ctx, vmID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVTPM indicates an expected call of CreateVTPM.
+func (mr *MockVMActionsMockRecorder) CreateVTPM(ctx, vmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVTPM", reflect.TypeOf((*MockVMActions)(nil).CreateVTPM), ctx, vmID)
+}
+
+// DeleteVTPM mocks base method.
+func (m *MockVMActions) DeleteVTPM(ctx context.Context, vmID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVTPM", ctx, vmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVTPM indicates an expected call of DeleteVTPM.
+func (mr *MockVMActionsMockRecorder) DeleteVTPM(ctx, vmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVTPM", reflect.TypeOf((*MockVMActions)(nil).DeleteVTPM), ctx, vmID)
+}
+
+// DetachPCI mocks base method.
+func (m *MockVMActions) DetachPCI(ctx context.Context, vmID, pciID uuid.UUID, unhide bool) (payloads.PCIDom0Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPCI", ctx, vmID, pciID, unhide)
+	ret0, _ := ret[0].(payloads.PCIDom0Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachPCI indicates an expected call of DetachPCI.
+func (mr *MockVMActionsMockRecorder) DetachPCI(ctx, vmID, pciID, unhide any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPCI", reflect.TypeOf((*MockVMActions)(nil).DetachPCI), ctx, vmID, pciID, unhide)
+}
+
+// EjectCD mocks base method.
+func (m *MockVMActions) EjectCD(ctx context.Context, vmID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EjectCD", ctx, vmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EjectCD indicates an expected call of EjectCD.
+func (mr *MockVMActionsMockRecorder) EjectCD(ctx, vmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EjectCD", reflect.TypeOf((*MockVMActions)(nil).EjectCD), ctx, vmID)
+}
+
 // HardReboot mocks base method.
 func (m *MockVMActions) HardReboot(ctx context.Context, id uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -438,6 +627,20 @@ func (mr *MockVMActionsMockRecorder) HardShutdown(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardShutdown", reflect.TypeOf((*MockVMActions)(nil).HardShutdown), ctx, id)
 }
 
+// InsertCD mocks base method.
+func (m *MockVMActions) InsertCD(ctx context.Context, vmID, isoVDIID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCD", ctx, vmID, isoVDIID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCD indicates an expected call of InsertCD.
+func (mr *MockVMActionsMockRecorder) InsertCD(ctx, vmID, isoVDIID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCD", reflect.TypeOf((*MockVMActions)(nil).InsertCD), ctx, vmID, isoVDIID)
+}
+
 // Pause mocks base method.
 func (m *MockVMActions) Pause(ctx context.Context, id uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
@@ -483,6 +686,20 @@ func (mr *MockVMActionsMockRecorder) Resume(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockVMActions)(nil).Resume), ctx, id)
 }
 
+// SetSecureBoot mocks base method.
+func (m *MockVMActions) SetSecureBoot(ctx context.Context, vmID uuid.UUID, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecureBoot", ctx, vmID, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSecureBoot indicates an expected call of SetSecureBoot.
+func (mr *MockVMActionsMockRecorder) SetSecureBoot(ctx, vmID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecureBoot", reflect.TypeOf((*MockVMActions)(nil).SetSecureBoot), ctx, vmID, enabled)
+}
+
 // Snapshot mocks base method.
 func (m *MockVMActions) Snapshot(ctx context.Context, id uuid.UUID, name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -22,6 +22,21 @@ type PBD interface {
 	// Returns all matching PBDs or an error if the operation fails.
 	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.PBD, error)
 
+	// Create creates a new PBD connecting a host to a Storage Repository.
+	// The PBD is created unplugged, call Plug to attach the SR to the host.
+	// Parameters:
+	//   - hostID: ID of the host to connect
+	//   - srID: ID of the SR to connect the host to
+	//   - deviceConfig: SR-type-specific configuration (e.g. {"server": "nfs-host", "serverpath": "/export"})
+	// Returns the ID of the created PBD or an error if the operation fails.
+	Create(ctx context.Context, hostID uuid.UUID, srID uuid.UUID, deviceConfig map[string]string) (uuid.UUID, error)
+
+	// Delete destroys a PBD. The PBD must be unplugged first.
+	// Parameters:
+	//   - id: ID of the PBD to delete
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+
 	// PBDActions is a group of actions that can be performed on a PBD.
 	PBDActions
 }
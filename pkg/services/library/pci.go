@@ -0,0 +1,44 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/pci.go . PCI
+type PCI interface {
+	// Get retrieves a PCI device by its ID.
+	// Parameters:
+	//   - id: ID of the PCI device
+	// Returns the PCI device or an error if the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.PCI, error)
+
+	// GetAll retrieves PCI devices with configurable limit and filtering.
+	// Parameters:
+	//   - limit: maximum number of PCI devices to return (0 for no limit)
+	//   - filter: filter string for PCI device selection, e.g. "$host:<id>" (empty for no filter)
+	// Returns all matching PCI devices or an error if the operation fails.
+	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.PCI, error)
+
+	// GetDom0Access retrieves the access of the control domain to a PCI device.
+	// Parameters:
+	//   - id: ID of the PCI device
+	// Returns the access or an error if the operation fails.
+	GetDom0Access(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error)
+
+	// Hide hides a PCI device from the control domain, so that it can be passed
+	// through to VMs. It applies when the host reboots.
+	// Parameters:
+	//   - id: ID of the PCI device
+	// Returns the new access or an error if the operation fails.
+	Hide(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error)
+
+	// Unhide gives a PCI device back to the control domain. It applies when the
+	// host reboots.
+	// Parameters:
+	//   - id: ID of the PCI device
+	// Returns the new access or an error if the operation fails.
+	Unhide(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error)
+}
@@ -0,0 +1,32 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/pgpu.go . PGPU
+type PGPU interface {
+	// Get retrieves a physical GPU by its ID.
+	// Parameters:
+	//   - id: ID of the GPU
+	// Returns the GPU or an error if the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.PGPU, error)
+
+	// GetAll retrieves physical GPUs with configurable limit and filtering.
+	// Parameters:
+	//   - limit: maximum number of GPUs to return (0 for no limit)
+	//   - filter: filter string for GPU selection, e.g. "$host:<id>" (empty for no filter)
+	// Returns all matching GPUs or an error if the operation fails.
+	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.PGPU, error)
+
+	// GetCapacities computes the number of vGPUs of each supported type a GPU
+	// can still run, from its running vGPUs. A GPU only runs vGPUs of a single
+	// type at a time.
+	// Parameters:
+	//   - id: ID of the GPU
+	// Returns the capacity of each supported type or an error if the operation fails.
+	GetCapacities(ctx context.Context, id uuid.UUID) ([]payloads.VGPUTypeCapacity, error)
+}
@@ -9,8 +9,7 @@ import (
 
 //go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/pool.go . Pool,PoolAction
 type Pool interface {
-	Get(ctx context.Context, id uuid.UUID) (*payloads.Pool, error)
-	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.Pool, error)
+	PoolReader
 
 	Taggable
 	Taskable
@@ -18,6 +17,13 @@ type Pool interface {
 	PoolAction
 }
 
+// PoolReader is the read part of Pool, also implemented by the Pool lister of
+// the informer.
+type PoolReader interface {
+	Get(ctx context.Context, id uuid.UUID) (*payloads.Pool, error)
+	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.Pool, error)
+}
+
 type PoolAction interface {
 	CreateVM(ctx context.Context, poolID uuid.UUID, params payloads.CreateVMParams) (uuid.UUID, error)
 	CreateNetwork(ctx context.Context, poolID uuid.UUID, params payloads.CreateNetworkParams) (uuid.UUID, error)
@@ -34,4 +40,7 @@ type PoolAction interface {
 	EmergencyShutdown(ctx context.Context, poolID uuid.UUID) error
 	RollingReboot(ctx context.Context, poolID uuid.UUID) error
 	RollingUpdate(ctx context.Context, poolID uuid.UUID) error
+	// SetUEFICertificates replaces the custom Secure Boot certificates of a pool.
+	// The zero value removes them: the VMs then use the default certificates.
+	SetUEFICertificates(ctx context.Context, poolID uuid.UUID, certificates payloads.UEFICertificates) error
 }
@@ -0,0 +1,66 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/remote.go . Remote
+type Remote interface {
+	// Get retrieves a remote by its ID.
+	// Parameters:
+	//   - id: ID of the remote
+	// Returns the remote or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.Remote, error)
+
+	// GetAll retrieves all the remotes.
+	// Returns the remotes or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.Remote, error)
+
+	// Create creates a remote. XO enables it and checks that it is reachable.
+	// Parameters:
+	//   - params: name, URL and options of the remote
+	// Returns the created remote or an error if the URL is invalid or the operation fails.
+	Create(ctx context.Context, params payloads.RemoteCreateParams) (*payloads.Remote, error)
+
+	// Update changes the name, URL, options and/or proxy of a remote.
+	// Parameters:
+	//   - id: ID of the remote
+	//   - params: the properties to change
+	// Returns an error if the URL is invalid or the operation fails.
+	Update(ctx context.Context, id uuid.UUID, params payloads.RemoteUpdateParams) error
+
+	// Delete deletes a remote. Its backups are kept on the storage.
+	// Parameters:
+	//   - id: ID of the remote
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Enable mounts a remote, making it usable by the backup jobs.
+	// Parameters:
+	//   - id: ID of the remote
+	// Returns an error if the operation fails.
+	Enable(ctx context.Context, id uuid.UUID) error
+
+	// Disable unmounts a remote. The jobs using it fail until it is enabled.
+	// Parameters:
+	//   - id: ID of the remote
+	// Returns an error if the operation fails.
+	Disable(ctx context.Context, id uuid.UUID) error
+
+	// Test writes a file on a remote, reads it back and deletes it.
+	// Parameters:
+	//   - id: ID of the remote
+	// Returns the result of the test, failed or not, or an error if the test
+	// could not be run.
+	Test(ctx context.Context, id uuid.UUID) (*payloads.RemoteTestResult, error)
+
+	// Usage returns the disk usage of a remote.
+	// Parameters:
+	//   - id: ID of the remote
+	// Returns the usage or an error if the remote is disabled or unreachable,
+	// or the operation fails.
+	Usage(ctx context.Context, id uuid.UUID) (*payloads.RemoteUsage, error)
+}
@@ -0,0 +1,94 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/resource_set.go . ResourceSet
+type ResourceSet interface {
+	// Get retrieves a resource set by its ID.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	// Returns the resource set or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id string) (*payloads.ResourceSet, error)
+
+	// GetAll retrieves all the resource sets.
+	// Returns the resource sets or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.ResourceSet, error)
+
+	// Create creates a resource set.
+	// Parameters:
+	//   - params: name, subjects, objects and limits of the resource set
+	// Returns the created resource set or an error if the operation fails.
+	Create(ctx context.Context, params payloads.ResourceSetCreateParams) (*payloads.ResourceSet, error)
+
+	// Delete deletes a resource set. Its VMs are not deleted.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id string) error
+
+	// AddSubject allows a user or a group to use a resource set.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	//   - subject: ID of the user or group
+	// Returns an error if the operation fails.
+	AddSubject(ctx context.Context, id string, subject uuid.UUID) error
+
+	// RemoveSubject forbids a user or a group to use a resource set.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	//   - subject: ID of the user or group
+	// Returns an error if the operation fails.
+	RemoveSubject(ctx context.Context, id string, subject uuid.UUID) error
+
+	// AddObject allows the VMs of a resource set to use a template, an SR or a network.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	//   - object: ID of the template, SR or network
+	// Returns an error if the operation fails.
+	AddObject(ctx context.Context, id string, object uuid.UUID) error
+
+	// RemoveObject forbids the VMs of a resource set to use a template, an SR or a network.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	//   - object: ID of the template, SR or network
+	// Returns an error if the operation fails.
+	RemoveObject(ctx context.Context, id string, object uuid.UUID) error
+
+	// SetLimit sets the quota of a resource, replacing the previous one.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	//   - key: limited resource
+	//   - quantity: quota, in vCPUs or bytes
+	// Returns an error if the operation fails.
+	SetLimit(ctx context.Context, id string, key payloads.ResourceSetLimitKey, quantity int64) error
+
+	// RemoveLimit removes the quota of a resource, which becomes unlimited.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	//   - key: limited resource
+	// Returns an error if the operation fails.
+	RemoveLimit(ctx context.Context, id string, key payloads.ResourceSetLimitKey) error
+
+	// Usage returns the consumption of each limited resource of a resource set.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	// Returns the used and available quantity of each limit, or an error if the
+	// operation fails.
+	Usage(ctx context.Context, id string) (payloads.ResourceSetUsage, error)
+
+	// CanFit predicts whether a VM can be created in a resource set, without
+	// creating it. The resources are computed like XO does: the vCPUs and memory
+	// of the template, unless the memory is given, and the size of the template
+	// disks and of the new disks.
+	// Parameters:
+	//   - id: XO ID of the resource set
+	//   - params: parameters of the VM creation
+	// Returns the prediction or an error if the template can't be retrieved or
+	// the operation fails.
+	CanFit(ctx context.Context, id string, params payloads.CreateVMParams) (*payloads.ResourceSetFit, error)
+}
@@ -0,0 +1,67 @@
+package library
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/schedule.go . Schedule
+type Schedule interface {
+	// Get retrieves a schedule by its ID.
+	// Parameters:
+	//   - id: ID of the schedule
+	// Returns the schedule or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.Schedule, error)
+
+	// GetAll retrieves all the schedules.
+	// Returns the schedules or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.Schedule, error)
+
+	// GetByJob retrieves the schedules running a job.
+	// Parameters:
+	//   - jobID: ID of the job
+	// Returns the schedules or an error if the operation fails.
+	GetByJob(ctx context.Context, jobID uuid.UUID) ([]*payloads.Schedule, error)
+
+	// Create creates a schedule. The cron pattern and timezone are validated
+	// before calling XO.
+	// Parameters:
+	//   - params: job, cron pattern and timezone of the schedule
+	// Returns the created schedule or an error if the operation fails.
+	Create(ctx context.Context, params payloads.ScheduleCreateParams) (*payloads.Schedule, error)
+
+	// Update changes the properties of a schedule. The cron pattern and timezone
+	// are validated before calling XO.
+	// Parameters:
+	//   - id: ID of the schedule
+	//   - params: the properties to change
+	// Returns the updated schedule or an error if the operation fails.
+	Update(ctx context.Context, id uuid.UUID, params payloads.ScheduleUpdateParams) (*payloads.Schedule, error)
+
+	// Delete deletes a schedule. Its job is not deleted.
+	// Parameters:
+	//   - id: ID of the schedule
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Validate checks a cron pattern and a timezone locally.
+	// Parameters:
+	//   - cron: cron pattern, with 5 fields or 6 with the seconds first
+	//   - timezone: IANA timezone, e.g. "Europe/Paris", "" for the XO server's
+	// Returns an error describing the invalid fields, if any.
+	Validate(cron string, timezone string) error
+
+	// NextRuns computes the next runs of a schedule locally. A disabled schedule
+	// has no run.
+	// Parameters:
+	//   - schedule: the schedule
+	//   - from: the runs are strictly after this time. Its location is used for
+	//     the schedules without timezone, it should be the XO server's timezone.
+	//   - n: maximum number of runs
+	// Returns the runs, fewer than n if the pattern does not match in the next
+	// years, or an error if the schedule is invalid.
+	NextRuns(schedule *payloads.Schedule, from time.Time, n int) ([]time.Time, error)
+}
@@ -0,0 +1,67 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/server.go . Server
+type Server interface {
+	// Get retrieves a server by its ID.
+	// Parameters:
+	//   - id: ID of the server
+	// Returns the server or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.Server, error)
+
+	// GetAll retrieves the servers with their connection status.
+	// Returns the servers or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.Server, error)
+
+	// Create registers a server in XO and, unless disabled, connects to it:
+	// its pool is then available once Create returns.
+	// Parameters:
+	//   - params: address and credentials of the server
+	// Returns the server or an error if the operation fails. If the server is
+	// registered but the connection fails, the server is returned with the error.
+	Create(ctx context.Context, params payloads.ServerCreateParams) (*payloads.Server, error)
+
+	// Update changes the address, credentials or flags of a server.
+	// Parameters:
+	//   - id: ID of the server
+	//   - params: the properties to change
+	// Returns the updated server or an error if the operation fails.
+	Update(ctx context.Context, id uuid.UUID, params payloads.ServerUpdateParams) (*payloads.Server, error)
+
+	// Delete disconnects a server and removes it from XO. The pool is not changed.
+	// Parameters:
+	//   - id: ID of the server
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Connect connects XO to a server, waiting for the connection.
+	// Parameters:
+	//   - id: ID of the server
+	// Returns an error if the connection fails.
+	Connect(ctx context.Context, id uuid.UUID) error
+
+	// Disconnect disconnects XO from a server. It stays disconnected until the
+	// next call to Connect or restart of XO.
+	// Parameters:
+	//   - id: ID of the server
+	// Returns an error if the operation fails.
+	Disconnect(ctx context.Context, id uuid.UUID) error
+
+	// Enable enables a server and connects to it.
+	// Parameters:
+	//   - id: ID of the server
+	// Returns an error if the operation or the connection fails.
+	Enable(ctx context.Context, id uuid.UUID) error
+
+	// Disable disconnects a server and prevents XO from connecting to it when it starts.
+	// Parameters:
+	//   - id: ID of the server
+	// Returns an error if the operation fails.
+	Disable(ctx context.Context, id uuid.UUID) error
+}
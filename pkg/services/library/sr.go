@@ -9,6 +9,18 @@ import (
 
 //go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/sr.go . SR
 type SR interface {
+	SRReader
+
+	Taggable
+
+	Taskable
+
+	SRActions
+}
+
+// SRReader is the read part of SR, also implemented by the SR lister of the
+// informer.
+type SRReader interface {
 	// Get retrieves a Storage Repository by its ID.
 	// Parameters:
 	//   - id: ID of the SR to retrieve
@@ -21,12 +33,6 @@ type SR interface {
 	//   - filter: filter string for SR selection (empty for no filter)
 	// Returns all matching SRs or an error if the operation fails.
 	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.StorageRepository, error)
-
-	Taggable
-
-	Taskable
-
-	SRActions
 }
 
 type SRActions interface {
@@ -46,4 +52,39 @@ type SRActions interface {
 	// Returns the resulting task ID or an error if the operation fails.
 	// TODO: This task is asynchronous but the API offers a way to make it synchronous.
 	Scan(ctx context.Context, id uuid.UUID) (string, error)
+
+	// SetMaintenanceMode enables or disables the maintenance mode of the SR.
+	// When enabling, XO shuts down every running or paused VM using a disk on the SR
+	// and unplugs its PBDs. Disabling restores the PBDs and restarts the halted VMs.
+	// Parameters:
+	//   - id: ID of the SR
+	//   - enabled: true to enter maintenance mode, false to leave it
+	// Returns the IDs of the VMs halted to enter maintenance mode (always empty when
+	// disabling) or an error if the operation fails.
+	SetMaintenanceMode(ctx context.Context, id uuid.UUID, enabled bool) ([]uuid.UUID, error)
+
+	// Health aggregates the state of the SR into a single report: PBD attachment per host,
+	// physical vs virtual usage, VDI chain depth and coalesce backlog.
+	// Parameters:
+	//   - id: ID of the SR to inspect
+	// Returns the health report or an error if the operation fails.
+	Health(ctx context.Context, id uuid.UUID) (*payloads.SRHealth, error)
+
+	// RepairPBDs makes sure every host able to reach the SR has a plugged PBD to it.
+	// For a shared SR this covers every host of the SR's pool: missing PBDs are created
+	// with the device config of an existing PBD of the SR. For a local SR only the
+	// existing PBDs are considered. Detached PBDs are plugged and the call waits for the
+	// plug tasks to complete.
+	// Parameters:
+	//   - srID: ID of the SR to repair
+	// Returns what was done for each host. A failure on one host is reported in its result
+	// and does not stop the repair of the others; an error is only returned when the SR or
+	// its hosts cannot be retrieved.
+	RepairPBDs(ctx context.Context, srID uuid.UUID) ([]payloads.PBDRepairResult, error)
+
+	// ListISOs lists the ISO images that can be inserted in a VM CD drive.
+	// Parameters:
+	//   - srID: ID of an ISO SR, or uuid.Nil to list the ISOs of every ISO SR
+	// Returns the ISO VDIs or an error if the SR is not an ISO SR or the operation fails.
+	ListISOs(ctx context.Context, srID uuid.UUID) ([]*payloads.VDI, error)
 }
@@ -0,0 +1,74 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/user.go . User
+type User interface {
+	// Get retrieves a user by its ID.
+	// Parameters:
+	//   - id: ID of the user
+	// Returns the user or an error if it does not exist or the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.User, error)
+
+	// GetAll retrieves all the users. Only admins can list users.
+	// Returns the users or an error if the operation fails.
+	GetAll(ctx context.Context) ([]*payloads.User, error)
+
+	// GetCurrent retrieves the user the client is authenticated as.
+	// Returns the user or an error if the operation fails.
+	GetCurrent(ctx context.Context) (*payloads.User, error)
+
+	// Create creates a new user.
+	// Parameters:
+	//   - params: login, password and permission of the user
+	// Returns the created user or an error if the operation fails.
+	Create(ctx context.Context, params payloads.UserCreateParams) (*payloads.User, error)
+
+	// Update changes the login, password, permission and/or preferences of a user.
+	// Parameters:
+	//   - id: ID of the user
+	//   - params: fields to update, nil fields are left unchanged
+	// Returns an error if the operation fails.
+	Update(ctx context.Context, id uuid.UUID, params payloads.UserUpdateParams) error
+
+	// Delete deletes a user.
+	// Parameters:
+	//   - id: ID of the user
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// SetPermission changes the global permission level of a user.
+	// Parameters:
+	//   - id: ID of the user
+	//   - permission: new permission level
+	// Returns an error if the operation fails.
+	SetPermission(ctx context.Context, id uuid.UUID, permission payloads.UserPermission) error
+
+	// ChangePassword changes the password of the current user. Admins can set the
+	// password of any user with Update.
+	// Parameters:
+	//   - oldPassword: current password
+	//   - newPassword: new password
+	// Returns an error if the old password is wrong or the operation fails.
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) error
+
+	// AddSSHKey adds a public key to the preferences of a user. XO installs these
+	// keys on the VMs the user creates with cloud-init.
+	// Parameters:
+	//   - id: ID of the user
+	//   - key: title and OpenSSH public key, e.g. "ssh-ed25519 AAAA... comment"
+	// Returns an error if the key is malformed or already present, or if the operation fails.
+	AddSSHKey(ctx context.Context, id uuid.UUID, key payloads.SSHKey) error
+
+	// RemoveSSHKey removes a public key from the preferences of a user.
+	// Parameters:
+	//   - id: ID of the user
+	//   - title: title of the key to remove
+	// Returns an error if the user has no key with this title or the operation fails.
+	RemoveSSHKey(ctx context.Context, id uuid.UUID, title string) error
+}
@@ -29,22 +29,63 @@ type VDI interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Export streams the VDI content in the given format.
+	// QCOW2 and VMDK are converted locally from a raw export: QCOW2 output is
+	// fully allocated, VMDK output is a sparse, compressed streamOptimized image.
 	// Parameters:
 	// - id: ID of the VDI to export
-	// - format: export format (e.g., "raw", "vhd")
+	// - format: export format (e.g., "raw", "vhd", "qcow2", "vmdk")
 	// - fn: callback function that receives the stream reader. The service handles resource cleanup automatically.
 	// The callback receives the io.Reader and is responsible for consuming the stream.
 	// The underlying HTTP connection is automatically closed after the callback returns.
 	Export(ctx context.Context, id uuid.UUID, format payloads.VDIFormat, fn func(io.Reader) error) error
 	// Import uploads VDI content in the given format.
+	// QCOW2 and VMDK images are converted locally to VHD while uploading. They
+	// need random access, so content must then implement io.ReaderAt (e.g. *os.File).
 	// Parameters:
 	// - id: ID of the VDI to import into
-	// - format: format of the content being imported (e.g., "raw", "vhd")
+	// - format: format of the content being imported (e.g., "raw", "vhd", "qcow2", "vmdk")
 	// - content: reader for the content to be imported
 	// - size: size of the content in bytes
 	// Returns an error if the operation fails.
 	Import(ctx context.Context, id uuid.UUID, format payloads.VDIFormat, content io.Reader, size int64) error
 
+	// ExportWithOptions behaves like Export with progress reporting, checksum
+	// and bandwidth throttling. ChunkSize and MaxRetries are ignored.
+	// Parameters:
+	// - id: ID of the VDI to export
+	// - format: export format (e.g., "raw", "vhd", "qcow2", "vmdk")
+	// - fn: callback function that receives the stream reader
+	// - opts: transfer options
+	// Returns the size and the requested checksum of the exported content or an error if the operation fails.
+	ExportWithOptions(
+		ctx context.Context,
+		id uuid.UUID,
+		format payloads.VDIFormat,
+		fn func(io.Reader) error,
+		opts payloads.VDITransferOptions,
+	) (*payloads.VDITransferResult, error)
+	// ImportWithOptions behaves like Import with progress reporting, checksum,
+	// bandwidth throttling and chunked, resumable uploads. Chunked uploads need
+	// content implementing io.ReaderAt to re-read the failed ranges, and a
+	// server supporting resumable uploads, see VDITransferOptions.ChunkSize.
+	// The checksum covers the uploaded content, i.e. the VHD stream for
+	// converted QCOW2 and VMDK images.
+	// Parameters:
+	// - id: ID of the VDI to import into
+	// - format: format of the content being imported (e.g., "raw", "vhd", "qcow2", "vmdk")
+	// - content: reader for the content to be imported
+	// - size: size of the content in bytes
+	// - opts: transfer options
+	// Returns the size and the requested checksum of the uploaded content or an error if the operation fails.
+	ImportWithOptions(
+		ctx context.Context,
+		id uuid.UUID,
+		format payloads.VDIFormat,
+		content io.Reader,
+		size int64,
+		opts payloads.VDITransferOptions,
+	) (*payloads.VDITransferResult, error)
+
 	// Create creates a new VDI with the specified parameters.
 	Create(context.Context, payloads.VDICreateParams) (uuid.UUID, error)
 
@@ -66,4 +107,77 @@ type VDIActions interface {
 	// Returns a task ID or an error if the operation fails.
 	// TODO: This task is asynchronous but the API offers a way to mark it as synchronous.
 	Migrate(ctx context.Context, id uuid.UUID, srId uuid.UUID) (string, error)
+
+	// Resize grows a VDI to the given virtual size.
+	// The call is refused if the VDI has a conflicting operation in progress.
+	// Parameters:
+	//   - id: ID of the VDI to resize
+	//   - size: new virtual size in bytes, must be greater than the current one
+	//   - online: true to resize a VDI attached to a running VM, false for a detached VDI
+	// Returns a task ID or an error if the operation fails.
+	Resize(ctx context.Context, id uuid.UUID, size int64, online bool) (string, error)
+
+	// Clone creates a full copy of a VDI on the same SR.
+	// The call is synchronous: it waits for the clone task to be completed.
+	// Parameters:
+	//   - id: ID of the VDI to clone
+	// Returns the ID of the new VDI or an error if the operation fails.
+	Clone(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+
+	// Snapshot creates a read-only snapshot of a VDI.
+	// The call is synchronous: it waits for the snapshot task to be completed.
+	// Parameters:
+	//   - id: ID of the VDI to snapshot
+	// Returns the ID of the new VDI snapshot or an error if the operation fails.
+	Snapshot(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+
+	// Update changes the name, description, other_config or read-only flag of a VDI.
+	// Parameters:
+	//   - id: ID of the VDI to update
+	//   - params: fields to update, nil fields are left untouched
+	// Returns an error if the operation fails.
+	Update(ctx context.Context, id uuid.UUID, params payloads.VDIUpdateParams) error
+
+	// SetOnBoot sets whether the changes made to a VDI are kept (persist) or
+	// discarded (reset) when the VM it is attached to boots.
+	// Parameters:
+	//   - id: ID of the VDI
+	//   - mode: payloads.VDIOnBootPersist or payloads.VDIOnBootReset
+	// Returns a task ID or an error if the operation fails.
+	SetOnBoot(ctx context.Context, id uuid.UUID, mode payloads.VDIOnBoot) (string, error)
+
+	// EnableCBT enables changed block tracking on a VDI.
+	// Snapshots taken afterwards can be compared with ListChangedBlocks.
+	// Parameters:
+	//   - id: ID of the VDI
+	// Returns a task ID or an error if the operation fails.
+	EnableCBT(ctx context.Context, id uuid.UUID) (string, error)
+
+	// DisableCBT disables changed block tracking on a VDI.
+	// Parameters:
+	//   - id: ID of the VDI
+	// Returns a task ID or an error if the operation fails.
+	DisableCBT(ctx context.Context, id uuid.UUID) (string, error)
+
+	// ListChangedBlocks compares two snapshots of a CBT-enabled VDI.
+	// Parameters:
+	//   - baseSnapshotID: ID of the older snapshot
+	//   - currentSnapshotID: ID of the newer snapshot
+	// Returns the decoded bitmap of the blocks that changed between both
+	// snapshots or an error if the operation fails.
+	ListChangedBlocks(ctx context.Context, baseSnapshotID, currentSnapshotID uuid.UUID) (*payloads.ChangedBlocks, error)
+
+	// ExportChangedBlocks streams only the blocks that changed between two
+	// snapshots of a CBT-enabled VDI, one contiguous extent at a time.
+	// Parameters:
+	//   - baseSnapshotID: ID of the older snapshot
+	//   - currentSnapshotID: ID of the newer snapshot, whose content is exported
+	//   - fn: callback invoked for each extent, in disk order, with a reader of
+	//     exactly extent.Length bytes. The reader is closed when fn returns.
+	// Returns an error if the operation or a callback fails.
+	ExportChangedBlocks(
+		ctx context.Context,
+		baseSnapshotID, currentSnapshotID uuid.UUID,
+		fn func(extent payloads.BlockExtent, data io.Reader) error,
+	) error
 }
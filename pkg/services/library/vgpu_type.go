@@ -0,0 +1,24 @@
+package library
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+)
+
+//go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/vgpu_type.go . VGPUType
+type VGPUType interface {
+	// Get retrieves a vGPU type by its ID.
+	// Parameters:
+	//   - id: ID of the vGPU type
+	// Returns the vGPU type or an error if the operation fails.
+	Get(ctx context.Context, id uuid.UUID) (*payloads.VGPUType, error)
+
+	// GetAll retrieves vGPU types with configurable limit and filtering.
+	// Parameters:
+	//   - limit: maximum number of vGPU types to return (0 for no limit)
+	//   - filter: filter string for vGPU type selection (empty for no filter)
+	// Returns all matching vGPU types or an error if the operation fails.
+	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.VGPUType, error)
+}
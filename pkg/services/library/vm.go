@@ -10,15 +10,9 @@ import (
 //go:generate go run go.uber.org/mock/mockgen --build_flags=--mod=mod --destination mock/vm.go . VM,VMActions
 
 type VM interface {
-	GetByID(ctx context.Context, id uuid.UUID) (*payloads.VM, error)
+	VMReader
 	// Deprecated: Use GetAll instead (List limits results to 10 VMs)
 	List(ctx context.Context) ([]*payloads.VM, error)
-	// GetAll retrieves VMs with configurable limit and filtering.
-	// Parameters:
-	//   - limit: maximum number of VMs to return (0 for no limit)
-	//   - filter: filter string for VM selection (empty for no filter)
-	// Returns all matching VMs or an error if the operation fails.
-	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.VM, error)
 	// Create creates a new VM in the specified pool.
 	// Note: VM creation is primarily handled by the Pool service; this method is provided for convenience.
 	// Parameters:
@@ -31,6 +25,11 @@ type VM interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	// GetVDIs retrieves VDIs associated with a VM, with optional limit and filtering.
 	GetVDIs(ctx context.Context, vmID uuid.UUID, limit int, filter string) ([]*payloads.VDI, error)
+	// GetCDDrives retrieves the CD drives of a VM.
+	// Parameters:
+	//   - vmID: ID of the VM
+	// Returns the VBDs of the CD drives, with the inserted ISO VDI if any, or an error if the operation fails.
+	GetCDDrives(ctx context.Context, vmID uuid.UUID) ([]*payloads.VBD, error)
 
 	// VMActions is a group of actions that can be performed on a VM.
 	VMActions
@@ -39,6 +38,18 @@ type VM interface {
 	Taskable
 }
 
+// VMReader is the read part of VM, also implemented by the VM lister of the
+// informer.
+type VMReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*payloads.VM, error)
+	// GetAll retrieves VMs with configurable limit and filtering.
+	// Parameters:
+	//   - limit: maximum number of VMs to return (0 for no limit)
+	//   - filter: filter string for VM selection (empty for no filter)
+	// Returns all matching VMs or an error if the operation fails.
+	GetAll(ctx context.Context, limit int, filter string) ([]*payloads.VM, error)
+}
+
 type VMActions interface {
 	// Start powers on the specified VM.
 	// Parameters:
@@ -97,4 +108,55 @@ type VMActions interface {
 	//   - id: ID of the VM to unpause
 	// Returns the task ID associated with the unpause operation or an error if the operation fails.
 	Unpause(ctx context.Context, id uuid.UUID) (string, error)
+	// InsertCD inserts an ISO in the CD drive of a VM, replacing the ISO already
+	// inserted. A CD drive is created if the VM has none.
+	// Parameters:
+	//   - vmID: ID of the VM
+	//   - isoVDIID: ID of the ISO VDI to insert, see SR.ListISOs
+	// Returns an error if the VDI is not in an ISO SR or the operation fails.
+	InsertCD(ctx context.Context, vmID uuid.UUID, isoVDIID uuid.UUID) error
+	// EjectCD ejects the ISO inserted in the CD drive of a VM. Nothing is done
+	// if no ISO is inserted.
+	// Parameters:
+	//   - vmID: ID of the VM
+	// Returns an error if the operation fails.
+	EjectCD(ctx context.Context, vmID uuid.UUID) error
+	// AttachPCI passes a PCI device of the host through to a VM, hiding it from
+	// the control domain of the host if it is not hidden yet. The VM gets the
+	// device on its next start, after the reboot of the host if the device had
+	// to be hidden.
+	// Parameters:
+	//   - vmID: ID of the VM
+	//   - pciID: ID of the PCI device
+	// Returns the access of the control domain to the device, which requires a
+	// reboot of the host when it is PCIDom0AccessDisableOnReboot, or an error if
+	// the operation fails.
+	AttachPCI(ctx context.Context, vmID uuid.UUID, pciID uuid.UUID) (payloads.PCIDom0Access, error)
+	// DetachPCI removes a passed through PCI device from a VM, on its next start.
+	// Parameters:
+	//   - vmID: ID of the VM
+	//   - pciID: ID of the PCI device
+	//   - unhide: also give the device back to the control domain of the host
+	// Returns the access of the control domain to the device or an error if the
+	// operation fails.
+	DetachPCI(ctx context.Context, vmID uuid.UUID, pciID uuid.UUID, unhide bool) (payloads.PCIDom0Access, error)
+	// CreateVTPM creates the virtual TPM of a VM, e.g. for Windows 11. The VM
+	// must be halted and boot with UEFI, and its pool must support vTPMs.
+	// Parameters:
+	//   - vmID: ID of the VM
+	// Returns the ID of the vTPM or an error if the operation fails.
+	CreateVTPM(ctx context.Context, vmID uuid.UUID) (uuid.UUID, error)
+	// DeleteVTPM deletes the virtual TPM of a VM, and the secrets it holds.
+	// The VM must be halted. Nothing is done if the VM has no vTPM.
+	// Parameters:
+	//   - vmID: ID of the VM
+	// Returns an error if the operation fails.
+	DeleteVTPM(ctx context.Context, vmID uuid.UUID) error
+	// SetSecureBoot enables or disables Secure Boot on a VM. The VM must be
+	// halted and boot with UEFI.
+	// Parameters:
+	//   - vmID: ID of the VM
+	//   - enabled: true to enable Secure Boot
+	// Returns an error if the operation fails.
+	SetSecureBoot(ctx context.Context, vmID uuid.UUID, enabled bool) error
 }
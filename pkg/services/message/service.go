@@ -0,0 +1,110 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	client     *client.Client
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(client *client.Client, jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Message {
+	return &Service{
+		client:     client,
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) GetAll(ctx context.Context, filter payloads.MessageFilter) ([]*payloads.Message, error) {
+	messages, err := list[payloads.Message](ctx, s, "messages", filter, "$object")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*payloads.Message, 0, len(messages))
+	for _, m := range messages {
+		if matches(filter, m.Object, m.Name, m.Priority, m.Time) {
+			result = append(result, m)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *payloads.Message) int {
+		return b.Time.Compare(a.Time)
+	})
+	return result, nil
+}
+
+func (s *Service) GetAlarms(ctx context.Context, filter payloads.MessageFilter) ([]*payloads.Alarm, error) {
+	alarms, err := list[payloads.Alarm](ctx, s, "alarms", filter, "object:uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Name = ""
+	result := make([]*payloads.Alarm, 0, len(alarms))
+	for _, a := range alarms {
+		if matches(filter, a.Object.UUID, "", a.Priority, a.Time) {
+			result = append(result, a)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *payloads.Alarm) int {
+		return b.Time.Compare(a.Time)
+	})
+	return result, nil
+}
+
+// list retrieves a collection of messages. Only the object of the filter is
+// applied by XO, the other criteria are checked by the callers, as XO matches
+// strings partially.
+func list[T any](ctx context.Context, s *Service, resource string, filter payloads.MessageFilter,
+	objectField string) ([]*T, error) {
+	path := core.NewPathBuilder().Resource(resource).Build()
+	params := map[string]any{"fields": "*"}
+	if filter.Object != uuid.Nil {
+		params["filter"] = fmt.Sprintf("%s:%s", objectField, filter.Object)
+	}
+
+	var result []*T
+	if err := client.TypedGet(ctx, s.client, path, params, &result); err != nil {
+		s.log.Error("Failed to get "+resource, zap.String("objectID", filter.Object.String()), zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+func matches(filter payloads.MessageFilter, object uuid.UUID, name string, priority payloads.MessagePriority,
+	t time.Time) bool {
+	switch {
+	case filter.Object != uuid.Nil && object != filter.Object:
+		return false
+	case filter.Name != "" && name != filter.Name:
+		return false
+	case filter.Priority != payloads.MessagePriorityUnknown && priority != payloads.MessagePriorityUnknown &&
+		priority > filter.Priority:
+		return false
+	case !filter.Since.IsZero() && t.Before(filter.Since):
+		return false
+	case !filter.Until.IsZero() && !t.Before(filter.Until):
+		return false
+	}
+	return true
+}
+
+func (s *Service) Delete(_ context.Context, id uuid.UUID) error {
+	var result any
+	return s.jsonrpcSvc.Call("message.delete", map[string]any{"id": id.String()}, &result,
+		zap.String("messageID", id.String()))
+}
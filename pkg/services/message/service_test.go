@@ -0,0 +1,134 @@
+package message
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+)
+
+var (
+	testHostID = uuid.Must(uuid.FromString("0b6c8f5e-3a2d-4e1f-9c7b-8a6d5e4f3a01"))
+	testSRID   = uuid.Must(uuid.FromString("0b6c8f5e-3a2d-4e1f-9c7b-8a6d5e4f3a02"))
+)
+
+func setupTestServer(t *testing.T) (library.Message, *mock.MockJSONRPC, *[]string) {
+	t.Helper()
+	var filters []string
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /rest/v0/messages", func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("filter"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[
+			{"id": "5f1e2d3c-4b5a-4978-8695-a4b3c2d1e001", "name": "HOST_CLOCK_SKEW_DETECTED", "body": "skew",
+			 "time": 1700000000, "priority": 3, "$object": "%[1]s"},
+			{"id": "5f1e2d3c-4b5a-4978-8695-a4b3c2d1e002", "name": "VM_STARTED", "body": "",
+			 "time": 1700000600, "priority": 5, "$object": "%[1]s"},
+			{"id": "5f1e2d3c-4b5a-4978-8695-a4b3c2d1e003", "name": "ALARM", "body": "value: 0.95",
+			 "time": 1700001200, "$object": "%[2]s"}
+		]`, testHostID, testSRID)
+	})
+
+	mux.HandleFunc("GET /rest/v0/alarms", func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("filter"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[
+			{"id": "5f1e2d3c-4b5a-4978-8695-a4b3c2d1e003", "body": {"name": "physical_utilisation", "value": "0.95"},
+			 "time": 1700001200, "object": {"type": "SR", "uuid": "%[1]s"}},
+			{"id": "5f1e2d3c-4b5a-4978-8695-a4b3c2d1e004", "body": {"name": "mem_usage", "value": "0.9"},
+			 "time": 1700001800, "object": {"type": "host", "uuid": "%[2]s"}}
+		]`, testSRID, testHostID)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	restClient := &client.Client{
+		HttpClient: server.Client(),
+		BaseURL:    &url.URL{Scheme: "http", Host: server.URL[7:], Path: "/rest/v0"},
+		AuthToken:  "test-token",
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(restClient, mockJSONRPC, log), mockJSONRPC, &filters
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("all messages, most recent first", func(t *testing.T) {
+		svc, _, filters := setupTestServer(t)
+
+		messages, err := svc.GetAll(t.Context(), payloads.MessageFilter{})
+
+		require.NoError(t, err)
+		require.Len(t, messages, 3)
+		assert.Equal(t, payloads.AlarmMessageName, messages[0].Name)
+		assert.Equal(t, time.Unix(1700001200, 0), messages[0].Time)
+		assert.Equal(t, payloads.MessagePriorityWarning, messages[2].Priority)
+		assert.Equal(t, []string{""}, *filters)
+	})
+
+	t.Run("object, priority and time range", func(t *testing.T) {
+		svc, _, filters := setupTestServer(t)
+
+		messages, err := svc.GetAll(t.Context(), payloads.MessageFilter{
+			Object:   testHostID,
+			Priority: payloads.MessagePriorityWarning,
+			Since:    time.Unix(1700000000, 0),
+			Until:    time.Unix(1700001200, 0),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "HOST_CLOCK_SKEW_DETECTED", messages[0].Name)
+		assert.Equal(t, []string{"$object:" + testHostID.String()}, *filters)
+	})
+
+	t.Run("unknown priority is kept", func(t *testing.T) {
+		svc, _, _ := setupTestServer(t)
+
+		messages, err := svc.GetAll(t.Context(), payloads.MessageFilter{Priority: payloads.MessagePriorityCritical})
+
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, payloads.MessagePriorityUnknown, messages[0].Priority)
+	})
+}
+
+func TestGetAlarms(t *testing.T) {
+	svc, _, filters := setupTestServer(t)
+
+	alarms, err := svc.GetAlarms(t.Context(), payloads.MessageFilter{Object: testSRID})
+
+	require.NoError(t, err)
+	require.Len(t, alarms, 1)
+	assert.Equal(t, "physical_utilisation", alarms[0].Body.Name)
+	assert.Equal(t, "0.95", alarms[0].Body.Value)
+	assert.Equal(t, payloads.ResourceTypeSR, alarms[0].Object.Type)
+	assert.Equal(t, []string{"object:uuid:" + testSRID.String()}, *filters)
+}
+
+func TestDelete(t *testing.T) {
+	svc, mockJSONRPC, _ := setupTestServer(t)
+	id := uuid.Must(uuid.NewV4())
+	mockJSONRPC.EXPECT().Call("message.delete", map[string]any{"id": id.String()}, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	require.NoError(t, svc.Delete(t.Context(), id))
+}
@@ -65,6 +65,48 @@ func (s *Service) GetAll(ctx context.Context, limit int, filter string) ([]*payl
 	return result, nil
 }
 
+func (s *Service) Create(
+	ctx context.Context, hostID uuid.UUID, srID uuid.UUID, deviceConfig map[string]string) (uuid.UUID, error) {
+	if hostID == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("hostID must be set")
+	}
+	if srID == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("srID must be set")
+	}
+
+	path := core.NewPathBuilder().Resource("pbds").Build()
+	params := payloads.CreatePBDParams{
+		Host:         hostID,
+		SR:           srID,
+		DeviceConfig: deviceConfig,
+	}
+
+	// The device config may contain credentials (e.g. CIFS/iSCSI passwords), it is not logged.
+	var result payloads.CreateResponse
+	if err := client.TypedPost(ctx, s.client, path, params, &result); err != nil {
+		s.log.Error("Failed to create PBD",
+			zap.String("hostID", hostID.String()),
+			zap.String("srID", srID.String()),
+			zap.Error(err))
+		return uuid.Nil, err
+	}
+
+	return result.ID, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	path := core.NewPathBuilder().Resource("pbds").ID(id).Build()
+
+	var result struct{}
+
+	if err := client.TypedDelete(ctx, s.client, path, core.EmptyParams, &result); err != nil {
+		s.log.Error("Failed to delete PBD", zap.String("pbdID", id.String()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 func (s *Service) Plug(ctx context.Context, id uuid.UUID) (string, error) {
 	path := core.NewPathBuilder().Resource("pbds").ID(id).ActionsGroup().Action("plug").Build()
 
@@ -356,3 +356,80 @@ func TestUnplug(t *testing.T) {
 		assert.Empty(t, taskID)
 	})
 }
+
+func TestCreate(t *testing.T) {
+	hostID := uuid.Must(uuid.FromString(testHostID))
+	srID := uuid.Must(uuid.FromString(testSRID))
+	deviceConfig := map[string]string{"server": "nfs-host", "serverpath": "/export"}
+
+	t.Run("successfully creates a PBD", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/pbds", r.URL.Path)
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, testHostID, body["host"])
+			assert.Equal(t, testSRID, body["SR"])
+			assert.Equal(t, map[string]any{"server": "nfs-host", "serverpath": "/export"}, body["device_config"])
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(payloads.CreateResponse{ID: uuid.Must(uuid.FromString(testPBDID1))})
+		})
+		defer server.Close()
+
+		id, err := svc.Create(t.Context(), hostID, srID, deviceConfig)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Must(uuid.FromString(testPBDID1)), id)
+	})
+
+	t.Run("rejects missing host or SR", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("no request expected")
+		})
+		defer server.Close()
+
+		_, err := svc.Create(t.Context(), uuid.Nil, srID, deviceConfig)
+		assert.ErrorContains(t, err, "hostID must be set")
+
+		_, err = svc.Create(t.Context(), hostID, uuid.Nil, deviceConfig)
+		assert.ErrorContains(t, err, "srID must be set")
+	})
+
+	t.Run("returns error on http error", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		})
+		defer server.Close()
+
+		id, err := svc.Create(t.Context(), hostID, srID, deviceConfig)
+
+		assert.Error(t, err)
+		assert.Equal(t, uuid.Nil, id)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	pbdID := uuid.Must(uuid.FromString(testPBDID1))
+
+	t.Run("successfully deletes a PBD", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/pbds/"+testPBDID1, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		assert.NoError(t, svc.Delete(t.Context(), pbdID))
+	})
+
+	t.Run("returns error on http error", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "PBD is plugged", http.StatusBadRequest)
+		})
+		defer server.Close()
+
+		assert.Error(t, svc.Delete(t.Context(), pbdID))
+	})
+}
@@ -0,0 +1,81 @@
+package pci
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	client     *client.Client
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(client *client.Client, jsonrpcSvc library.JSONRPC, log *logger.Logger) library.PCI {
+	return &Service{
+		client:     client,
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.PCI, error) {
+	var result payloads.PCI
+	path := core.NewPathBuilder().Resource(payloads.ResourceTypePCI.Path()).ID(id).Build()
+	if err := client.TypedGet(ctx, s.client, path, core.EmptyParams, &result); err != nil {
+		s.log.Error("Failed to get PCI device by ID", zap.String("pciID", id.String()), zap.Error(err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.PCI, error) {
+	path := core.NewPathBuilder().Resource(payloads.ResourceTypePCI.Path()).Build()
+	params := map[string]any{"fields": "*"}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if filter != "" {
+		params["filter"] = filter
+	}
+
+	var result []*payloads.PCI
+	if err := client.TypedGet(ctx, s.client, path, params, &result); err != nil {
+		s.log.Error("Failed to get all PCI devices", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) GetDom0Access(_ context.Context, id uuid.UUID) (payloads.PCIDom0Access, error) {
+	var result payloads.PCIDom0Access
+	if err := s.jsonrpcSvc.Call("pci.getDom0AccessStatus", map[string]any{"id": id.String()}, &result,
+		zap.String("pciID", id.String())); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+func (s *Service) Hide(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error) {
+	return s.setDom0Access(ctx, id, "pci.disableDom0Access")
+}
+
+func (s *Service) Unhide(ctx context.Context, id uuid.UUID) (payloads.PCIDom0Access, error) {
+	return s.setDom0Access(ctx, id, "pci.enableDom0Access")
+}
+
+func (s *Service) setDom0Access(ctx context.Context, id uuid.UUID, method string) (payloads.PCIDom0Access, error) {
+	var result any
+	if err := s.jsonrpcSvc.Call(method, map[string]any{"id": id.String()}, &result,
+		zap.String("pciID", id.String())); err != nil {
+		return "", err
+	}
+	return s.GetDom0Access(ctx, id)
+}
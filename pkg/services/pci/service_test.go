@@ -0,0 +1,127 @@
+package pci
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+)
+
+var (
+	testPCIID  = uuid.Must(uuid.FromString("4c3b2a19-0f8e-4d7c-8b6a-5f4e3d2c1b01"))
+	testHostID = uuid.Must(uuid.FromString("4c3b2a19-0f8e-4d7c-8b6a-5f4e3d2c1b02"))
+)
+
+func setupTestServer(t *testing.T) (library.PCI, *mock.MockJSONRPC) {
+	t.Helper()
+	pciJSON := fmt.Sprintf(`{"id": "%s", "uuid": "%[1]s", "type": "PCI", "class_name": "3D controller",
+		"device_name": "TU104GL [Tesla T4]", "pci_id": "0000:3b:00.0", "$host": "%s"}`, testPCIID, testHostID)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v0/pcis", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "$host:"+testHostID.String(), r.URL.Query().Get("filter"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", pciJSON)
+	})
+	mux.HandleFunc("GET /rest/v0/pcis/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != testPCIID.String() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, pciJSON)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	restClient := &client.Client{
+		HttpClient: server.Client(),
+		BaseURL:    &url.URL{Scheme: "http", Host: server.URL[7:], Path: "/rest/v0"},
+		AuthToken:  "test-token",
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(restClient, mockJSONRPC, log), mockJSONRPC
+}
+
+func TestGet(t *testing.T) {
+	svc, _ := setupTestServer(t)
+
+	pci, err := svc.Get(t.Context(), testPCIID)
+	require.NoError(t, err)
+	assert.Equal(t, "0000:3b:00.0", pci.PCIID)
+	assert.Equal(t, testHostID, pci.Host)
+
+	_, err = svc.Get(t.Context(), uuid.Must(uuid.NewV4()))
+	assert.Error(t, err)
+}
+
+func TestGetAll(t *testing.T) {
+	svc, _ := setupTestServer(t)
+
+	pcis, err := svc.GetAll(t.Context(), 0, "$host:"+testHostID.String())
+
+	require.NoError(t, err)
+	require.Len(t, pcis, 1)
+	assert.Equal(t, "3D controller", pcis[0].ClassName)
+}
+
+func TestDom0Access(t *testing.T) {
+	params := map[string]any{"id": testPCIID.String()}
+
+	t.Run("get", func(t *testing.T) {
+		svc, mockJSONRPC := setupTestServer(t)
+		mockJSONRPC.EXPECT().Call("pci.getDom0AccessStatus", params, gomock.Any(), gomock.Any()).
+			SetArg(2, payloads.PCIDom0AccessEnabled).Return(nil)
+
+		access, err := svc.GetDom0Access(t.Context(), testPCIID)
+
+		require.NoError(t, err)
+		assert.False(t, access.Hidden())
+	})
+
+	t.Run("hide", func(t *testing.T) {
+		svc, mockJSONRPC := setupTestServer(t)
+		gomock.InOrder(
+			mockJSONRPC.EXPECT().Call("pci.disableDom0Access", params, gomock.Any(), gomock.Any()).Return(nil),
+			mockJSONRPC.EXPECT().Call("pci.getDom0AccessStatus", params, gomock.Any(), gomock.Any()).
+				SetArg(2, payloads.PCIDom0AccessDisableOnReboot).Return(nil),
+		)
+
+		access, err := svc.Hide(t.Context(), testPCIID)
+
+		require.NoError(t, err)
+		assert.True(t, access.Hidden())
+		assert.True(t, access.RebootRequired())
+	})
+
+	t.Run("unhide", func(t *testing.T) {
+		svc, mockJSONRPC := setupTestServer(t)
+		gomock.InOrder(
+			mockJSONRPC.EXPECT().Call("pci.enableDom0Access", params, gomock.Any(), gomock.Any()).Return(nil),
+			mockJSONRPC.EXPECT().Call("pci.getDom0AccessStatus", params, gomock.Any(), gomock.Any()).
+				SetArg(2, payloads.PCIDom0AccessEnableOnReboot).Return(nil),
+		)
+
+		access, err := svc.Unhide(t.Context(), testPCIID)
+
+		require.NoError(t, err)
+		assert.Equal(t, payloads.PCIDom0AccessEnableOnReboot, access)
+	})
+}
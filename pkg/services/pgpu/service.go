@@ -0,0 +1,93 @@
+package pgpu
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	client *client.Client
+	log    *logger.Logger
+}
+
+func New(client *client.Client, log *logger.Logger) library.PGPU {
+	return &Service{
+		client: client,
+		log:    log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.PGPU, error) {
+	var result payloads.PGPU
+	path := core.NewPathBuilder().Resource(payloads.ResourceTypePGPU.Path()).ID(id).Build()
+	if err := client.TypedGet(ctx, s.client, path, core.EmptyParams, &result); err != nil {
+		s.log.Error("Failed to get PGPU by ID", zap.String("pgpuID", id.String()), zap.Error(err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.PGPU, error) {
+	path := core.NewPathBuilder().Resource(payloads.ResourceTypePGPU.Path()).Build()
+	params := map[string]any{"fields": "*"}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if filter != "" {
+		params["filter"] = filter
+	}
+
+	var result []*payloads.PGPU
+	if err := client.TypedGet(ctx, s.client, path, params, &result); err != nil {
+		s.log.Error("Failed to get all PGPUs", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) GetCapacities(ctx context.Context, id uuid.UUID) ([]payloads.VGPUTypeCapacity, error) {
+	pgpu, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Number of vGPUs running on the GPU, by type
+	running := make(map[uuid.UUID]int)
+	if len(pgpu.VGPUs) > 0 {
+		path := core.NewPathBuilder().Resource(payloads.ResourceTypeVGPU.Path()).Build()
+		var vgpus []*payloads.VGPU
+		if err := client.TypedGet(ctx, s.client, path, map[string]any{"fields": "id,vgpuType"}, &vgpus); err != nil {
+			s.log.Error("Failed to get vGPUs", zap.String("pgpuID", id.String()), zap.Error(err))
+			return nil, err
+		}
+		for _, vgpu := range vgpus {
+			if slices.Contains(pgpu.VGPUs, vgpu.ID) {
+				running[vgpu.VGPUType]++
+			}
+		}
+	}
+
+	capacities := make([]payloads.VGPUTypeCapacity, 0, len(pgpu.SupportedVGPUTypes))
+	for _, vgpuType := range pgpu.SupportedVGPUTypes {
+		capacity := payloads.VGPUTypeCapacity{
+			VGPUType: vgpuType,
+			Enabled:  slices.Contains(pgpu.EnabledVGPUTypes, vgpuType),
+			Max:      pgpu.SupportedVGPUMaxCapacities[vgpuType],
+		}
+		// A GPU runs a single type of vGPUs at a time.
+		otherTypes := len(running) > 1 || len(running) == 1 && running[vgpuType] == 0
+		if capacity.Enabled && !otherTypes {
+			capacity.Remaining = max(capacity.Max-running[vgpuType], 0)
+		}
+		capacities = append(capacities, capacity)
+	}
+	return capacities, nil
+}
@@ -0,0 +1,113 @@
+package pgpu
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+)
+
+var (
+	testPGPUID = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d01"))
+	// Idle GPU
+	testIdlePGPUID = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d02"))
+	testT4_4Q      = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d11"))
+	testT4_8Q      = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d12"))
+	testDisabled   = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d13"))
+	testVGPU1      = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d21"))
+	testVGPU2      = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d22"))
+	testVGPU3      = uuid.Must(uuid.FromString("6e5d4c3b-2a19-4f8e-9d7c-6b5a4f3e2d23"))
+)
+
+func pgpuJSON(id uuid.UUID, vgpus string) string {
+	return fmt.Sprintf(`{"id": "%s", "type": "PGPU", "dom0Access": "disabled",
+		"supportedVgpuTypes": ["%[3]s", "%[4]s", "%[5]s"],
+		"enabledVgpuTypes": ["%[3]s", "%[4]s"],
+		"supportedVgpuMaxCapcities": {"%[3]s": 4, "%[4]s": 2, "%[5]s": 1},
+		"vgpus": %[2]s}`, id, vgpus, testT4_4Q, testT4_8Q, testDisabled)
+}
+
+func setupTestServer(t *testing.T) library.PGPU {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v0/pgpus/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PathValue("id") {
+		case testPGPUID.String():
+			fmt.Fprint(w, pgpuJSON(testPGPUID, fmt.Sprintf(`["%s", "%s"]`, testVGPU1, testVGPU2)))
+		case testIdlePGPUID.String():
+			fmt.Fprint(w, pgpuJSON(testIdlePGPUID, "[]"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("GET /rest/v0/vgpus", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// testVGPU3 runs on another GPU.
+		fmt.Fprintf(w, `[{"id": "%[1]s", "vgpuType": "%[4]s"}, {"id": "%[2]s", "vgpuType": "%[4]s"},
+			{"id": "%[3]s", "vgpuType": "%[5]s"}]`, testVGPU1, testVGPU2, testVGPU3, testT4_4Q, testT4_8Q)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	return New(&client.Client{
+		HttpClient: server.Client(),
+		BaseURL:    &url.URL{Scheme: "http", Host: server.URL[7:], Path: "/rest/v0"},
+		AuthToken:  "test-token",
+	}, log)
+}
+
+func TestGet(t *testing.T) {
+	svc := setupTestServer(t)
+
+	pgpu, err := svc.Get(t.Context(), testPGPUID)
+
+	require.NoError(t, err)
+	assert.Equal(t, payloads.PCIDom0AccessDisabled, pgpu.Dom0Access)
+	assert.Equal(t, 4, pgpu.SupportedVGPUMaxCapacities[testT4_4Q])
+	assert.Len(t, pgpu.VGPUs, 2)
+}
+
+func TestGetCapacities(t *testing.T) {
+	t.Run("busy GPU", func(t *testing.T) {
+		svc := setupTestServer(t)
+
+		capacities, err := svc.GetCapacities(t.Context(), testPGPUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []payloads.VGPUTypeCapacity{
+			{VGPUType: testT4_4Q, Enabled: true, Max: 4, Remaining: 2},
+			// The GPU runs vGPUs of another type.
+			{VGPUType: testT4_8Q, Enabled: true, Max: 2, Remaining: 0},
+			{VGPUType: testDisabled, Enabled: false, Max: 1, Remaining: 0},
+		}, capacities)
+	})
+
+	t.Run("idle GPU", func(t *testing.T) {
+		svc := setupTestServer(t)
+
+		capacities, err := svc.GetCapacities(t.Context(), testIdlePGPUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []payloads.VGPUTypeCapacity{
+			{VGPUType: testT4_4Q, Enabled: true, Max: 4, Remaining: 4},
+			{VGPUType: testT4_8Q, Enabled: true, Max: 2, Remaining: 2},
+			{VGPUType: testDisabled, Enabled: false, Max: 1, Remaining: 0},
+		}, capacities)
+	})
+}
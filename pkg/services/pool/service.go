@@ -1,10 +1,14 @@
 package pool
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 
 	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/cloudinit"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/tagger"
@@ -16,8 +20,9 @@ import (
 )
 
 type Service struct {
-	client *client.Client
-	log    *logger.Logger
+	client     *client.Client
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
 	// Needed by the actions
 	taskService library.Task
 	tagService  *tagger.Tagger
@@ -26,12 +31,14 @@ type Service struct {
 func New(
 	client *client.Client,
 	task library.Task,
+	jsonrpcSvc library.JSONRPC,
 	log *logger.Logger,
 ) library.Pool {
 	return &Service{
 		client:      client,
 		taskService: task,
 		tagService:  tagger.New(client, log, payloads.ResourceTypePool),
+		jsonrpcSvc:  jsonrpcSvc,
 		log:         log,
 	}
 }
@@ -77,6 +84,20 @@ func (s *Service) GetAll(ctx context.Context, limit int, filter string) ([]*payl
 }
 
 func (s *Service) CreateVM(ctx context.Context, poolID uuid.UUID, params payloads.CreateVMParams) (uuid.UUID, error) {
+	// Fail before creating the VM rather than leaving it unconfigured at first boot.
+	// XO renders the placeholders, e.g. "hostname: {name}%", which are not
+	// valid YAML values before rendering.
+	vars := payloads.CloudConfigVariables{Name: params.NameLabel}
+	if params.CloudConfig != nil && *params.CloudConfig != "" {
+		if err := cloudinit.ValidateUserData(cloudinit.Render(*params.CloudConfig, vars)); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	if params.NetworkConfig != nil && *params.NetworkConfig != "" {
+		if err := cloudinit.ValidateNetworkConfig(cloudinit.Render(*params.NetworkConfig, vars)); err != nil {
+			return uuid.Nil, err
+		}
+	}
 	return s.createResource(ctx, poolID, "vm", params)
 }
 
@@ -175,6 +196,67 @@ func (s *Service) RollingUpdate(ctx context.Context, poolID uuid.UUID) error {
 	return s.performPoolAction(ctx, poolID, "rolling_update")
 }
 
+// SetUEFICertificates replaces the custom Secure Boot certificates of the pool.
+// XAPI takes them as a base64 encoded tar archive of the .auth files.
+func (s *Service) SetUEFICertificates(
+	_ context.Context, poolID uuid.UUID, certificates payloads.UEFICertificates) error {
+	archive, err := uefiCertificatesArchive(certificates)
+	if err != nil {
+		return err
+	}
+
+	var result any
+	params := map[string]any{
+		"id":           poolID.String(),
+		"certificates": archive,
+	}
+	if err := s.jsonrpcSvc.Call("pool.setUefiCertificates", params, &result,
+		zap.String("poolID", poolID.String())); err != nil {
+		return err
+	}
+	s.log.Info("Pool UEFI certificates set", zap.String("poolID", poolID.String()),
+		zap.Bool("custom", archive != ""))
+	return nil
+}
+
+// uefiCertificatesArchive returns the archive of the certificates, "" when
+// there are none.
+func uefiCertificatesArchive(certificates payloads.UEFICertificates) (string, error) {
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"PK.auth", certificates.PK},
+		{"KEK.auth", certificates.KEK},
+		{"db.auth", certificates.DB},
+		{"dbx.auth", certificates.DBX},
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	empty := true
+	for _, file := range files {
+		if len(file.data) == 0 {
+			continue
+		}
+		empty = false
+		header := &tar.Header{Name: file.name, Mode: 0o644, Size: int64(len(file.data))}
+		if err := tw.WriteHeader(header); err != nil {
+			return "", fmt.Errorf("failed to archive %s: %w", file.name, err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return "", fmt.Errorf("failed to archive %s: %w", file.name, err)
+		}
+	}
+	if empty {
+		return "", nil
+	}
+	if err := tw.Close(); err != nil {
+		return "", fmt.Errorf("failed to archive the UEFI certificates: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
 // CreateNetwork
 func (s *Service) CreateNetwork(
 	ctx context.Context, poolID uuid.UUID, params payloads.CreateNetworkParams) (uuid.UUID, error) {
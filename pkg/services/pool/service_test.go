@@ -1,9 +1,13 @@
 package pool
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -39,7 +43,7 @@ func setupTestServer(t *testing.T, handler http.HandlerFunc) (library.Pool, *htt
 	ctrl := gomock.NewController(t)
 	mockTask := mock.NewMockTask(ctrl)
 
-	poolService := New(restClient, mockTask, log)
+	poolService := New(restClient, mockTask, mock.NewMockJSONRPC(ctrl), log)
 	return poolService, server
 }
 
@@ -206,6 +210,64 @@ func TestCreateResource(t *testing.T) {
 		assert.Equal(t, expectedID, gotID)
 	})
 
+	t.Run("templates are validated once rendered", func(t *testing.T) {
+		poolID := uuid.Must(uuid.NewV4())
+		expectedID := uuid.Must(uuid.NewV4())
+
+		ctrl := gomock.NewController(t)
+		mockTask := mock.NewMockTask(ctrl)
+		mockTask.EXPECT().HandleTaskResponse(gomock.Any(), payloads.TaskIDResponse{TaskID: testFakeTaskID}, true).
+			Return(&payloads.Task{
+				Status: payloads.Success,
+				Result: payloads.Result{ID: expectedID},
+			}, nil)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var vm payloads.CreateVMParams
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&vm))
+			// The template is sent unrendered, XO renders it.
+			assert.Equal(t, "#cloud-config\nhostname: {name}%\n", *vm.CloudConfig)
+			_, _ = w.Write([]byte("{\"taskId\":\"" + testFakeTaskID + "\"}"))
+		})
+		poolService, server := setupTestServer(t, handler)
+		defer server.Close()
+		s := poolService.(*Service)
+		s.taskService = mockTask
+
+		// XO's default template, and a network config using {index}.
+		cloudConfig := "#cloud-config\nhostname: {name}%\n"
+		networkConfig := "version: 1\nconfig:\n  - type: physical\n    name: eth{index}\n"
+		gotID, err := s.CreateVM(context.Background(), poolID, payloads.CreateVMParams{
+			NameLabel:     "test-vm",
+			CloudConfig:   &cloudConfig,
+			NetworkConfig: &networkConfig,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedID, gotID)
+	})
+
+	t.Run("invalid cloud configs are rejected before creation", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		})
+		poolService, server := setupTestServer(t, handler)
+		defer server.Close()
+
+		cloudConfig := "hostname: test-vm\n"
+		_, err := poolService.CreateVM(context.Background(), uuid.Must(uuid.NewV4()), payloads.CreateVMParams{
+			NameLabel:   "test-vm",
+			CloudConfig: &cloudConfig,
+		})
+		assert.ErrorContains(t, err, "invalid user data")
+
+		networkConfig := "version: 2\nethernets:\n  eth0:\n    addresses: [10.0.0.1]\n"
+		_, err = poolService.CreateVM(context.Background(), uuid.Must(uuid.NewV4()), payloads.CreateVMParams{
+			NameLabel:     "test-vm",
+			NetworkConfig: &networkConfig,
+		})
+		assert.ErrorContains(t, err, "invalid network config")
+	})
+
 	t.Run("http error", func(t *testing.T) {
 		poolID := uuid.Must(uuid.NewV4())
 
@@ -608,3 +670,55 @@ func TestPerformPoolAction(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSetUEFICertificates(t *testing.T) {
+	poolID := uuid.Must(uuid.NewV4())
+
+	setup := func(t *testing.T) (library.Pool, *mock.MockJSONRPC) {
+		ctrl := gomock.NewController(t)
+		log, _ := logger.New(false, []string{"stdout"}, []string{"stderr"})
+		mockJSONRPC := mock.NewMockJSONRPC(ctrl)
+		return New(nil, mock.NewMockTask(ctrl), mockJSONRPC, log), mockJSONRPC
+	}
+
+	t.Run("custom certificates", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		var archive string
+		mockJSONRPC.EXPECT().Call("pool.setUefiCertificates", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, params map[string]any, _ any, _ ...any) error {
+				assert.Equal(t, poolID.String(), params["id"])
+				archive = params["certificates"].(string)
+				return nil
+			})
+
+		err := svc.SetUEFICertificates(t.Context(), poolID, payloads.UEFICertificates{
+			KEK: []byte("kek"),
+			DB:  []byte("db"),
+		})
+		assert.NoError(t, err)
+
+		data, err := base64.StdEncoding.DecodeString(archive)
+		assert.NoError(t, err)
+		files := map[string]string{}
+		tr := tar.NewReader(bytes.NewReader(data))
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			content, err := io.ReadAll(tr)
+			assert.NoError(t, err)
+			files[header.Name] = string(content)
+		}
+		assert.Equal(t, map[string]string{"KEK.auth": "kek", "db.auth": "db"}, files)
+	})
+
+	t.Run("default certificates", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("pool.setUefiCertificates",
+			map[string]any{"id": poolID.String(), "certificates": ""}, gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, svc.SetUEFICertificates(t.Context(), poolID, payloads.UEFICertificates{}))
+	})
+}
@@ -0,0 +1,182 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+var remoteTypes = []payloads.RemoteType{
+	payloads.RemoteTypeLocal, payloads.RemoteTypeNFS, payloads.RemoteTypeSMB, payloads.RemoteTypeS3,
+}
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Remote {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.Remote, error) {
+	remotes, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, remote := range remotes {
+		if remote.ID == id {
+			return remote, nil
+		}
+	}
+	return nil, fmt.Errorf("remote %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.Remote, error) {
+	var result []*payloads.Remote
+	if err := s.jsonrpcSvc.Call("remote.getAll", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) Create(_ context.Context, params payloads.RemoteCreateParams) (*payloads.Remote, error) {
+	if params.Name == "" {
+		return nil, fmt.Errorf("remote name is required")
+	}
+	if err := checkURL(params.URL); err != nil {
+		return nil, err
+	}
+
+	rpcParams := map[string]any{
+		"name": params.Name,
+		"url":  params.URL,
+	}
+	if params.Options != "" {
+		rpcParams["options"] = params.Options
+	}
+	if params.Proxy != nil {
+		rpcParams["proxy"] = params.Proxy.String()
+	}
+
+	var result payloads.Remote
+	if err := s.jsonrpcSvc.Call("remote.create", rpcParams, &result, zap.String("name", params.Name)); err != nil {
+		return nil, err
+	}
+	s.log.Info("Remote created", zap.String("remoteID", result.ID.String()),
+		zap.String("url", result.RedactedURL()))
+	return &result, nil
+}
+
+func (s *Service) Update(_ context.Context, id uuid.UUID, params payloads.RemoteUpdateParams) error {
+	rpcParams := map[string]any{}
+	if params.Name != nil {
+		rpcParams["name"] = *params.Name
+	}
+	if params.URL != nil {
+		if err := checkURL(*params.URL); err != nil {
+			return err
+		}
+		rpcParams["url"] = *params.URL
+	}
+	if params.Options != nil {
+		rpcParams["options"] = *params.Options
+	}
+	if params.Proxy != nil {
+		rpcParams["proxy"] = params.Proxy.String()
+	}
+	return s.set(id, rpcParams)
+}
+
+func (s *Service) Delete(_ context.Context, id uuid.UUID) error {
+	var result any
+	return s.jsonrpcSvc.Call("remote.delete", map[string]any{"id": id.String()}, &result,
+		zap.String("remoteID", id.String()))
+}
+
+func (s *Service) Enable(_ context.Context, id uuid.UUID) error {
+	return s.set(id, map[string]any{"enabled": true})
+}
+
+func (s *Service) Disable(_ context.Context, id uuid.UUID) error {
+	return s.set(id, map[string]any{"enabled": false})
+}
+
+func (s *Service) Test(_ context.Context, id uuid.UUID) (*payloads.RemoteTestResult, error) {
+	var result struct {
+		Success   bool            `json:"success"`
+		Step      string          `json:"step"`
+		File      string          `json:"file"`
+		ReadRate  float64         `json:"readRate"`
+		WriteRate float64         `json:"writeRate"`
+		Error     json.RawMessage `json:"error"`
+	}
+	if err := s.jsonrpcSvc.Call("remote.test", map[string]any{"id": id.String()}, &result,
+		zap.String("remoteID", id.String())); err != nil {
+		return nil, err
+	}
+
+	test := &payloads.RemoteTestResult{
+		Success:   result.Success,
+		Step:      result.Step,
+		File:      result.File,
+		ReadRate:  result.ReadRate,
+		WriteRate: result.WriteRate,
+		Error:     core.XOErrorMessage(result.Error),
+	}
+	if !test.Success {
+		s.log.Warn("Remote test failed", zap.String("remoteID", id.String()),
+			zap.String("step", test.Step), zap.String("error", test.Error))
+	}
+	return test, nil
+}
+
+func (s *Service) Usage(_ context.Context, id uuid.UUID) (*payloads.RemoteUsage, error) {
+	var result map[string]struct {
+		Size int64 `json:"size"`
+		Used int64 `json:"used"`
+	}
+	if err := s.jsonrpcSvc.Call("remote.getAllInfo", map[string]any{}, &result,
+		zap.String("remoteID", id.String())); err != nil {
+		return nil, err
+	}
+
+	// XO only reports the remotes it could reach.
+	info, ok := result[id.String()]
+	if !ok {
+		return nil, fmt.Errorf("usage of remote %s is not available, it may be disabled or unreachable", id)
+	}
+	return &payloads.RemoteUsage{
+		Size:      info.Size,
+		Used:      info.Used,
+		Available: info.Size - info.Used,
+	}, nil
+}
+
+func (s *Service) set(id uuid.UUID, params map[string]any) error {
+	params["id"] = id.String()
+	var result any
+	return s.jsonrpcSvc.Call("remote.set", params, &result, zap.String("remoteID", id.String()))
+}
+
+// checkURL rejects the URLs XO can't parse before sending them, as XO's error
+// would contain the credentials.
+func checkURL(u string) error {
+	remote := payloads.Remote{URL: u}
+	for _, t := range remoteTypes {
+		if remote.Type() == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported remote URL %q", remote.RedactedURL())
+}
@@ -0,0 +1,268 @@
+package resourceset
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+	"go.uber.org/zap"
+)
+
+const templateResourcePath = "vm-templates"
+
+type Service struct {
+	// Needed by CanFit to compute the resources of the template
+	vbdService library.VBD
+	vdiService library.VDI
+
+	client     *client.Client
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(
+	client *client.Client,
+	vbd library.VBD,
+	vdi library.VDI,
+	jsonrpcSvc library.JSONRPC,
+	log *logger.Logger,
+) library.ResourceSet {
+	return &Service{
+		client:     client,
+		vbdService: vbd,
+		vdiService: vdi,
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*payloads.ResourceSet, error) {
+	sets, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range sets {
+		if set.ID == id {
+			return set, nil
+		}
+	}
+	return nil, fmt.Errorf("resource set %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.ResourceSet, error) {
+	var result []*payloads.ResourceSet
+	if err := s.jsonrpcSvc.Call("resourceSet.getAll", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) Create(
+	_ context.Context, params payloads.ResourceSetCreateParams) (*payloads.ResourceSet, error) {
+	limits := make(map[string]int64, len(params.Limits))
+	for key, quantity := range params.Limits {
+		limits[string(key)] = quantity
+	}
+	rpcParams := map[string]any{
+		"name":     params.Name,
+		"subjects": uuidStrings(params.Subjects),
+		"objects":  uuidStrings(params.Objects),
+		"limits":   limits,
+	}
+
+	var result payloads.ResourceSet
+	if err := s.jsonrpcSvc.Call("resourceSet.create", rpcParams, &result,
+		zap.String("name", params.Name)); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) Delete(_ context.Context, id string) error {
+	return s.call("resourceSet.delete", id, map[string]any{})
+}
+
+func (s *Service) AddSubject(_ context.Context, id string, subject uuid.UUID) error {
+	return s.call("resourceSet.addSubject", id, map[string]any{"subject": subject.String()})
+}
+
+func (s *Service) RemoveSubject(_ context.Context, id string, subject uuid.UUID) error {
+	return s.call("resourceSet.removeSubject", id, map[string]any{"subject": subject.String()})
+}
+
+func (s *Service) AddObject(_ context.Context, id string, object uuid.UUID) error {
+	return s.call("resourceSet.addObject", id, map[string]any{"object": object.String()})
+}
+
+func (s *Service) RemoveObject(_ context.Context, id string, object uuid.UUID) error {
+	return s.call("resourceSet.removeObject", id, map[string]any{"object": object.String()})
+}
+
+func (s *Service) SetLimit(
+	_ context.Context, id string, key payloads.ResourceSetLimitKey, quantity int64) error {
+	if quantity < 0 {
+		return fmt.Errorf("invalid %s limit %d: must not be negative", key, quantity)
+	}
+	return s.call("resourceSet.addLimit", id, map[string]any{"limitId": string(key), "quantity": quantity})
+}
+
+func (s *Service) RemoveLimit(_ context.Context, id string, key payloads.ResourceSetLimitKey) error {
+	return s.call("resourceSet.removeLimit", id, map[string]any{"limitId": string(key)})
+}
+
+func (s *Service) Usage(ctx context.Context, id string) (payloads.ResourceSetUsage, error) {
+	set, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return usage(set), nil
+}
+
+func (s *Service) CanFit(
+	ctx context.Context, id string, params payloads.CreateVMParams) (*payloads.ResourceSetFit, error) {
+	set, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	required, err := s.required(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	fit := &payloads.ResourceSetFit{Required: required}
+	for _, key := range []payloads.ResourceSetLimitKey{
+		payloads.ResourceSetLimitCPUs, payloads.ResourceSetLimitMemory, payloads.ResourceSetLimitDisk,
+	} {
+		limit, ok := set.Limits[key]
+		if ok && required[key] > limit.Available {
+			fit.Exceeded = append(fit.Exceeded, key)
+		}
+	}
+	for _, object := range usedObjects(params) {
+		if !slices.Contains(set.Objects, object) {
+			fit.MissingObjects = append(fit.MissingObjects, object)
+		}
+	}
+	fit.Fits = len(fit.Exceeded) == 0 && len(fit.MissingObjects) == 0
+
+	s.log.Debug("Predicted VM creation in resource set", zap.String("resourceSetID", id),
+		zap.Any("required", required), zap.Bool("fits", fit.Fits))
+	return fit, nil
+}
+
+// required computes the resources consumed by a VM creation, like XO does: the
+// vCPUs and maximum dynamic memory of the template, and the size of the template
+// disks, replaced or removed by the VDI parameters with the same user device,
+// and of the new disks.
+func (s *Service) required(
+	ctx context.Context, params payloads.CreateVMParams) (map[payloads.ResourceSetLimitKey]int64, error) {
+	var template payloads.VM
+	path := core.NewPathBuilder().Resource(templateResourcePath).ID(params.Template).Build()
+	if err := client.TypedGet(ctx, s.client, path, core.EmptyParams, &template); err != nil {
+		s.log.Error("Failed to get template", zap.String("templateID", params.Template.String()), zap.Error(err))
+		return nil, err
+	}
+
+	memory := template.Memory.Size
+	if len(template.Memory.Dynamic) == 2 {
+		memory = template.Memory.Dynamic[1]
+	}
+	if params.Memory != nil {
+		memory = int64(*params.Memory)
+	}
+
+	// Sizes of the template disks, by user device.
+	disks := make(map[string]int64)
+	vbds, err := s.vbdService.GetAll(ctx, 0, "VM:"+params.Template.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, vbd := range vbds {
+		if vbd.IsCDDrive || vbd.VDI == nil {
+			continue
+		}
+		vdi, err := s.vdiService.Get(ctx, *vbd.VDI)
+		if err != nil {
+			return nil, err
+		}
+		disks[strconv.Itoa(int(vbd.Position))] = vdi.Size
+	}
+
+	var disk int64
+	for _, vdi := range params.VDIs {
+		if vdi.UserDevice != nil {
+			if _, ok := disks[*vdi.UserDevice]; ok {
+				switch {
+				case vdi.Destroy != nil && *vdi.Destroy:
+					delete(disks, *vdi.UserDevice)
+				case vdi.Size != nil:
+					disks[*vdi.UserDevice] = *vdi.Size
+				}
+				continue
+			}
+		}
+		if vdi.Size != nil && (vdi.Destroy == nil || !*vdi.Destroy) {
+			disk += *vdi.Size
+		}
+	}
+	for _, size := range disks {
+		disk += size
+	}
+
+	return map[payloads.ResourceSetLimitKey]int64{
+		payloads.ResourceSetLimitCPUs:   int64(template.CPUs.Number),
+		payloads.ResourceSetLimitMemory: memory,
+		payloads.ResourceSetLimitDisk:   disk,
+	}, nil
+}
+
+// call calls a method of XO which changes a resource set. They return nothing.
+func (s *Service) call(method string, id string, params map[string]any) error {
+	params["id"] = id
+	var result any
+	return s.jsonrpcSvc.Call(method, params, &result, zap.String("resourceSetID", id))
+}
+
+func usage(set *payloads.ResourceSet) payloads.ResourceSetUsage {
+	result := make(payloads.ResourceSetUsage, len(set.Limits))
+	for key, limit := range set.Limits {
+		result[key] = payloads.ResourceSetLimitUsage{
+			Total:     limit.Total,
+			Used:      limit.Total - limit.Available,
+			Available: limit.Available,
+		}
+	}
+	return result
+}
+
+// usedObjects returns the template, SRs and networks a VM creation uses, which
+// XO requires to be objects of the resource set.
+func usedObjects(params payloads.CreateVMParams) []uuid.UUID {
+	objects := []uuid.UUID{params.Template}
+	for _, vdi := range params.VDIs {
+		if vdi.SR != nil && !slices.Contains(objects, *vdi.SR) {
+			objects = append(objects, *vdi.SR)
+		}
+	}
+	for _, vif := range params.VIFs {
+		if vif.Network != nil && !slices.Contains(objects, *vif.Network) {
+			objects = append(objects, *vif.Network)
+		}
+	}
+	return objects
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
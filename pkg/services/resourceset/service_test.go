@@ -0,0 +1,285 @@
+package resourceset
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+)
+
+const testSetID = "aYq3mJfs0rM"
+
+var (
+	testTemplateID = uuid.Must(uuid.FromString("7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0001"))
+	testSRID       = uuid.Must(uuid.FromString("7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0002"))
+	testNetworkID  = uuid.Must(uuid.FromString("7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0003"))
+	testVBDID      = uuid.Must(uuid.FromString("7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0004"))
+	testVDIID      = uuid.Must(uuid.FromString("7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0005"))
+	testUserID     = uuid.Must(uuid.FromString("7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0006"))
+)
+
+const gib = int64(1024 * 1024 * 1024)
+
+type mocks struct {
+	jsonrpc *mock.MockJSONRPC
+	vbd     *mock.MockVBD
+	vdi     *mock.MockVDI
+}
+
+func setup(t *testing.T) (library.ResourceSet, mocks) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /vm-templates/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != testTemplateID.String() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(payloads.VM{
+			ID:     testTemplateID,
+			CPUs:   payloads.CPUs{Number: 2, Max: 4},
+			Memory: payloads.Memory{Dynamic: []int64{gib, 2 * gib}, Size: gib},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	baseURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse server URL: %v", err)
+	}
+	restClient := &client.Client{
+		HttpClient: server.Client(),
+		BaseURL:    baseURL,
+		AuthToken:  "test-token",
+	}
+
+	ctrl := gomock.NewController(t)
+	m := mocks{
+		jsonrpc: mock.NewMockJSONRPC(ctrl),
+		vbd:     mock.NewMockVBD(ctrl),
+		vdi:     mock.NewMockVDI(ctrl),
+	}
+	return New(restClient, m.vbd, m.vdi, m.jsonrpc, log), m
+}
+
+func testSet(limits map[payloads.ResourceSetLimitKey]payloads.ResourceSetLimit) *payloads.ResourceSet {
+	return &payloads.ResourceSet{
+		ID:       testSetID,
+		Name:     "team",
+		Subjects: []uuid.UUID{testUserID},
+		Objects:  []uuid.UUID{testTemplateID, testSRID},
+		Limits:   limits,
+	}
+}
+
+func expectGetAll(m mocks, set *payloads.ResourceSet) {
+	m.jsonrpc.EXPECT().Call("resourceSet.getAll", map[string]any{}, gomock.Any()).
+		SetArg(2, []*payloads.ResourceSet{set}).Return(nil)
+}
+
+func TestUnmarshal(t *testing.T) {
+	var set payloads.ResourceSet
+	err := json.Unmarshal([]byte(`{
+		"id": "aYq3mJfs0rM",
+		"name": "team",
+		"subjects": ["7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0006"],
+		"objects": ["7d1c1e52-4b4a-4f0e-8d1c-3c7c5a1e0001"],
+		"limits": {
+			"cpus": {"available": 6, "total": 8},
+			"memory": {"available": 4294967296, "total": 8589934592}
+		}
+	}`), &set)
+
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{testUserID}, set.Subjects)
+	assert.Equal(t, payloads.ResourceSetLimit{Total: 8, Available: 6}, set.Limits[payloads.ResourceSetLimitCPUs])
+	assert.NotContains(t, set.Limits, payloads.ResourceSetLimitDisk)
+}
+
+func TestCreate(t *testing.T) {
+	svc, m := setup(t)
+	m.jsonrpc.EXPECT().Call("resourceSet.create", map[string]any{
+		"name":     "team",
+		"subjects": []string{testUserID.String()},
+		"objects":  []string{testTemplateID.String()},
+		"limits":   map[string]int64{"cpus": 8},
+	}, gomock.Any(), gomock.Any()).SetArg(2, *testSet(nil)).Return(nil)
+
+	set, err := svc.Create(t.Context(), payloads.ResourceSetCreateParams{
+		Name:     "team",
+		Subjects: []uuid.UUID{testUserID},
+		Objects:  []uuid.UUID{testTemplateID},
+		Limits:   map[payloads.ResourceSetLimitKey]int64{payloads.ResourceSetLimitCPUs: 8},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, testSetID, set.ID)
+}
+
+func TestMembers(t *testing.T) {
+	svc, m := setup(t)
+	// XO returns nothing when changing a resource set: the mocks leave the result unset.
+	m.jsonrpc.EXPECT().Call("resourceSet.addSubject",
+		map[string]any{"id": testSetID, "subject": testUserID.String()}, gomock.Any(), gomock.Any()).Return(nil)
+	m.jsonrpc.EXPECT().Call("resourceSet.removeObject",
+		map[string]any{"id": testSetID, "object": testTemplateID.String()}, gomock.Any(), gomock.Any()).Return(nil)
+	m.jsonrpc.EXPECT().Call("resourceSet.delete",
+		map[string]any{"id": testSetID}, gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, svc.AddSubject(t.Context(), testSetID, testUserID))
+	require.NoError(t, svc.RemoveObject(t.Context(), testSetID, testTemplateID))
+	require.NoError(t, svc.Delete(t.Context(), testSetID))
+}
+
+func TestLimits(t *testing.T) {
+	svc, m := setup(t)
+	m.jsonrpc.EXPECT().Call("resourceSet.addLimit",
+		map[string]any{"id": testSetID, "limitId": "memory", "quantity": 4 * gib},
+		gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, svc.SetLimit(t.Context(), testSetID, payloads.ResourceSetLimitMemory, 4*gib))
+	assert.Error(t, svc.SetLimit(t.Context(), testSetID, payloads.ResourceSetLimitMemory, -1))
+
+	m.jsonrpc.EXPECT().Call("resourceSet.removeLimit",
+		map[string]any{"id": testSetID, "limitId": "disk"},
+		gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, svc.RemoveLimit(t.Context(), testSetID, payloads.ResourceSetLimitDisk))
+}
+
+func TestUsage(t *testing.T) {
+	svc, m := setup(t)
+	expectGetAll(m, testSet(map[payloads.ResourceSetLimitKey]payloads.ResourceSetLimit{
+		payloads.ResourceSetLimitCPUs: {Total: 8, Available: 6},
+		payloads.ResourceSetLimitDisk: {Total: 100 * gib, Available: 100 * gib},
+	}))
+
+	usage, err := svc.Usage(t.Context(), testSetID)
+
+	require.NoError(t, err)
+	assert.Equal(t, payloads.ResourceSetUsage{
+		payloads.ResourceSetLimitCPUs: {Total: 8, Used: 2, Available: 6},
+		payloads.ResourceSetLimitDisk: {Total: 100 * gib, Used: 0, Available: 100 * gib},
+	}, usage)
+
+	expectGetAll(m, testSet(nil))
+	_, err = svc.Usage(t.Context(), "unknown")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestCanFit(t *testing.T) {
+	memory := int(3 * gib)
+	destroy := true
+	userDevice0 := "0"
+	size20 := 20 * gib
+	size5 := 5 * gib
+
+	tests := []struct {
+		name     string
+		limits   map[payloads.ResourceSetLimitKey]payloads.ResourceSetLimit
+		params   payloads.CreateVMParams
+		fits     bool
+		required map[payloads.ResourceSetLimitKey]int64
+		exceeded []payloads.ResourceSetLimitKey
+		missing  []uuid.UUID
+	}{
+		{
+			name: "template resources fit",
+			limits: map[payloads.ResourceSetLimitKey]payloads.ResourceSetLimit{
+				payloads.ResourceSetLimitCPUs:   {Total: 8, Available: 2},
+				payloads.ResourceSetLimitMemory: {Total: 8 * gib, Available: 2 * gib},
+				payloads.ResourceSetLimitDisk:   {Total: 100 * gib, Available: 10 * gib},
+			},
+			params: payloads.CreateVMParams{Template: testTemplateID},
+			fits:   true,
+			required: map[payloads.ResourceSetLimitKey]int64{
+				payloads.ResourceSetLimitCPUs:   2,
+				payloads.ResourceSetLimitMemory: 2 * gib,
+				payloads.ResourceSetLimitDisk:   10 * gib,
+			},
+		},
+		{
+			name: "resized disk, new disk and memory exceed the limits",
+			limits: map[payloads.ResourceSetLimitKey]payloads.ResourceSetLimit{
+				payloads.ResourceSetLimitMemory: {Total: 8 * gib, Available: 2 * gib},
+				payloads.ResourceSetLimitDisk:   {Total: 100 * gib, Available: 20 * gib},
+			},
+			params: payloads.CreateVMParams{
+				Template: testTemplateID,
+				Memory:   &memory,
+				VDIs: []payloads.VDIParams{
+					{UserDevice: &userDevice0, Size: &size20},
+					{Size: &size5, SR: &testSRID},
+				},
+			},
+			required: map[payloads.ResourceSetLimitKey]int64{
+				payloads.ResourceSetLimitCPUs:   2,
+				payloads.ResourceSetLimitMemory: 3 * gib,
+				payloads.ResourceSetLimitDisk:   25 * gib,
+			},
+			exceeded: []payloads.ResourceSetLimitKey{payloads.ResourceSetLimitMemory, payloads.ResourceSetLimitDisk},
+		},
+		{
+			name:   "destroyed disk and network outside of the set",
+			limits: map[payloads.ResourceSetLimitKey]payloads.ResourceSetLimit{},
+			params: payloads.CreateVMParams{
+				Template: testTemplateID,
+				VDIs:     []payloads.VDIParams{{UserDevice: &userDevice0, Destroy: &destroy}},
+				VIFs:     []payloads.VIFParams{{Network: &testNetworkID}},
+			},
+			required: map[payloads.ResourceSetLimitKey]int64{
+				payloads.ResourceSetLimitCPUs:   2,
+				payloads.ResourceSetLimitMemory: 2 * gib,
+				payloads.ResourceSetLimitDisk:   0,
+			},
+			missing: []uuid.UUID{testNetworkID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := setup(t)
+			expectGetAll(m, testSet(tt.limits))
+			m.vbd.EXPECT().GetAll(gomock.Any(), 0, "VM:"+testTemplateID.String()).Return([]*payloads.VBD{
+				{ID: testVBDID, VM: testTemplateID, VDI: &testVDIID, Position: 0},
+				{IsCDDrive: true, VM: testTemplateID, Position: 3},
+			}, nil)
+			m.vdi.EXPECT().Get(gomock.Any(), testVDIID).Return(&payloads.VDI{ID: testVDIID, Size: 10 * gib}, nil)
+
+			fit, err := svc.CanFit(t.Context(), testSetID, tt.params)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.fits, fit.Fits)
+			assert.Equal(t, tt.required, fit.Required)
+			assert.Equal(t, tt.exceeded, fit.Exceeded)
+			assert.Equal(t, tt.missing, fit.MissingObjects)
+		})
+	}
+}
+
+func TestCanFitUnknownTemplate(t *testing.T) {
+	svc, m := setup(t)
+	expectGetAll(m, testSet(nil))
+
+	_, err := svc.CanFit(t.Context(), testSetID, payloads.CreateVMParams{Template: testNetworkID})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/cron"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Schedule {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.Schedule, error) {
+	schedules, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		if schedule.ID == id {
+			return schedule, nil
+		}
+	}
+	return nil, fmt.Errorf("schedule %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.Schedule, error) {
+	var result []*payloads.Schedule
+	if err := s.jsonrpcSvc.Call("schedule.getAll", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) GetByJob(ctx context.Context, jobID uuid.UUID) ([]*payloads.Schedule, error) {
+	schedules, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []*payloads.Schedule
+	for _, schedule := range schedules {
+		if schedule.JobID == jobID {
+			result = append(result, schedule)
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) Create(_ context.Context, params payloads.ScheduleCreateParams) (*payloads.Schedule, error) {
+	if params.JobID == uuid.Nil {
+		return nil, fmt.Errorf("schedule job ID is required")
+	}
+	if err := s.Validate(params.Cron, params.Timezone); err != nil {
+		return nil, err
+	}
+
+	rpcParams := map[string]any{
+		"jobId":   params.JobID.String(),
+		"cron":    params.Cron,
+		"enabled": params.Enabled,
+	}
+	if params.Name != "" {
+		rpcParams["name"] = params.Name
+	}
+	if params.Timezone != "" {
+		rpcParams["timezone"] = params.Timezone
+	}
+
+	var result payloads.Schedule
+	if err := s.jsonrpcSvc.Call("schedule.create", rpcParams, &result,
+		zap.String("jobID", params.JobID.String())); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) Update(
+	ctx context.Context, id uuid.UUID, params payloads.ScheduleUpdateParams) (*payloads.Schedule, error) {
+	rpcParams := map[string]any{"id": id.String()}
+	if params.Cron != nil || params.Timezone != nil {
+		// The pattern and the timezone are validated together.
+		current, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		pattern, timezone := current.Cron, current.Timezone
+		if params.Cron != nil {
+			pattern = *params.Cron
+			rpcParams["cron"] = pattern
+		}
+		if params.Timezone != nil {
+			timezone = *params.Timezone
+			rpcParams["timezone"] = timezone
+		}
+		if err := s.Validate(pattern, timezone); err != nil {
+			return nil, err
+		}
+	}
+	if params.JobID != nil {
+		rpcParams["jobId"] = params.JobID.String()
+	}
+	if params.Name != nil {
+		rpcParams["name"] = *params.Name
+	}
+	if params.Enabled != nil {
+		rpcParams["enabled"] = *params.Enabled
+	}
+
+	var result any
+	if err := s.jsonrpcSvc.Call("schedule.set", rpcParams, &result, zap.String("scheduleID", id.String())); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *Service) Delete(_ context.Context, id uuid.UUID) error {
+	var result any
+	return s.jsonrpcSvc.Call("schedule.delete", map[string]any{"id": id.String()}, &result,
+		zap.String("scheduleID", id.String()))
+}
+
+func (s *Service) Validate(pattern string, timezone string) error {
+	_, err := cron.Parse(pattern, timezone)
+	return err
+}
+
+func (s *Service) NextRuns(schedule *payloads.Schedule, from time.Time, n int) ([]time.Time, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid number of runs %d", n)
+	}
+	location := from.Location()
+	if schedule.Timezone != "" {
+		var err error
+		if location, err = cron.LoadLocation(schedule.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	parsed, err := cron.ParseInLocation(schedule.Cron, location)
+	if err != nil {
+		return nil, err
+	}
+	if !schedule.Enabled {
+		return nil, nil
+	}
+
+	runs := make([]time.Time, 0, n)
+	for len(runs) < n {
+		from = parsed.Next(from)
+		if from.IsZero() {
+			break
+		}
+		runs = append(runs, from)
+	}
+	return runs, nil
+}
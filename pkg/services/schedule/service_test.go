@@ -0,0 +1,200 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+var (
+	testScheduleID = uuid.Must(uuid.FromString("5d2f1a3b-7c4e-4b8a-9f6d-1e2c3b4a5d01"))
+	testJobID      = uuid.Must(uuid.FromString("5d2f1a3b-7c4e-4b8a-9f6d-1e2c3b4a5d02"))
+	otherJobID     = uuid.Must(uuid.FromString("5d2f1a3b-7c4e-4b8a-9f6d-1e2c3b4a5d03"))
+)
+
+const schedulesJSON = `[
+	{"id": "5d2f1a3b-7c4e-4b8a-9f6d-1e2c3b4a5d01", "jobId": "5d2f1a3b-7c4e-4b8a-9f6d-1e2c3b4a5d02",
+	 "name": "nightly", "cron": "0 2 * * *", "timezone": "Europe/Paris", "enabled": true},
+	{"id": "5d2f1a3b-7c4e-4b8a-9f6d-1e2c3b4a5d04", "jobId": "5d2f1a3b-7c4e-4b8a-9f6d-1e2c3b4a5d03",
+	 "cron": "0 0 * * 0", "enabled": false}
+]`
+
+func setup(t *testing.T) (library.Schedule, *mock.MockJSONRPC) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(mockJSONRPC, log), mockJSONRPC
+}
+
+// returnJSON decodes data into the result of the call, like the JSON-RPC client does.
+func returnJSON(t *testing.T, data string) func(string, map[string]any, any, ...any) error {
+	return func(_ string, _ map[string]any, result any, _ ...any) error {
+		require.NoError(t, json.Unmarshal([]byte(data), result))
+		return nil
+	}
+}
+
+func TestGet(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, schedulesJSON)).Times(2)
+
+	schedule, err := svc.Get(t.Context(), testScheduleID)
+	require.NoError(t, err)
+	assert.Equal(t, testJobID, schedule.JobID)
+	assert.Equal(t, "0 2 * * *", schedule.Cron)
+	assert.Equal(t, "Europe/Paris", schedule.Timezone)
+	assert.True(t, schedule.Enabled)
+
+	_, err = svc.Get(t.Context(), uuid.Must(uuid.NewV4()))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestGetByJob(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, schedulesJSON))
+
+	schedules, err := svc.GetByJob(t.Context(), otherJobID)
+
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, "0 0 * * 0", schedules[0].Cron)
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("schedule.create", map[string]any{
+			"jobId":    testJobID.String(),
+			"cron":     "0 2 * * *",
+			"enabled":  true,
+			"name":     "nightly",
+			"timezone": "Europe/Paris",
+		}, gomock.Any(), gomock.Any()).
+			SetArg(2, payloads.Schedule{ID: testScheduleID, JobID: testJobID, Cron: "0 2 * * *"}).Return(nil)
+
+		schedule, err := svc.Create(t.Context(), payloads.ScheduleCreateParams{
+			JobID:    testJobID,
+			Name:     "nightly",
+			Cron:     "0 2 * * *",
+			Timezone: "Europe/Paris",
+			Enabled:  true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, testScheduleID, schedule.ID)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		_, err := svc.Create(t.Context(), payloads.ScheduleCreateParams{JobID: testJobID, Cron: "0 25 * * *"})
+
+		assert.ErrorContains(t, err, "hour: 25 out of range")
+	})
+
+	t.Run("missing job", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		_, err := svc.Create(t.Context(), payloads.ScheduleCreateParams{Cron: "0 2 * * *"})
+
+		assert.ErrorContains(t, err, "job ID is required")
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("validates with the current pattern", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+			DoAndReturn(returnJSON(t, schedulesJSON))
+
+		timezone := "Mars/Olympus"
+		_, err := svc.Update(t.Context(), testScheduleID, payloads.ScheduleUpdateParams{Timezone: &timezone})
+
+		assert.ErrorContains(t, err, `invalid timezone "Mars/Olympus"`)
+	})
+
+	t.Run("changes the given fields", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("schedule.getAll", map[string]any{}, gomock.Any()).
+			DoAndReturn(returnJSON(t, schedulesJSON)).Times(2)
+		mockJSONRPC.EXPECT().Call("schedule.set", map[string]any{
+			"id":      testScheduleID.String(),
+			"cron":    "0 3 * * *",
+			"enabled": false,
+		}, gomock.Any(), gomock.Any()).Return(nil)
+
+		pattern, enabled := "0 3 * * *", false
+		_, err := svc.Update(t.Context(), testScheduleID, payloads.ScheduleUpdateParams{
+			Cron:    &pattern,
+			Enabled: &enabled,
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("schedule.delete",
+		map[string]any{"id": testScheduleID.String()}, gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, svc.Delete(t.Context(), testScheduleID))
+}
+
+func TestNextRuns(t *testing.T) {
+	svc, _ := setup(t)
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	from := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("schedule timezone", func(t *testing.T) {
+		runs, err := svc.NextRuns(&payloads.Schedule{Cron: "0 2 * * *", Timezone: "Europe/Paris", Enabled: true}, from, 2)
+
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.True(t, runs[0].Equal(time.Date(2024, 7, 2, 2, 0, 0, 0, paris)))
+		assert.True(t, runs[1].Equal(time.Date(2024, 7, 3, 2, 0, 0, 0, paris)))
+	})
+
+	t.Run("timezone of from", func(t *testing.T) {
+		runs, err := svc.NextRuns(&payloads.Schedule{Cron: "0 15 * * *", Enabled: true}, from.In(paris), 1)
+
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.True(t, runs[0].Equal(time.Date(2024, 7, 1, 15, 0, 0, 0, paris)))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		runs, err := svc.NextRuns(&payloads.Schedule{Cron: "0 2 * * *"}, from, 3)
+
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := svc.NextRuns(&payloads.Schedule{Cron: "0 2 * *", Enabled: true}, from, 3)
+
+		assert.ErrorContains(t, err, "expected 5 or 6 fields")
+	})
+
+	t.Run("negative number of runs", func(t *testing.T) {
+		_, err := svc.NextRuns(&payloads.Schedule{Cron: "0 2 * * *", Enabled: true}, from, -1)
+
+		assert.ErrorContains(t, err, "invalid number of runs -1")
+	})
+}
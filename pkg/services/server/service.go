@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.Server {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+// rpcServer is a server as returned by XO, whose error is an object.
+type rpcServer struct {
+	payloads.Server
+	Error json.RawMessage `json:"error"`
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.Server, error) {
+	servers, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range servers {
+		if server.ID == id {
+			return server, nil
+		}
+	}
+	return nil, fmt.Errorf("server %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.Server, error) {
+	var result []*rpcServer
+	if err := s.jsonrpcSvc.Call("server.getAll", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+
+	servers := make([]*payloads.Server, len(result))
+	for i, server := range result {
+		server.Server.Error = core.XOErrorMessage(server.Error)
+		servers[i] = &server.Server
+	}
+	return servers, nil
+}
+
+func (s *Service) Create(ctx context.Context, params payloads.ServerCreateParams) (*payloads.Server, error) {
+	switch {
+	case params.Host == "":
+		return nil, fmt.Errorf("server host is required")
+	case params.Username == "":
+		return nil, fmt.Errorf("server username is required")
+	}
+
+	rpcParams := map[string]any{
+		"host":              params.Host,
+		"username":          params.Username,
+		"password":          params.Password,
+		"readOnly":          params.ReadOnly,
+		"allowUnauthorized": params.AllowUnauthorized,
+		// XO would connect in the background, Enable waits for the connection.
+		"autoConnect": false,
+	}
+	if params.Label != "" {
+		rpcParams["label"] = params.Label
+	}
+
+	var result string
+	if err := s.jsonrpcSvc.Call("server.add", rpcParams, &result, zap.String("host", params.Host)); err != nil {
+		return nil, err
+	}
+	id, err := uuid.FromString(result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server ID %q returned by XO: %w", result, err)
+	}
+	s.log.Info("Server added", zap.String("serverID", id.String()), zap.String("host", params.Host))
+
+	if !params.Disabled {
+		if err := s.Enable(ctx, id); err != nil {
+			server, getErr := s.Get(ctx, id)
+			if getErr != nil {
+				server = &payloads.Server{ID: id}
+			}
+			return server, err
+		}
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *Service) Update(
+	ctx context.Context, id uuid.UUID, params payloads.ServerUpdateParams) (*payloads.Server, error) {
+	rpcParams := map[string]any{"id": id.String()}
+	if params.Label != nil {
+		rpcParams["label"] = *params.Label
+	}
+	if params.Host != nil {
+		rpcParams["host"] = *params.Host
+	}
+	if params.Username != nil {
+		rpcParams["username"] = *params.Username
+	}
+	if params.Password != nil {
+		rpcParams["password"] = *params.Password
+	}
+	if params.ReadOnly != nil {
+		rpcParams["readOnly"] = *params.ReadOnly
+	}
+	if params.AllowUnauthorized != nil {
+		rpcParams["allowUnauthorized"] = *params.AllowUnauthorized
+	}
+
+	if err := s.call("server.set", rpcParams, id); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *Service) Delete(_ context.Context, id uuid.UUID) error {
+	return s.call("server.remove", map[string]any{"id": id.String()}, id)
+}
+
+func (s *Service) Connect(_ context.Context, id uuid.UUID) error {
+	return s.call("server.connect", map[string]any{"id": id.String()}, id)
+}
+
+func (s *Service) Disconnect(_ context.Context, id uuid.UUID) error {
+	return s.call("server.disconnect", map[string]any{"id": id.String()}, id)
+}
+
+func (s *Service) Enable(_ context.Context, id uuid.UUID) error {
+	return s.call("server.enable", map[string]any{"id": id.String()}, id)
+}
+
+func (s *Service) Disable(_ context.Context, id uuid.UUID) error {
+	return s.call("server.disable", map[string]any{"id": id.String()}, id)
+}
+
+func (s *Service) call(method string, params map[string]any, id uuid.UUID) error {
+	var result any
+	return s.jsonrpcSvc.Call(method, params, &result, zap.String("serverID", id.String()))
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+var testServerID = uuid.Must(uuid.FromString("7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c01"))
+
+const serversJSON = `[
+	{"id": "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c01", "label": "lab", "host": "192.168.1.10",
+	 "username": "root", "status": "connected", "enabled": true, "readOnly": false,
+	 "allowUnauthorized": true, "poolId": "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c02"},
+	{"id": "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c03", "label": "prod", "host": "10.0.0.1:8443",
+	 "username": "root", "status": "disconnected", "enabled": true,
+	 "error": {"code": "ECONNREFUSED", "message": "connect ECONNREFUSED 10.0.0.1:8443"}}
+]`
+
+func setup(t *testing.T) (library.Server, *mock.MockJSONRPC) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(mockJSONRPC, log), mockJSONRPC
+}
+
+// returnJSON decodes data into the result of the call, like the JSON-RPC client does.
+func returnJSON(t *testing.T, data string) func(string, map[string]any, any, ...any) error {
+	return func(_ string, _ map[string]any, result any, _ ...any) error {
+		require.NoError(t, json.Unmarshal([]byte(data), result))
+		return nil
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("server.getAll", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, serversJSON))
+
+	servers, err := svc.GetAll(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+	assert.Equal(t, payloads.ServerStatusConnected, servers[0].Status)
+	assert.True(t, servers[0].AllowUnauthorized)
+	assert.Equal(t, "7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c02", servers[0].PoolID.String())
+	assert.Empty(t, servers[0].Error)
+	assert.Equal(t, payloads.ServerStatusDisconnected, servers[1].Status)
+	assert.Equal(t, "ECONNREFUSED: connect ECONNREFUSED 10.0.0.1:8443", servers[1].Error)
+}
+
+func TestGet(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("server.getAll", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, serversJSON)).Times(2)
+
+	server, err := svc.Get(t.Context(), testServerID)
+	require.NoError(t, err)
+	assert.Equal(t, "lab", server.Label)
+
+	_, err = svc.Get(t.Context(), uuid.Must(uuid.NewV4()))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestCreate(t *testing.T) {
+	params := payloads.ServerCreateParams{
+		Label:             "lab",
+		Host:              "192.168.1.10",
+		Username:          "root",
+		Password:          "secret",
+		AllowUnauthorized: true,
+	}
+	addParams := map[string]any{
+		"label":             "lab",
+		"host":              "192.168.1.10",
+		"username":          "root",
+		"password":          "secret",
+		"readOnly":          false,
+		"allowUnauthorized": true,
+		"autoConnect":       false,
+	}
+
+	t.Run("connects", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		gomock.InOrder(
+			mockJSONRPC.EXPECT().Call("server.add", addParams, gomock.Any(), gomock.Any()).
+				SetArg(2, testServerID.String()).Return(nil),
+			mockJSONRPC.EXPECT().Call("server.enable",
+				map[string]any{"id": testServerID.String()}, gomock.Any(), gomock.Any()).Return(nil),
+			mockJSONRPC.EXPECT().Call("server.getAll", map[string]any{}, gomock.Any()).
+				DoAndReturn(returnJSON(t, serversJSON)),
+		)
+
+		server, err := svc.Create(t.Context(), params)
+
+		require.NoError(t, err)
+		assert.Equal(t, payloads.ServerStatusConnected, server.Status)
+	})
+
+	t.Run("connection failure", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("server.add", addParams, gomock.Any(), gomock.Any()).
+			SetArg(2, testServerID.String()).Return(nil)
+		mockJSONRPC.EXPECT().Call("server.enable", gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("authentication failed"))
+		mockJSONRPC.EXPECT().Call("server.getAll", map[string]any{}, gomock.Any()).
+			DoAndReturn(returnJSON(t, serversJSON))
+
+		server, err := svc.Create(t.Context(), params)
+
+		assert.ErrorContains(t, err, "authentication failed")
+		require.NotNil(t, server)
+		assert.Equal(t, testServerID, server.ID)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		disabled := params
+		disabled.Disabled = true
+		mockJSONRPC.EXPECT().Call("server.add", addParams, gomock.Any(), gomock.Any()).
+			SetArg(2, testServerID.String()).Return(nil)
+		mockJSONRPC.EXPECT().Call("server.getAll", map[string]any{}, gomock.Any()).
+			DoAndReturn(returnJSON(t, serversJSON))
+
+		_, err := svc.Create(t.Context(), disabled)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing host", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		_, err := svc.Create(t.Context(), payloads.ServerCreateParams{Username: "root"})
+
+		assert.ErrorContains(t, err, "host is required")
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	readOnly, password := true, "new"
+	mockJSONRPC.EXPECT().Call("server.set", map[string]any{
+		"id":       testServerID.String(),
+		"password": "new",
+		"readOnly": true,
+	}, gomock.Any(), gomock.Any()).Return(nil)
+	mockJSONRPC.EXPECT().Call("server.getAll", map[string]any{}, gomock.Any()).
+		DoAndReturn(returnJSON(t, serversJSON))
+
+	_, err := svc.Update(t.Context(), testServerID, payloads.ServerUpdateParams{
+		ReadOnly: &readOnly,
+		Password: &password,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestActions(t *testing.T) {
+	tests := []struct {
+		method string
+		action func(library.Server) error
+	}{
+		{"server.remove", func(svc library.Server) error { return svc.Delete(t.Context(), testServerID) }},
+		{"server.connect", func(svc library.Server) error { return svc.Connect(t.Context(), testServerID) }},
+		{"server.disconnect", func(svc library.Server) error { return svc.Disconnect(t.Context(), testServerID) }},
+		{"server.enable", func(svc library.Server) error { return svc.Enable(t.Context(), testServerID) }},
+		{"server.disable", func(svc library.Server) error { return svc.Disable(t.Context(), testServerID) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			svc, mockJSONRPC := setup(t)
+			mockJSONRPC.EXPECT().Call(tt.method,
+				map[string]any{"id": testServerID.String()}, gomock.Any(), gomock.Any()).Return(nil)
+
+			require.NoError(t, tt.action(svc))
+		})
+	}
+}
@@ -20,14 +20,37 @@ type Service struct {
 	log         *logger.Logger
 	taskService library.Task
 	tagService  *tagger.Tagger
+	// Needed by the maintenance mode, health report and PBD repair, which span several resources
+	hostService library.Host
+	pbdService  library.PBD
+	vdiService  library.VDI
+	vbdService  library.VBD
+	vmService   library.VM
+	jsonrpcSvc  library.JSONRPC
 }
 
-func New(client *client.Client, taskService library.Task, log *logger.Logger) library.SR {
+func New(
+	client *client.Client,
+	taskService library.Task,
+	hostService library.Host,
+	pbdService library.PBD,
+	vdiService library.VDI,
+	vbdService library.VBD,
+	vmService library.VM,
+	jsonrpcSvc library.JSONRPC,
+	log *logger.Logger,
+) library.SR {
 	return &Service{
 		client:      client,
 		log:         log,
 		taskService: taskService,
 		tagService:  tagger.New(client, log, payloads.ResourceTypeSR),
+		hostService: hostService,
+		pbdService:  pbdService,
+		vdiService:  vdiService,
+		vbdService:  vbdService,
+		vmService:   vmService,
+		jsonrpcSvc:  jsonrpcSvc,
 	}
 }
 
@@ -121,3 +144,301 @@ func (s *Service) Scan(ctx context.Context, id uuid.UUID) (string, error) {
 
 	return taskResult.ID, nil
 }
+
+func (s *Service) SetMaintenanceMode(ctx context.Context, id uuid.UUID, enabled bool) ([]uuid.UUID, error) {
+	var result any
+
+	if !enabled {
+		params := map[string]any{
+			"id": id.String(),
+		}
+		if err := s.jsonrpcSvc.Call("sr.disableMaintenanceMode", params, &result,
+			zap.String("srID", id.String())); err != nil {
+			return nil, err
+		}
+		return []uuid.UUID{}, nil
+	}
+
+	// XO refuses to enter maintenance mode unless every running VM using the SR is
+	// explicitly listed as a VM to shut down, so we collect them first.
+	vmIDs, err := s.runningVMs(ctx, id)
+	if err != nil {
+		s.log.Error("Failed to list running VMs on SR", zap.String("srID", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to list running VMs on SR %s: %w", id, err)
+	}
+
+	vmsToShutdown := make([]string, 0, len(vmIDs))
+	for _, vmID := range vmIDs {
+		vmsToShutdown = append(vmsToShutdown, vmID.String())
+	}
+
+	params := map[string]any{
+		"id":            id.String(),
+		"vmsToShutdown": vmsToShutdown,
+	}
+	if err := s.jsonrpcSvc.Call("sr.enableMaintenanceMode", params, &result,
+		zap.String("srID", id.String())); err != nil {
+		return nil, err
+	}
+
+	return vmIDs, nil
+}
+
+// runningVMs returns the running or paused VMs having at least one disk on the SR.
+func (s *Service) runningVMs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	vdis, err := s.vdiService.GetAll(ctx, 0, srFilter(id))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]struct{})
+	vmIDs := make([]uuid.UUID, 0)
+	for _, vdi := range vdis {
+		for _, vbdID := range vdi.VBDs {
+			vbd, err := s.vbdService.Get(ctx, vbdID)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[vbd.VM]; ok {
+				continue
+			}
+			seen[vbd.VM] = struct{}{}
+
+			vm, err := s.vmService.GetByID(ctx, vbd.VM)
+			if err != nil {
+				return nil, err
+			}
+			if vm.PowerState == payloads.PowerStateRunning || vm.PowerState == payloads.PowerStatePaused {
+				vmIDs = append(vmIDs, vm.ID)
+			}
+		}
+	}
+
+	return vmIDs, nil
+}
+
+func (s *Service) Health(ctx context.Context, id uuid.UUID) (*payloads.SRHealth, error) {
+	sr, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &payloads.SRHealth{
+		SR:                sr.ID,
+		InMaintenanceMode: sr.InMaintenanceMode,
+		PBDs:              make([]payloads.SRPBDStatus, 0, len(sr.PBDs)),
+		Size:              sr.Size,
+		PhysicalUsage:     sr.PhysicalUsage,
+		VirtualUsage:      sr.Usage,
+	}
+	if sr.Size > 0 {
+		health.PhysicalUsageRatio = sr.PhysicalUsage / sr.Size
+	}
+	if sr.PhysicalUsage > 0 {
+		health.VirtualToPhysicalRatio = sr.Usage / sr.PhysicalUsage
+	}
+
+	for _, pbdID := range sr.PBDs {
+		pbd, err := s.pbdService.Get(ctx, pbdID)
+		if err != nil {
+			s.log.Error("Failed to get PBD for SR health", zap.String("srID", id.String()),
+				zap.String("pbdID", pbdID.String()), zap.Error(err))
+			return nil, fmt.Errorf("failed to get PBD %s of SR %s: %w", pbdID, id, err)
+		}
+		health.PBDs = append(health.PBDs, payloads.SRPBDStatus{
+			PBD:      pbd.ID,
+			Host:     pbd.Host,
+			Attached: pbd.Attached,
+		})
+		if !pbd.Attached {
+			health.DetachedPBDs++
+		}
+	}
+
+	vdis, err := s.vdiService.GetAll(ctx, 0, srFilter(id))
+	if err != nil {
+		s.log.Error("Failed to get VDIs for SR health", zap.String("srID", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to get VDIs of SR %s: %w", id, err)
+	}
+	health.ChainDepths, health.MaxChainDepth, health.CoalesceBacklog = analyzeChains(vdis)
+
+	return health, nil
+}
+
+// analyzeChains computes the chain depth of every leaf VDI, the longest chain
+// and the number of parents having a single child (pending coalesce).
+// Parents that are not part of the given VDIs (e.g. unmanaged base copies)
+// still count as one level of the chain.
+func analyzeChains(vdis []*payloads.VDI) (map[uuid.UUID]int, int, int) {
+	parents := make(map[uuid.UUID]uuid.UUID, len(vdis))
+	children := make(map[uuid.UUID]int)
+	for _, vdi := range vdis {
+		if vdi.Parent != nil && *vdi.Parent != uuid.Nil {
+			parents[vdi.ID] = *vdi.Parent
+			children[*vdi.Parent]++
+		}
+	}
+
+	depths := make(map[uuid.UUID]int)
+	maxDepth := 0
+	for _, vdi := range vdis {
+		if children[vdi.ID] > 0 {
+			continue
+		}
+		depth := 1
+		visited := map[uuid.UUID]struct{}{vdi.ID: {}}
+		for current := vdi.ID; ; depth++ {
+			parent, ok := parents[current]
+			if !ok {
+				break
+			}
+			// Guard against malformed chains looping on themselves
+			if _, loop := visited[parent]; loop {
+				break
+			}
+			visited[parent] = struct{}{}
+			current = parent
+		}
+		depths[vdi.ID] = depth
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+
+	backlog := 0
+	for _, count := range children {
+		if count == 1 {
+			backlog++
+		}
+	}
+
+	return depths, maxDepth, backlog
+}
+
+func (s *Service) RepairPBDs(ctx context.Context, srID uuid.UUID) ([]payloads.PBDRepairResult, error) {
+	sr, err := s.Get(ctx, srID)
+	if err != nil {
+		return nil, err
+	}
+
+	pbdsByHost := make(map[uuid.UUID]*payloads.PBD, len(sr.PBDs))
+	hostIDs := make([]uuid.UUID, 0, len(sr.PBDs))
+	var template *payloads.PBD
+	for _, pbdID := range sr.PBDs {
+		pbd, err := s.pbdService.Get(ctx, pbdID)
+		if err != nil {
+			s.log.Error("Failed to get PBD for SR repair", zap.String("srID", srID.String()),
+				zap.String("pbdID", pbdID.String()), zap.Error(err))
+			return nil, fmt.Errorf("failed to get PBD %s of SR %s: %w", pbdID, srID, err)
+		}
+		pbdsByHost[pbd.Host] = pbd
+		hostIDs = append(hostIDs, pbd.Host)
+		// Prefer the config of a working PBD to create the missing ones
+		if template == nil || (!template.Attached && pbd.Attached) {
+			template = pbd
+		}
+	}
+
+	// A local SR can only be reached by the host it already belongs to
+	if sr.Shared {
+		hosts, err := s.hostService.GetAll(ctx, 0, fmt.Sprintf("$pool:%s", sr.Pool))
+		if err != nil {
+			s.log.Error("Failed to get hosts for SR repair", zap.String("srID", srID.String()),
+				zap.String("poolID", sr.Pool.String()), zap.Error(err))
+			return nil, fmt.Errorf("failed to get hosts of pool %s: %w", sr.Pool, err)
+		}
+		for _, host := range hosts {
+			if _, ok := pbdsByHost[host.ID]; !ok {
+				hostIDs = append(hostIDs, host.ID)
+			}
+		}
+	}
+
+	results := make([]payloads.PBDRepairResult, 0, len(hostIDs))
+	for _, hostID := range hostIDs {
+		result := payloads.PBDRepairResult{Host: hostID}
+		pbd, exists := pbdsByHost[hostID]
+
+		switch {
+		case exists && pbd.Attached:
+			result.PBD = pbd.ID
+			result.Action = payloads.PBDRepairActionNone
+		case exists:
+			result.PBD = pbd.ID
+			result.Action = payloads.PBDRepairActionPlugged
+			result.Err = s.plugPBD(ctx, pbd.ID)
+		case template == nil:
+			result.Err = fmt.Errorf("SR %s has no PBD to copy the device config from", srID)
+		default:
+			result.Action = payloads.PBDRepairActionCreated
+			result.PBD, result.Err = s.pbdService.Create(ctx, hostID, srID, template.DeviceConfig)
+			if result.Err == nil {
+				result.Err = s.plugPBD(ctx, result.PBD)
+			}
+		}
+
+		if result.Err != nil {
+			s.log.Warn("Failed to repair PBD", zap.String("srID", srID.String()),
+				zap.String("hostID", hostID.String()), zap.Error(result.Err))
+			result.Action = payloads.PBDRepairActionFailed
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// plugPBD plugs the PBD and waits for the plug task to complete.
+func (s *Service) plugPBD(ctx context.Context, id uuid.UUID) error {
+	taskID, err := s.pbdService.Plug(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	task, err := s.taskService.Wait(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if task.Status != payloads.Success {
+		return fmt.Errorf("PBD plug failed: %s", task.Result.Message)
+	}
+
+	return nil
+}
+
+func (s *Service) ListISOs(ctx context.Context, srID uuid.UUID) ([]*payloads.VDI, error) {
+	var srIDs []uuid.UUID
+	if srID == uuid.Nil {
+		srs, err := s.GetAll(ctx, 0, "content_type:"+payloads.SRContentTypeISO)
+		if err != nil {
+			return nil, err
+		}
+		for _, sr := range srs {
+			srIDs = append(srIDs, sr.ID)
+		}
+	} else {
+		sr, err := s.Get(ctx, srID)
+		if err != nil {
+			return nil, err
+		}
+		if sr.ContentType != payloads.SRContentTypeISO {
+			return nil, fmt.Errorf("SR %s is not an ISO SR (content type %q)", srID, sr.ContentType)
+		}
+		srIDs = []uuid.UUID{srID}
+	}
+
+	isos := []*payloads.VDI{}
+	for _, id := range srIDs {
+		vdis, err := s.vdiService.GetAll(ctx, 0, srFilter(id))
+		if err != nil {
+			s.log.Error("Failed to list ISOs of SR", zap.String("srID", id.String()), zap.Error(err))
+			return nil, err
+		}
+		isos = append(isos, vdis...)
+	}
+	return isos, nil
+}
+
+func srFilter(id uuid.UUID) string {
+	return fmt.Sprintf("$SR:%s", id)
+}
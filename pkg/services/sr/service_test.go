@@ -89,7 +89,7 @@ func setupTestServerWithHandler(t *testing.T, handler http.HandlerFunc) (*Servic
 	ctrl := gomock.NewController(t)
 	mockTask := mock.NewMockTask(ctrl)
 
-	return New(restClient, mockTask, log).(*Service), server, mockTask
+	return newTestService(ctrl, restClient, mockTask, log), server, mockTask
 }
 
 func setupTestServer(t *testing.T) (*httptest.Server, *Service, *mock.MockTask) {
@@ -163,7 +163,22 @@ func setupTestServer(t *testing.T) (*httptest.Server, *Service, *mock.MockTask)
 
 	ctrl := gomock.NewController(t)
 	mockTask := mock.NewMockTask(ctrl)
-	return server, New(restClient, mockTask, log).(*Service), mockTask
+	return server, newTestService(ctrl, restClient, mockTask, log), mockTask
+}
+
+func newTestService(ctrl *gomock.Controller, restClient *client.Client, mockTask *mock.MockTask,
+	log *logger.Logger) *Service {
+	return New(
+		restClient,
+		mockTask,
+		mock.NewMockHost(ctrl),
+		mock.NewMockPBD(ctrl),
+		mock.NewMockVDI(ctrl),
+		mock.NewMockVBD(ctrl),
+		mock.NewMockVM(ctrl),
+		mock.NewMockJSONRPC(ctrl),
+		log,
+	).(*Service)
 }
 
 func TestNew(t *testing.T) {
@@ -177,7 +192,8 @@ func TestNew(t *testing.T) {
 	log, _ := logger.New(true, nil, nil)
 	ctrl := gomock.NewController(t)
 	mockTask := mock.NewMockTask(ctrl)
-	svc := New(c, mockTask, log)
+	svc := New(c, mockTask, mock.NewMockHost(ctrl), mock.NewMockPBD(ctrl), mock.NewMockVDI(ctrl), mock.NewMockVBD(ctrl),
+		mock.NewMockVM(ctrl), mock.NewMockJSONRPC(ctrl), log)
 
 	assert.NotNil(t, svc)
 }
@@ -368,3 +384,350 @@ func TestScan(t *testing.T) {
 		assert.Empty(t, taskID)
 	})
 }
+
+func TestSetMaintenanceMode(t *testing.T) {
+	srID := uuid.Must(uuid.FromString(testSRID1))
+	vdiID := uuid.Must(uuid.FromString("c3d4e5f6-0000-0000-0000-000000000001"))
+	vbdID1 := uuid.Must(uuid.FromString("c3d4e5f6-0000-0000-0000-000000000011"))
+	vbdID2 := uuid.Must(uuid.FromString("c3d4e5f6-0000-0000-0000-000000000012"))
+	runningVMID := uuid.Must(uuid.FromString("c3d4e5f6-0000-0000-0000-000000000021"))
+	haltedVMID := uuid.Must(uuid.FromString("c3d4e5f6-0000-0000-0000-000000000022"))
+
+	setup := func(t *testing.T) (*Service, *mock.MockVDI, *mock.MockVBD, *mock.MockVM, *mock.MockJSONRPC) {
+		ctrl := gomock.NewController(t)
+		log, _ := logger.New(false, []string{"stdout"}, []string{"stderr"})
+		mockVDI := mock.NewMockVDI(ctrl)
+		mockVBD := mock.NewMockVBD(ctrl)
+		mockVM := mock.NewMockVM(ctrl)
+		mockJSONRPC := mock.NewMockJSONRPC(ctrl)
+		svc := New(nil, mock.NewMockTask(ctrl), mock.NewMockHost(ctrl), mock.NewMockPBD(ctrl), mockVDI, mockVBD,
+			mockVM, mockJSONRPC, log)
+		return svc.(*Service), mockVDI, mockVBD, mockVM, mockJSONRPC
+	}
+
+	t.Run("enable halts the running VMs using the SR", func(t *testing.T) {
+		svc, mockVDI, mockVBD, mockVM, mockJSONRPC := setup(t)
+
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID1).
+			Return([]*payloads.VDI{{ID: vdiID, VBDs: []uuid.UUID{vbdID1, vbdID2}}}, nil)
+		mockVBD.EXPECT().Get(gomock.Any(), vbdID1).Return(&payloads.VBD{ID: vbdID1, VM: runningVMID}, nil)
+		mockVBD.EXPECT().Get(gomock.Any(), vbdID2).Return(&payloads.VBD{ID: vbdID2, VM: haltedVMID}, nil)
+		mockVM.EXPECT().GetByID(gomock.Any(), runningVMID).
+			Return(&payloads.VM{ID: runningVMID, PowerState: payloads.PowerStateRunning}, nil)
+		mockVM.EXPECT().GetByID(gomock.Any(), haltedVMID).
+			Return(&payloads.VM{ID: haltedVMID, PowerState: payloads.PowerStateHalted}, nil)
+		mockJSONRPC.EXPECT().Call("sr.enableMaintenanceMode", map[string]any{
+			"id":            testSRID1,
+			"vmsToShutdown": []string{runningVMID.String()},
+		}, gomock.Any(), gomock.Any()).Return(nil)
+
+		halted, err := svc.SetMaintenanceMode(t.Context(), srID, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{runningVMID}, halted)
+	})
+
+	t.Run("disable does not look for VMs", func(t *testing.T) {
+		svc, _, _, _, mockJSONRPC := setup(t)
+
+		mockJSONRPC.EXPECT().Call("sr.disableMaintenanceMode", map[string]any{
+			"id": testSRID1,
+		}, gomock.Any(), gomock.Any()).Return(nil)
+
+		halted, err := svc.SetMaintenanceMode(t.Context(), srID, false)
+
+		require.NoError(t, err)
+		assert.Empty(t, halted)
+	})
+
+	t.Run("returns error when VDIs cannot be listed", func(t *testing.T) {
+		svc, mockVDI, _, _, _ := setup(t)
+
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID1).Return(nil, fmt.Errorf("boom"))
+
+		halted, err := svc.SetMaintenanceMode(t.Context(), srID, true)
+
+		assert.Error(t, err)
+		assert.Nil(t, halted)
+	})
+
+	t.Run("returns error when the JSON-RPC call fails", func(t *testing.T) {
+		svc, mockVDI, _, _, mockJSONRPC := setup(t)
+
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID1).Return([]*payloads.VDI{}, nil)
+		mockJSONRPC.EXPECT().Call("sr.enableMaintenanceMode", gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("JSON-RPC call to sr.enableMaintenanceMode failed"))
+
+		halted, err := svc.SetMaintenanceMode(t.Context(), srID, true)
+
+		assert.Error(t, err)
+		assert.Nil(t, halted)
+	})
+}
+
+func TestHealth(t *testing.T) {
+	srID := uuid.Must(uuid.FromString(testSRID1))
+	pbdID1 := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000001"))
+	pbdID2 := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000002"))
+	hostID1 := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000011"))
+	hostID2 := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000012"))
+	base := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000021"))
+	middle := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000022"))
+	leaf := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000023"))
+	standalone := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000024"))
+	unmanagedParent := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000025"))
+	orphan := uuid.Must(uuid.FromString("e5f6a7b8-0000-0000-0000-000000000026"))
+
+	setup := func(t *testing.T, sr *payloads.StorageRepository) (*Service, *mock.MockPBD, *mock.MockVDI) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(sr)
+		})
+		t.Cleanup(server.Close)
+		ctrl := gomock.NewController(t)
+		mockPBD := mock.NewMockPBD(ctrl)
+		mockVDI := mock.NewMockVDI(ctrl)
+		svc.pbdService = mockPBD
+		svc.vdiService = mockVDI
+		return svc, mockPBD, mockVDI
+	}
+
+	t.Run("aggregates PBDs, usage and chains", func(t *testing.T) {
+		sr := mockSRs()[0]
+		sr.PBDs = []uuid.UUID{pbdID1, pbdID2}
+		sr.Size = 1000
+		sr.PhysicalUsage = 250
+		sr.Usage = 500
+		svc, mockPBD, mockVDI := setup(t, sr)
+
+		mockPBD.EXPECT().Get(gomock.Any(), pbdID1).
+			Return(&payloads.PBD{ID: pbdID1, Host: hostID1, Attached: true}, nil)
+		mockPBD.EXPECT().Get(gomock.Any(), pbdID2).
+			Return(&payloads.PBD{ID: pbdID2, Host: hostID2, Attached: false}, nil)
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID1).Return([]*payloads.VDI{
+			{ID: base},
+			{ID: middle, Parent: &base},
+			{ID: leaf, Parent: &middle},
+			{ID: standalone},
+			{ID: orphan, Parent: &unmanagedParent},
+		}, nil)
+
+		health, err := svc.Health(t.Context(), srID)
+
+		require.NoError(t, err)
+		assert.Equal(t, srID, health.SR)
+		assert.Equal(t, []payloads.SRPBDStatus{
+			{PBD: pbdID1, Host: hostID1, Attached: true},
+			{PBD: pbdID2, Host: hostID2, Attached: false},
+		}, health.PBDs)
+		assert.Equal(t, 1, health.DetachedPBDs)
+		assert.InDelta(t, 0.25, health.PhysicalUsageRatio, 1e-9)
+		assert.InDelta(t, 2.0, health.VirtualToPhysicalRatio, 1e-9)
+		assert.Equal(t, map[uuid.UUID]int{leaf: 3, standalone: 1, orphan: 2}, health.ChainDepths)
+		assert.Equal(t, 3, health.MaxChainDepth)
+		assert.Equal(t, 3, health.CoalesceBacklog)
+	})
+
+	t.Run("empty SR has zero ratios", func(t *testing.T) {
+		sr := mockSRs()[0]
+		sr.Size = 0
+		sr.PhysicalUsage = 0
+		svc, _, mockVDI := setup(t, sr)
+
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID1).Return([]*payloads.VDI{}, nil)
+
+		health, err := svc.Health(t.Context(), srID)
+
+		require.NoError(t, err)
+		assert.Zero(t, health.PhysicalUsageRatio)
+		assert.Zero(t, health.VirtualToPhysicalRatio)
+		assert.Zero(t, health.MaxChainDepth)
+		assert.Empty(t, health.PBDs)
+	})
+
+	t.Run("returns error when a PBD cannot be fetched", func(t *testing.T) {
+		sr := mockSRs()[0]
+		sr.PBDs = []uuid.UUID{pbdID1}
+		svc, mockPBD, _ := setup(t, sr)
+
+		mockPBD.EXPECT().Get(gomock.Any(), pbdID1).Return(nil, fmt.Errorf("not found"))
+
+		health, err := svc.Health(t.Context(), srID)
+
+		assert.Error(t, err)
+		assert.Nil(t, health)
+	})
+}
+
+func TestRepairPBDs(t *testing.T) {
+	srID := uuid.Must(uuid.FromString(testSRID2))
+	poolID := uuid.Must(uuid.FromString(testPoolID))
+	attachedPBD := uuid.Must(uuid.FromString("f6a7b8c9-0000-0000-0000-000000000001"))
+	detachedPBD := uuid.Must(uuid.FromString("f6a7b8c9-0000-0000-0000-000000000002"))
+	createdPBD := uuid.Must(uuid.FromString("f6a7b8c9-0000-0000-0000-000000000003"))
+	hostOK := uuid.Must(uuid.FromString("f6a7b8c9-0000-0000-0000-000000000011"))
+	hostDetached := uuid.Must(uuid.FromString("f6a7b8c9-0000-0000-0000-000000000012"))
+	hostMissing := uuid.Must(uuid.FromString("f6a7b8c9-0000-0000-0000-000000000013"))
+	deviceConfig := map[string]string{"server": "nfs-host", "serverpath": "/export"}
+
+	setup := func(t *testing.T, sr *payloads.StorageRepository) (*Service, *mock.MockTask, *mock.MockHost,
+		*mock.MockPBD) {
+		svc, server, mockTask := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(sr)
+		})
+		t.Cleanup(server.Close)
+		ctrl := gomock.NewController(t)
+		mockHost := mock.NewMockHost(ctrl)
+		mockPBD := mock.NewMockPBD(ctrl)
+		svc.hostService = mockHost
+		svc.pbdService = mockPBD
+		return svc, mockTask, mockHost, mockPBD
+	}
+
+	t.Run("plugs detached PBDs and creates missing ones on a shared SR", func(t *testing.T) {
+		sr := mockSRs()[1]
+		sr.PBDs = []uuid.UUID{attachedPBD, detachedPBD}
+		svc, mockTask, mockHost, mockPBD := setup(t, sr)
+
+		mockPBD.EXPECT().Get(gomock.Any(), attachedPBD).Return(&payloads.PBD{
+			ID: attachedPBD, Host: hostOK, Attached: true, DeviceConfig: deviceConfig}, nil)
+		mockPBD.EXPECT().Get(gomock.Any(), detachedPBD).Return(&payloads.PBD{
+			ID: detachedPBD, Host: hostDetached, Attached: false}, nil)
+		mockHost.EXPECT().GetAll(gomock.Any(), 0, "$pool:"+poolID.String()).Return([]*payloads.Host{
+			{ID: hostOK}, {ID: hostDetached}, {ID: hostMissing},
+		}, nil)
+		mockPBD.EXPECT().Plug(gomock.Any(), detachedPBD).Return("task-plug-1", nil)
+		mockTask.EXPECT().Wait(gomock.Any(), "task-plug-1").Return(&payloads.Task{Status: payloads.Success}, nil)
+		mockPBD.EXPECT().Create(gomock.Any(), hostMissing, srID, deviceConfig).Return(createdPBD, nil)
+		mockPBD.EXPECT().Plug(gomock.Any(), createdPBD).Return("task-plug-2", nil)
+		mockTask.EXPECT().Wait(gomock.Any(), "task-plug-2").Return(&payloads.Task{Status: payloads.Success}, nil)
+
+		results, err := svc.RepairPBDs(t.Context(), srID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []payloads.PBDRepairResult{
+			{Host: hostOK, PBD: attachedPBD, Action: payloads.PBDRepairActionNone},
+			{Host: hostDetached, PBD: detachedPBD, Action: payloads.PBDRepairActionPlugged},
+			{Host: hostMissing, PBD: createdPBD, Action: payloads.PBDRepairActionCreated},
+		}, results)
+	})
+
+	t.Run("reports per-host failures without stopping", func(t *testing.T) {
+		sr := mockSRs()[1]
+		sr.PBDs = []uuid.UUID{detachedPBD}
+		svc, mockTask, mockHost, mockPBD := setup(t, sr)
+
+		mockPBD.EXPECT().Get(gomock.Any(), detachedPBD).Return(&payloads.PBD{
+			ID: detachedPBD, Host: hostDetached, Attached: false, DeviceConfig: deviceConfig}, nil)
+		mockHost.EXPECT().GetAll(gomock.Any(), 0, gomock.Any()).Return([]*payloads.Host{
+			{ID: hostDetached}, {ID: hostMissing},
+		}, nil)
+		mockPBD.EXPECT().Plug(gomock.Any(), detachedPBD).Return("task-plug-1", nil)
+		mockTask.EXPECT().Wait(gomock.Any(), "task-plug-1").Return(&payloads.Task{
+			Status: payloads.Failure, Result: payloads.Result{Message: "SR_BACKEND_FAILURE"}}, nil)
+		mockPBD.EXPECT().Create(gomock.Any(), hostMissing, srID, deviceConfig).Return(uuid.Nil, fmt.Errorf("denied"))
+
+		results, err := svc.RepairPBDs(t.Context(), srID)
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, payloads.PBDRepairActionFailed, results[0].Action)
+		assert.ErrorContains(t, results[0].Err, "SR_BACKEND_FAILURE")
+		assert.Equal(t, payloads.PBDRepairActionFailed, results[1].Action)
+		assert.ErrorContains(t, results[1].Err, "denied")
+	})
+
+	t.Run("only considers existing PBDs on a local SR", func(t *testing.T) {
+		sr := mockSRs()[0]
+		sr.PBDs = []uuid.UUID{attachedPBD}
+		svc, _, _, mockPBD := setup(t, sr)
+
+		mockPBD.EXPECT().Get(gomock.Any(), attachedPBD).Return(&payloads.PBD{
+			ID: attachedPBD, Host: hostOK, Attached: true}, nil)
+
+		results, err := svc.RepairPBDs(t.Context(), srID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []payloads.PBDRepairResult{
+			{Host: hostOK, PBD: attachedPBD, Action: payloads.PBDRepairActionNone},
+		}, results)
+	})
+
+	t.Run("returns error when hosts cannot be listed", func(t *testing.T) {
+		sr := mockSRs()[1]
+		svc, _, mockHost, _ := setup(t, sr)
+
+		mockHost.EXPECT().GetAll(gomock.Any(), 0, gomock.Any()).Return(nil, fmt.Errorf("boom"))
+
+		results, err := svc.RepairPBDs(t.Context(), srID)
+
+		assert.Error(t, err)
+		assert.Nil(t, results)
+	})
+}
+
+func TestListISOs(t *testing.T) {
+	isoSR := mockSRs()[0]
+	isoSR.ContentType = payloads.SRContentTypeISO
+	isoID1 := uuid.Must(uuid.FromString("a7b8c9d0-0000-0000-0000-000000000001"))
+	isoID2 := uuid.Must(uuid.FromString("a7b8c9d0-0000-0000-0000-000000000002"))
+
+	setup := func(t *testing.T, handler http.HandlerFunc) (*Service, *mock.MockVDI) {
+		svc, server, _ := setupTestServerWithHandler(t, handler)
+		t.Cleanup(server.Close)
+		mockVDI := mock.NewMockVDI(gomock.NewController(t))
+		svc.vdiService = mockVDI
+		return svc, mockVDI
+	}
+
+	t.Run("lists the ISOs of an ISO SR", func(t *testing.T) {
+		svc, mockVDI := setup(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/srs/"+testSRID1, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(isoSR)
+		})
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID1).
+			Return([]*payloads.VDI{{ID: isoID1, NameLabel: "debian.iso"}}, nil)
+
+		isos, err := svc.ListISOs(t.Context(), isoSR.ID)
+
+		require.NoError(t, err)
+		require.Len(t, isos, 1)
+		assert.Equal(t, isoID1, isos[0].ID)
+	})
+
+	t.Run("lists the ISOs of every ISO SR", func(t *testing.T) {
+		otherSR := mockSRs()[1]
+		otherSR.ContentType = payloads.SRContentTypeISO
+		svc, mockVDI := setup(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/srs", r.URL.Path)
+			assert.Equal(t, "content_type:iso", r.URL.Query().Get("filter"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]*payloads.StorageRepository{isoSR, otherSR})
+		})
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID1).
+			Return([]*payloads.VDI{{ID: isoID1}}, nil)
+		mockVDI.EXPECT().GetAll(gomock.Any(), 0, "$SR:"+testSRID2).
+			Return([]*payloads.VDI{{ID: isoID2}}, nil)
+
+		isos, err := svc.ListISOs(t.Context(), uuid.Nil)
+
+		require.NoError(t, err)
+		require.Len(t, isos, 2)
+		assert.Equal(t, isoID1, isos[0].ID)
+		assert.Equal(t, isoID2, isos[1].ID)
+	})
+
+	t.Run("rejects a non-ISO SR", func(t *testing.T) {
+		svc, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(mockSRs()[0])
+		})
+
+		isos, err := svc.ListISOs(t.Context(), isoSR.ID)
+
+		assert.ErrorContains(t, err, "is not an ISO SR")
+		assert.Nil(t, isos)
+	})
+}
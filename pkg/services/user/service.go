@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	jsonrpcSvc library.JSONRPC
+	log        *logger.Logger
+}
+
+func New(jsonrpcSvc library.JSONRPC, log *logger.Logger) library.User {
+	return &Service{
+		jsonrpcSvc: jsonrpcSvc,
+		log:        log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.User, error) {
+	users, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("user %s not found", id)
+}
+
+func (s *Service) GetAll(_ context.Context) ([]*payloads.User, error) {
+	var result []*payloads.User
+	if err := s.jsonrpcSvc.Call("user.getAll", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) GetCurrent(_ context.Context) (*payloads.User, error) {
+	var result payloads.User
+	if err := s.jsonrpcSvc.Call("session.getUser", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) Create(ctx context.Context, params payloads.UserCreateParams) (*payloads.User, error) {
+	rpcParams := map[string]any{
+		"email":    params.Email,
+		"password": params.Password,
+	}
+	if params.Permission != "" {
+		rpcParams["permission"] = params.Permission
+	}
+
+	var id uuid.UUID
+	if err := s.jsonrpcSvc.Call("user.create", rpcParams, &id, zap.String("email", params.Email)); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *Service) Update(_ context.Context, id uuid.UUID, params payloads.UserUpdateParams) error {
+	rpcParams := map[string]any{
+		"id": id.String(),
+	}
+	if params.Email != nil {
+		rpcParams["email"] = *params.Email
+	}
+	if params.Password != nil {
+		rpcParams["password"] = *params.Password
+	}
+	if params.Permission != nil {
+		rpcParams["permission"] = *params.Permission
+	}
+	if params.Preferences != nil {
+		rpcParams["preferences"] = *params.Preferences
+	}
+
+	var result any
+	return s.jsonrpcSvc.Call("user.set", rpcParams, &result, zap.String("userID", id.String()))
+}
+
+func (s *Service) Delete(_ context.Context, id uuid.UUID) error {
+	var result bool
+	logContext := zap.String("userID", id.String())
+	if err := s.jsonrpcSvc.Call("user.delete", map[string]any{"id": id.String()}, &result, logContext); err != nil {
+		return err
+	}
+	return s.jsonrpcSvc.ValidateResult(result, "user deletion", logContext)
+}
+
+func (s *Service) SetPermission(ctx context.Context, id uuid.UUID, permission payloads.UserPermission) error {
+	return s.Update(ctx, id, payloads.UserUpdateParams{Permission: &permission})
+}
+
+func (s *Service) ChangePassword(_ context.Context, oldPassword, newPassword string) error {
+	var result any
+	return s.jsonrpcSvc.Call("user.changePassword", map[string]any{
+		"oldPassword": oldPassword,
+		"newPassword": newPassword,
+	}, &result)
+}
+
+func (s *Service) AddSSHKey(ctx context.Context, id uuid.UUID, key payloads.SSHKey) error {
+	if key.Title == "" {
+		return fmt.Errorf("SSH key title is required")
+	}
+	if err := validateSSHKey(key.Key); err != nil {
+		return err
+	}
+
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, existing := range user.Preferences.SSHKeys {
+		if existing.Title == key.Title {
+			return fmt.Errorf("user %s already has an SSH key titled %q", id, key.Title)
+		}
+		if existing.Key == key.Key {
+			return fmt.Errorf("user %s already has this SSH key, titled %q", id, existing.Title)
+		}
+	}
+
+	preferences := user.Preferences
+	preferences.SSHKeys = append(slices.Clone(preferences.SSHKeys), key)
+	return s.Update(ctx, id, payloads.UserUpdateParams{Preferences: &preferences})
+}
+
+func (s *Service) RemoveSSHKey(ctx context.Context, id uuid.UUID, title string) error {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	preferences := user.Preferences
+	preferences.SSHKeys = slices.DeleteFunc(slices.Clone(preferences.SSHKeys), func(key payloads.SSHKey) bool {
+		return key.Title == title
+	})
+	if len(preferences.SSHKeys) == len(user.Preferences.SSHKeys) {
+		return fmt.Errorf("user %s has no SSH key titled %q", id, title)
+	}
+	return s.Update(ctx, id, payloads.UserUpdateParams{Preferences: &preferences})
+}
+
+// validateSSHKey checks that key looks like an OpenSSH public key:
+// "<type> <base64 blob> [comment]", the blob starting with the key type.
+func validateSSHKey(key string) error {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return fmt.Errorf("invalid SSH public key: expected \"<type> <key> [comment]\"")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return fmt.Errorf("invalid SSH public key: %w", err)
+	}
+	// The blob starts with the length-prefixed key type.
+	keyType := fields[0]
+	if len(blob) < 4+len(keyType) || string(blob[4:4+len(keyType)]) != keyType {
+		return fmt.Errorf("invalid SSH public key: the key does not match its type %q", keyType)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package user
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
+)
+
+var (
+	testUserID1 = uuid.Must(uuid.FromString("722d17b9-699b-49d2-8193-be1ac573d3de"))
+	testUserID2 = uuid.Must(uuid.FromString("722d17b9-699b-49d2-8193-be1ac573d3df"))
+)
+
+func setup(t *testing.T) (library.User, *mock.MockJSONRPC) {
+	t.Helper()
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	mockJSONRPC := mock.NewMockJSONRPC(gomock.NewController(t))
+	return New(mockJSONRPC, log), mockJSONRPC
+}
+
+// expectGetAll makes the mocked JSON-RPC service return users, decoded from
+// JSON as XO sends them.
+func expectGetAll(t *testing.T, mockJSONRPC *mock.MockJSONRPC, usersJSON string) {
+	var users []*payloads.User
+	require.NoError(t, json.Unmarshal([]byte(usersJSON), &users))
+	mockJSONRPC.EXPECT().Call("user.getAll", map[string]any{}, gomock.Any()).SetArg(2, users).Return(nil)
+}
+
+// sshKey builds a syntactically valid OpenSSH public key.
+func sshKey(keyType string) string {
+	blob := binary.BigEndian.AppendUint32(nil, uint32(len(keyType)))
+	blob = append(blob, keyType...)
+	blob = binary.BigEndian.AppendUint32(blob, 32)
+	blob = append(blob, make([]byte, 32)...)
+	return keyType + " " + base64.StdEncoding.EncodeToString(blob) + " ci@example.com"
+}
+
+func TestGet(t *testing.T) {
+	usersJSON := fmt.Sprintf(`[
+		{"id": %q, "email": "admin", "permission": "admin", "groups": []},
+		{"id": %q, "email": "ci", "permission": "none", "preferences": {"lang": "fr"}}
+	]`, testUserID1, testUserID2)
+
+	t.Run("found", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(t, mockJSONRPC, usersJSON)
+
+		user, err := svc.Get(t.Context(), testUserID2)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ci", user.Email)
+		assert.Equal(t, payloads.UserPermissionNone, user.Permission)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(t, mockJSONRPC, "[]")
+
+		user, err := svc.Get(t.Context(), testUserID1)
+
+		assert.ErrorContains(t, err, "not found")
+		assert.Nil(t, user)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	svc, mockJSONRPC := setup(t)
+	mockJSONRPC.EXPECT().Call("user.create", map[string]any{
+		"email":      "ci",
+		"password":   "secret",
+		"permission": payloads.UserPermissionRead,
+	}, gomock.Any(), gomock.Any()).SetArg(2, testUserID1).Return(nil)
+	expectGetAll(t, mockJSONRPC, fmt.Sprintf(`[{"id": %q, "email": "ci", "permission": "read"}]`, testUserID1))
+
+	user, err := svc.Create(t.Context(), payloads.UserCreateParams{
+		Email:      "ci",
+		Password:   "secret",
+		Permission: payloads.UserPermissionRead,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, testUserID1, user.ID)
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("set permission", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("user.set", map[string]any{
+			"id":         testUserID1.String(),
+			"permission": payloads.UserPermissionAdmin,
+		}, gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, svc.SetPermission(t.Context(), testUserID1, payloads.UserPermissionAdmin))
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("user.changePassword", map[string]any{
+			"oldPassword": "old",
+			"newPassword": "new",
+		}, gomock.Any()).Return(fmt.Errorf("JSON-RPC call to user.changePassword failed: wrong password"))
+
+		assert.ErrorContains(t, svc.ChangePassword(t.Context(), "old", "new"), "wrong password")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("user.delete", map[string]any{"id": testUserID1.String()}, gomock.Any(),
+			gomock.Any()).SetArg(2, true).Return(nil)
+		mockJSONRPC.EXPECT().ValidateResult(true, "user deletion", gomock.Any()).Return(nil)
+
+		assert.NoError(t, svc.Delete(t.Context(), testUserID1))
+	})
+}
+
+func TestSSHKeys(t *testing.T) {
+	existing := payloads.SSHKey{Title: "laptop", Key: sshKey("ssh-rsa")}
+	usersJSON := fmt.Sprintf(`[{"id": %q, "email": "ci", "preferences": {
+		"lang": "fr",
+		"sshKeys": [{"title": %q, "key": %q}]
+	}}]`, testUserID1, existing.Title, existing.Key)
+
+	// preferencesParam returns the JSON of the preferences sent to user.set.
+	preferencesParam := func(t *testing.T, params map[string]any) string {
+		data, err := json.Marshal(params["preferences"])
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("add keeps the other preferences", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		key := payloads.SSHKey{Title: "ci", Key: sshKey("ssh-ed25519")}
+		expectGetAll(t, mockJSONRPC, usersJSON)
+		mockJSONRPC.EXPECT().Call("user.set", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, params map[string]any, _ any, _ ...any) error {
+				assert.JSONEq(t, fmt.Sprintf(`{"lang": "fr", "sshKeys": [
+					{"title": %q, "key": %q},
+					{"title": %q, "key": %q}
+				]}`, existing.Title, existing.Key, key.Title, key.Key), preferencesParam(t, params))
+				return nil
+			})
+
+		assert.NoError(t, svc.AddSSHKey(t.Context(), testUserID1, key))
+	})
+
+	t.Run("add rejects duplicates", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(t, mockJSONRPC, usersJSON)
+
+		err := svc.AddSSHKey(t.Context(), testUserID1, payloads.SSHKey{Title: "copy", Key: existing.Key})
+
+		assert.ErrorContains(t, err, `already has this SSH key, titled "laptop"`)
+	})
+
+	t.Run("add rejects malformed keys", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		rsaBlob := strings.Fields(sshKey("ssh-rsa"))[1]
+		for _, key := range []string{"ssh-ed25519", "ssh-ed25519 not-base64!", "ssh-ed25519 " + rsaBlob} {
+			err := svc.AddSSHKey(t.Context(), testUserID1, payloads.SSHKey{Title: "bad", Key: key})
+			assert.ErrorContains(t, err, "invalid SSH public key", key)
+		}
+	})
+
+	t.Run("remove the only key", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(t, mockJSONRPC, usersJSON)
+		mockJSONRPC.EXPECT().Call("user.set", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, params map[string]any, _ any, _ ...any) error {
+				// XO only deletes the preferences sent as null.
+				assert.JSONEq(t, `{"lang": "fr", "sshKeys": null}`, preferencesParam(t, params))
+				return nil
+			})
+
+		assert.NoError(t, svc.RemoveSSHKey(t.Context(), testUserID1, "laptop"))
+	})
+
+	t.Run("remove one of the keys", func(t *testing.T) {
+		other := payloads.SSHKey{Title: "ci", Key: sshKey("ssh-ed25519")}
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(t, mockJSONRPC, fmt.Sprintf(`[{"id": %q, "email": "ci", "preferences": {
+			"sshKeys": [{"title": %q, "key": %q}, {"title": %q, "key": %q}]
+		}}]`, testUserID1, existing.Title, existing.Key, other.Title, other.Key))
+		mockJSONRPC.EXPECT().Call("user.set", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, params map[string]any, _ any, _ ...any) error {
+				assert.JSONEq(t, fmt.Sprintf(`{"sshKeys": [{"title": %q, "key": %q}]}`, other.Title, other.Key),
+					preferencesParam(t, params))
+				return nil
+			})
+
+		assert.NoError(t, svc.RemoveSSHKey(t.Context(), testUserID1, "laptop"))
+	})
+
+	t.Run("remove unknown title", func(t *testing.T) {
+		svc, mockJSONRPC := setup(t)
+		expectGetAll(t, mockJSONRPC, usersJSON)
+
+		assert.ErrorContains(t, svc.RemoveSSHKey(t.Context(), testUserID1, "desktop"), `no SSH key titled "desktop"`)
+	})
+}
@@ -2,14 +2,19 @@ package vdi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/diskimage"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/tagger"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/tasker"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/transfer"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
 	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
@@ -20,6 +25,52 @@ const (
 	vdiResourcePath = "vdis"
 )
 
+// chunkRetryDelay is multiplied by the attempt number between two attempts
+// to upload a chunk.
+var chunkRetryDelay = time.Second
+
+// conflictingOperations lists, for each operation, the in-progress operations
+// it cannot run alongside. They are checked before submitting the work so the
+// caller gets an explicit error rather than a XAPI OTHER_OPERATION_IN_PROGRESS.
+var conflictingOperations = map[payloads.VDIOperation][]payloads.VDIOperation{
+	payloads.VDIOperationResize: {
+		payloads.VDIOperationResize, payloads.VDIOperationResizeOnline, payloads.VDIOperationSnapshot,
+		payloads.VDIOperationClone, payloads.VDIOperationCopy, payloads.VDIOperationMirror,
+		payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationResizeOnline: {
+		payloads.VDIOperationResize, payloads.VDIOperationResizeOnline, payloads.VDIOperationSnapshot,
+		payloads.VDIOperationClone, payloads.VDIOperationCopy, payloads.VDIOperationMirror,
+		payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationClone: {
+		payloads.VDIOperationResize, payloads.VDIOperationResizeOnline,
+		payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationSnapshot: {
+		payloads.VDIOperationResize, payloads.VDIOperationResizeOnline,
+		payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationUpdate: {
+		payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationSetOnBoot: {
+		payloads.VDIOperationResize, payloads.VDIOperationResizeOnline, payloads.VDIOperationSnapshot,
+		payloads.VDIOperationClone, payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationEnableCBT: {
+		payloads.VDIOperationEnableCBT, payloads.VDIOperationDisableCBT,
+		payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationDisableCBT: {
+		payloads.VDIOperationEnableCBT, payloads.VDIOperationDisableCBT,
+		payloads.VDIOperationListChangedBlocks, payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+	payloads.VDIOperationListChangedBlocks: {
+		payloads.VDIOperationDisableCBT, payloads.VDIOperationDestroy, payloads.VDIOperationDataDestroy,
+	},
+}
+
 type Service struct {
 	client      *client.Client
 	log         *logger.Logger
@@ -124,11 +175,55 @@ func (s *Service) GetTasks(ctx context.Context, id uuid.UUID, limit int, filter
 }
 
 func (s *Service) Export(ctx context.Context, id uuid.UUID, format payloads.VDIFormat, fn func(io.Reader) error) error {
+	_, err := s.ExportWithOptions(ctx, id, format, fn, payloads.VDITransferOptions{})
+	return err
+}
+
+func (s *Service) ExportWithOptions(
+	ctx context.Context,
+	id uuid.UUID,
+	format payloads.VDIFormat,
+	fn func(io.Reader) error,
+	opts payloads.VDITransferOptions,
+) (*payloads.VDITransferResult, error) {
 	if format == "" {
-		return fmt.Errorf("format cannot be empty")
+		return nil, fmt.Errorf("format cannot be empty")
 	}
 	if fn == nil {
-		return fmt.Errorf("callback function cannot be nil")
+		return nil, fmt.Errorf("callback function cannot be nil")
+	}
+
+	var meter *transfer.Meter
+	err := s.export(ctx, id, format, func(content io.Reader, size int64) error {
+		meter = transfer.NewMeter(ctx, size, opts)
+		return fn(meter.Reader(content, 0))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return meter.Finish()
+}
+
+// export streams the VDI content and its size (0 if unknown) to fn. Formats
+// the XO API does not provide are converted on the fly from a raw export.
+func (s *Service) export(ctx context.Context, id uuid.UUID, format payloads.VDIFormat,
+	fn func(content io.Reader, size int64) error) error {
+	if diskimage.Convertible(format) {
+		vdi, err := s.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.export(ctx, id, payloads.VDIFormatRaw, func(raw io.Reader, _ int64) error {
+			converted, err := diskimage.FromRaw(format, raw, vdi.Size)
+			if err != nil {
+				return err
+			}
+			defer converted.Close()
+
+			return fn(converted, 0)
+		})
 	}
 
 	path := core.NewPathBuilder().Resource(vdiResourcePath).ID(id).Build()
@@ -142,35 +237,185 @@ func (s *Service) Export(ctx context.Context, id uuid.UUID, format payloads.VDIF
 	}
 	defer resp.Body.Close()
 
-	return fn(resp.Body)
+	return fn(resp.Body, max(resp.ContentLength, 0))
 }
 
 func (s *Service) Import(
 	ctx context.Context, id uuid.UUID, format payloads.VDIFormat, content io.Reader, size int64) error {
+	_, err := s.ImportWithOptions(ctx, id, format, content, size, payloads.VDITransferOptions{})
+	return err
+}
+
+func (s *Service) ImportWithOptions(
+	ctx context.Context,
+	id uuid.UUID,
+	format payloads.VDIFormat,
+	content io.Reader,
+	size int64,
+	opts payloads.VDITransferOptions,
+) (*payloads.VDITransferResult, error) {
 	if format == "" {
-		return fmt.Errorf("format cannot be empty")
+		return nil, fmt.Errorf("format cannot be empty")
 	}
 	if content == nil {
-		return fmt.Errorf("content cannot be nil")
+		return nil, fmt.Errorf("content cannot be nil")
 	}
 	if size <= 0 {
-		return fmt.Errorf("size must be greater than 0")
+		return nil, fmt.Errorf("size must be greater than 0")
+	}
+
+	if diskimage.Convertible(format) {
+		src, ok := content.(io.ReaderAt)
+		if !ok {
+			return nil, fmt.Errorf("%s import requires content implementing io.ReaderAt, such as *os.File", format)
+		}
+		img, err := diskimage.Open(format, src, size)
+		if err != nil {
+			s.log.Error("Failed to open disk image", zap.String("vdiID", id.String()),
+				zap.String("format", string(format)), zap.Error(err))
+			return nil, err
+		}
+		if content, size, err = diskimage.ToVHD(img); err != nil {
+			return nil, err
+		}
+		format = payloads.VDIFormatVHD
 	}
 
 	path := core.NewPathBuilder().Resource(vdiResourcePath).ID(id).Build()
 	endpoint := fmt.Sprintf("%s.%s", path, format)
+	meter := transfer.NewMeter(ctx, size, opts)
+
+	if opts.ChunkSize > 0 {
+		src, ok := content.(io.ReaderAt)
+		if !ok {
+			return nil, fmt.Errorf("chunked import requires content implementing io.ReaderAt, such as *os.File")
+		}
+		if err := s.importChunks(ctx, id, endpoint, src, size, opts, meter); err != nil {
+			return nil, err
+		}
+		return meter.Finish()
+	}
 
-	resp, err := client.RawPut(ctx, s.client, endpoint, content, "application/octet-stream", size)
+	resp, err := client.RawPut(ctx, s.client, endpoint, meter.Reader(content, 0), "application/octet-stream", size)
 	if err != nil {
 		s.log.Error("Failed to import VDI content", zap.String("vdiID", id.String()),
 			zap.String("format", string(format)), zap.Error(err))
-		return err
+		return nil, err
 	}
 	_ = resp.Body.Close()
 
+	return meter.Finish()
+}
+
+// importChunks uploads the content in chunks, resuming from the last byte
+// received by the server when a chunk fails. The server is not probed first:
+// any request to the import endpoint may start an import.
+func (s *Service) importChunks(
+	ctx context.Context,
+	id uuid.UUID,
+	endpoint string,
+	src io.ReaderAt,
+	size int64,
+	opts payloads.VDITransferOptions,
+	meter *transfer.Meter,
+) error {
+	var offset int64
+	for retries := 0; offset < size; {
+		if err := meter.CatchUp(src, offset); err != nil {
+			return err
+		}
+
+		length := min(opts.ChunkSize, size-offset)
+		body := meter.Reader(io.NewSectionReader(src, offset, length), offset)
+		received, err := s.uploadChunk(ctx, endpoint, body, offset, length, size)
+		if err == nil && received > offset {
+			offset, retries = received, 0
+			continue
+		}
+		if err == nil {
+			err = fmt.Errorf("server made no progress at offset %d", offset)
+		}
+
+		if ctx.Err() != nil || errors.Is(err, errUploadCompleted) || retries >= opts.MaxRetries {
+			s.log.Error("Failed to import VDI chunk", zap.String("vdiID", id.String()),
+				zap.Int64("offset", offset), zap.Int("retries", retries), zap.Error(err))
+			return fmt.Errorf("VDI import failed at offset %d: %w", offset, err)
+		}
+		retries++
+		s.log.Warn("VDI chunk upload failed, resuming", zap.String("vdiID", id.String()),
+			zap.Int64("offset", offset), zap.Int("retry", retries), zap.Error(err))
+
+		if err := sleep(ctx, time.Duration(retries)*chunkRetryDelay); err != nil {
+			return err
+		}
+		// Resume from what the server actually received, or retry the same
+		// chunk if it can't be asked.
+		received, err = s.uploadState(ctx, endpoint, size)
+		switch {
+		case errors.Is(err, errUploadCompleted):
+			return fmt.Errorf("VDI import failed at offset %d: %w", offset, err)
+		case err == nil:
+			offset = received
+		}
+	}
+
 	return nil
 }
 
+// errUploadCompleted is returned when the server completed the upload before
+// the end of the content, i.e. it does not support resumable uploads. Nothing
+// more must be sent: the next request could start another import.
+var errUploadCompleted = errors.New("server completed the upload early")
+
+// uploadState asks the server how many bytes of a resumable upload it has
+// received.
+func (s *Service) uploadState(ctx context.Context, endpoint string, size int64) (int64, error) {
+	resp, err := client.RawPutRange(ctx, s.client, endpoint, nil, "application/octet-stream", 0, 0, size)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == client.StatusResumeIncomplete:
+		return client.ReceivedBytes(resp)
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return 0, fmt.Errorf("%w, on an upload state request", errUploadCompleted)
+	default:
+		return 0, fmt.Errorf("unexpected status %s to an upload state request", resp.Status)
+	}
+}
+
+// uploadChunk sends one chunk and returns the number of bytes the server has
+// received so far.
+func (s *Service) uploadChunk(
+	ctx context.Context, endpoint string, body io.Reader, offset, length, size int64) (int64, error) {
+	resp, err := client.RawPutRange(ctx, s.client, endpoint, body, "application/octet-stream", offset, length, size)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != client.StatusResumeIncomplete {
+		if offset+length < size {
+			return 0, fmt.Errorf("%w, at offset %d of %d", errUploadCompleted, offset+length, size)
+		}
+		return size, nil
+	}
+	return client.ReceivedBytes(resp)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func (s *Service) Create(ctx context.Context, params payloads.VDICreateParams) (uuid.UUID, error) {
 	path := core.NewPathBuilder().Resource(vdiResourcePath).Build()
 
@@ -184,3 +429,253 @@ func (s *Service) Create(ctx context.Context, params payloads.VDICreateParams) (
 
 	return result.ID, nil
 }
+
+func (s *Service) Resize(ctx context.Context, id uuid.UUID, size int64, online bool) (string, error) {
+	operation := payloads.VDIOperationResize
+	if online {
+		operation = payloads.VDIOperationResizeOnline
+	}
+
+	vdi, err := s.checkOperation(ctx, id, operation)
+	if err != nil {
+		return "", err
+	}
+	if size <= vdi.Size {
+		return "", fmt.Errorf("new size %d must be greater than the current size %d of VDI %s", size, vdi.Size, id)
+	}
+
+	task, err := s.performAction(ctx, id, string(operation), map[string]any{"size": size}, false)
+	if err != nil {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+func (s *Service) Clone(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	return s.createFromAction(ctx, id, payloads.VDIOperationClone)
+}
+
+func (s *Service) Snapshot(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	return s.createFromAction(ctx, id, payloads.VDIOperationSnapshot)
+}
+
+func (s *Service) Update(ctx context.Context, id uuid.UUID, params payloads.VDIUpdateParams) error {
+	if _, err := s.checkOperation(ctx, id, payloads.VDIOperationUpdate); err != nil {
+		return err
+	}
+
+	path := core.NewPathBuilder().Resource(vdiResourcePath).ID(id).Build()
+
+	var result struct{}
+
+	if err := client.TypedPatch(ctx, s.client, path, params, &result); err != nil {
+		s.log.Error("Failed to update VDI", zap.String("vdiID", id.String()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) SetOnBoot(ctx context.Context, id uuid.UUID, mode payloads.VDIOnBoot) (string, error) {
+	if mode != payloads.VDIOnBootPersist && mode != payloads.VDIOnBootReset {
+		return "", fmt.Errorf("invalid on_boot mode %q, expected %q or %q",
+			mode, payloads.VDIOnBootPersist, payloads.VDIOnBootReset)
+	}
+
+	if _, err := s.checkOperation(ctx, id, payloads.VDIOperationSetOnBoot); err != nil {
+		return "", err
+	}
+
+	task, err := s.performAction(ctx, id, string(payloads.VDIOperationSetOnBoot),
+		map[string]any{"mode": mode}, false)
+	if err != nil {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+func (s *Service) EnableCBT(ctx context.Context, id uuid.UUID) (string, error) {
+	return s.setCBT(ctx, id, payloads.VDIOperationEnableCBT)
+}
+
+func (s *Service) DisableCBT(ctx context.Context, id uuid.UUID) (string, error) {
+	return s.setCBT(ctx, id, payloads.VDIOperationDisableCBT)
+}
+
+func (s *Service) setCBT(ctx context.Context, id uuid.UUID, operation payloads.VDIOperation) (string, error) {
+	if _, err := s.checkOperation(ctx, id, operation); err != nil {
+		return "", err
+	}
+
+	task, err := s.performAction(ctx, id, string(operation), core.EmptyParams, false)
+	if err != nil {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+func (s *Service) ListChangedBlocks(
+	ctx context.Context, baseSnapshotID, currentSnapshotID uuid.UUID) (*payloads.ChangedBlocks, error) {
+	_, blocks, err := s.changedBlocks(ctx, baseSnapshotID, currentSnapshotID)
+	return blocks, err
+}
+
+// changedBlocks returns the current snapshot with its changed blocks.
+func (s *Service) changedBlocks(ctx context.Context,
+	baseSnapshotID, currentSnapshotID uuid.UUID) (*payloads.VDI, *payloads.ChangedBlocks, error) {
+	if baseSnapshotID == uuid.Nil || currentSnapshotID == uuid.Nil {
+		return nil, nil, fmt.Errorf("base and current snapshot IDs cannot be empty")
+	}
+	if baseSnapshotID == currentSnapshotID {
+		return nil, nil, fmt.Errorf("base and current snapshot IDs must be different")
+	}
+
+	current, err := s.checkOperation(ctx, currentSnapshotID, payloads.VDIOperationListChangedBlocks)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current.CBTEnabled != nil && !*current.CBTEnabled {
+		return nil, nil, fmt.Errorf("changed block tracking is not enabled on VDI %s", currentSnapshotID)
+	}
+
+	path := core.NewPathBuilder().Resource(vdiResourcePath).ID(currentSnapshotID).Resource("changed_blocks").Build()
+	params := map[string]any{"base": baseSnapshotID.String()}
+
+	var bitmap string
+	if err := client.TypedGet(ctx, s.client, path, params, &bitmap); err != nil {
+		s.log.Error("Failed to list VDI changed blocks",
+			zap.String("baseSnapshotID", baseSnapshotID.String()),
+			zap.String("currentSnapshotID", currentSnapshotID.String()),
+			zap.Error(err))
+		return nil, nil, err
+	}
+
+	blocks, err := payloads.DecodeChangedBlocks(bitmap)
+	if err != nil {
+		return nil, nil, err
+	}
+	return current, blocks, nil
+}
+
+func (s *Service) ExportChangedBlocks(
+	ctx context.Context,
+	baseSnapshotID, currentSnapshotID uuid.UUID,
+	fn func(extent payloads.BlockExtent, data io.Reader) error,
+) error {
+	if fn == nil {
+		return fmt.Errorf("callback function cannot be nil")
+	}
+
+	current, blocks, err := s.changedBlocks(ctx, baseSnapshotID, currentSnapshotID)
+	if err != nil {
+		return err
+	}
+
+	path := core.NewPathBuilder().Resource(vdiResourcePath).ID(currentSnapshotID).Build()
+	endpoint := fmt.Sprintf("%s.%s", path, payloads.VDIFormatRaw)
+
+	for _, extent := range blocks.Extents() {
+		// The last block of the bitmap can run past the end of the disk.
+		if current.Size > 0 {
+			if extent.Offset >= current.Size {
+				break
+			}
+			extent.Length = min(extent.Length, current.Size-extent.Offset)
+		}
+		if err := s.exportExtent(ctx, endpoint, extent, fn); err != nil {
+			s.log.Error("Failed to export VDI changed blocks",
+				zap.String("currentSnapshotID", currentSnapshotID.String()),
+				zap.Int64("offset", extent.Offset),
+				zap.Int64("length", extent.Length),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) exportExtent(
+	ctx context.Context,
+	endpoint string,
+	extent payloads.BlockExtent,
+	fn func(extent payloads.BlockExtent, data io.Reader) error,
+) error {
+	resp, err := client.RawGetRange(ctx, s.client, endpoint, extent.Offset, extent.Length)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return fn(extent, io.LimitReader(resp.Body, extent.Length))
+}
+
+// checkOperation fetches the VDI and refuses the operation if a conflicting
+// one is already in progress on it.
+func (s *Service) checkOperation(
+	ctx context.Context, id uuid.UUID, operation payloads.VDIOperation) (*payloads.VDI, error) {
+	vdi, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, current := range vdi.CurrentOperations {
+		if slices.Contains(conflictingOperations[operation], current) {
+			s.log.Warn("Refusing VDI operation, conflicting operation in progress",
+				zap.String("vdiID", id.String()),
+				zap.String("operation", string(operation)),
+				zap.String("currentOperation", string(current)))
+			return nil, fmt.Errorf("cannot %s VDI %s: %s operation in progress", operation, id, current)
+		}
+	}
+
+	return vdi, nil
+}
+
+// createFromAction runs an action producing a new VDI, waits for its task
+// and returns the ID of the new VDI.
+func (s *Service) createFromAction(
+	ctx context.Context, id uuid.UUID, operation payloads.VDIOperation) (uuid.UUID, error) {
+	if _, err := s.checkOperation(ctx, id, operation); err != nil {
+		return uuid.Nil, err
+	}
+
+	task, err := s.performAction(ctx, id, string(operation), core.EmptyParams, true)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if task.Status != payloads.Success {
+		s.log.Error("Task failed",
+			zap.String("status", string(task.Status)),
+			zap.String("message", task.Result.Message),
+			zap.String("stack", task.Result.Stack))
+		return uuid.Nil, fmt.Errorf("VDI %s failed: %s", operation, task.Result.Message)
+	}
+	if task.Result.ID == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("failed to retrieve new VDI ID from %s task result", operation)
+	}
+
+	return task.Result.ID, nil
+}
+
+func (s *Service) performAction(
+	ctx context.Context, id uuid.UUID, action string, params any, waitForCompletion bool) (*payloads.Task, error) {
+	path := core.NewPathBuilder().Resource(vdiResourcePath).ID(id).ActionsGroup().Action(action).Build()
+
+	var result payloads.TaskIDResponse
+
+	err := client.TypedPost(ctx, s.client, path, params, &result)
+	if err != nil {
+		s.log.Error(fmt.Sprintf("failed to %s VDI", action), zap.String("vdiID", id.String()), zap.Error(err))
+		return nil, err
+	}
+
+	task, err := s.taskService.HandleTaskResponse(ctx, result, waitForCompletion)
+	if err != nil {
+		s.log.Error("Task handling failed", zap.Error(err))
+		return nil, fmt.Errorf("VDI %s failed: %w", action, err)
+	}
+
+	return task, nil
+}
@@ -1,7 +1,11 @@
 package vdi
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,11 +15,13 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/diskimage"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/config"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
 	mock "github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library/mock"
@@ -680,3 +686,640 @@ func TestCreate(t *testing.T) {
 		assert.Empty(t, vdiID)
 	})
 }
+
+// setupActionTestServer serves the given VDI and forwards action and PATCH
+// requests to the given handler.
+func setupActionTestServer(t *testing.T, vdi *payloads.VDI,
+	handler http.HandlerFunc) (*Service, *httptest.Server, *mock.MockTask) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /vdis/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(vdi)
+	})
+	mux.HandleFunc("POST /vdis/{id}/actions/{action}", handler)
+	mux.HandleFunc("PATCH /vdis/{id}", handler)
+	return setupTestServerWithHandler(t, mux.ServeHTTP)
+}
+
+func TestResize(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID2))
+	taskResponse := payloads.TaskIDResponse{TaskID: "task-resize"}
+
+	t.Run("offline resize", func(t *testing.T) {
+		svc, server, mockTask := setupActionTestServer(t, mockVDIs()[1], func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "resize", r.PathValue("action"))
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, float64(2*testVDIVirtualSize), body["size"])
+			_ = json.NewEncoder(w).Encode(taskResponse)
+		})
+		defer server.Close()
+
+		mockTask.EXPECT().HandleTaskResponse(gomock.Any(), taskResponse, false).
+			Return(&payloads.Task{ID: "task-resize"}, nil)
+
+		taskID, err := svc.Resize(t.Context(), vdiID, 2*testVDIVirtualSize, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "task-resize", taskID)
+	})
+
+	t.Run("online resize", func(t *testing.T) {
+		svc, server, mockTask := setupActionTestServer(t, mockVDIs()[1], func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "resize_online", r.PathValue("action"))
+			_ = json.NewEncoder(w).Encode(taskResponse)
+		})
+		defer server.Close()
+
+		mockTask.EXPECT().HandleTaskResponse(gomock.Any(), taskResponse, false).
+			Return(&payloads.Task{ID: "task-resize"}, nil)
+
+		taskID, err := svc.Resize(t.Context(), vdiID, 2*testVDIVirtualSize, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "task-resize", taskID)
+	})
+
+	t.Run("refuses to shrink", func(t *testing.T) {
+		svc, server, _ := setupActionTestServer(t, mockVDIs()[1], func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("no action expected")
+		})
+		defer server.Close()
+
+		_, err := svc.Resize(t.Context(), vdiID, testVDIVirtualSize/2, false)
+
+		assert.ErrorContains(t, err, "must be greater than the current size")
+	})
+
+	t.Run("refuses while a conflicting operation is in progress", func(t *testing.T) {
+		vdi := mockVDIs()[1]
+		vdi.CurrentOperations = map[string]payloads.VDIOperation{"OpaqueRef:1": payloads.VDIOperationSnapshot}
+		svc, server, _ := setupActionTestServer(t, vdi, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("no action expected")
+		})
+		defer server.Close()
+
+		_, err := svc.Resize(t.Context(), vdiID, 2*testVDIVirtualSize, false)
+
+		assert.ErrorContains(t, err, "snapshot operation in progress")
+	})
+}
+
+func TestCloneAndSnapshot(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID1))
+	newVDIID := uuid.Must(uuid.FromString(testVDIID2))
+
+	for _, action := range []string{"clone", "snapshot"} {
+		run := func(svc *Service) (uuid.UUID, error) {
+			if action == "clone" {
+				return svc.Clone(t.Context(), vdiID)
+			}
+			return svc.Snapshot(t.Context(), vdiID)
+		}
+		taskResponse := payloads.TaskIDResponse{TaskID: "task-" + action}
+
+		t.Run(action+" returns the new VDI ID", func(t *testing.T) {
+			svc, server, mockTask := setupActionTestServer(t, mockVDIs()[0], func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, action, r.PathValue("action"))
+				_ = json.NewEncoder(w).Encode(taskResponse)
+			})
+			defer server.Close()
+
+			mockTask.EXPECT().HandleTaskResponse(gomock.Any(), taskResponse, true).
+				Return(&payloads.Task{Status: payloads.Success, Result: payloads.Result{ID: newVDIID}}, nil)
+
+			id, err := run(svc)
+
+			assert.NoError(t, err)
+			assert.Equal(t, newVDIID, id)
+		})
+
+		t.Run(action+" returns error when the task fails", func(t *testing.T) {
+			svc, server, mockTask := setupActionTestServer(t, mockVDIs()[0], func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(taskResponse)
+			})
+			defer server.Close()
+
+			mockTask.EXPECT().HandleTaskResponse(gomock.Any(), taskResponse, true).
+				Return(&payloads.Task{Status: payloads.Failure, Result: payloads.Result{Message: "SR_FULL"}}, nil)
+
+			id, err := run(svc)
+
+			assert.ErrorContains(t, err, "SR_FULL")
+			assert.Equal(t, uuid.Nil, id)
+		})
+
+		t.Run(action+" refuses while the VDI is being destroyed", func(t *testing.T) {
+			vdi := mockVDIs()[0]
+			vdi.CurrentOperations = map[string]payloads.VDIOperation{"OpaqueRef:1": payloads.VDIOperationDestroy}
+			svc, server, _ := setupActionTestServer(t, vdi, func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("no action expected")
+			})
+			defer server.Close()
+
+			_, err := run(svc)
+
+			assert.ErrorContains(t, err, "destroy operation in progress")
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID1))
+	name := "renamed"
+	readOnly := true
+
+	t.Run("sends only the set fields", func(t *testing.T) {
+		svc, server, _ := setupActionTestServer(t, mockVDIs()[0], func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"name_label":   name,
+				"read_only":    true,
+				"other_config": map[string]any{"owner": "ci"},
+			}, body)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		err := svc.Update(t.Context(), vdiID, payloads.VDIUpdateParams{
+			NameLabel:   &name,
+			ReadOnly:    &readOnly,
+			OtherConfig: map[string]string{"owner": "ci"},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns error on http error", func(t *testing.T) {
+		svc, server, _ := setupActionTestServer(t, mockVDIs()[0], func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		})
+		defer server.Close()
+
+		assert.Error(t, svc.Update(t.Context(), vdiID, payloads.VDIUpdateParams{NameLabel: &name}))
+	})
+}
+
+func TestSetOnBoot(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID1))
+	taskResponse := payloads.TaskIDResponse{TaskID: "task-on-boot"}
+
+	t.Run("sets the on boot mode", func(t *testing.T) {
+		svc, server, mockTask := setupActionTestServer(t, mockVDIs()[0], func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "set_on_boot", r.PathValue("action"))
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "reset", body["mode"])
+			_ = json.NewEncoder(w).Encode(taskResponse)
+		})
+		defer server.Close()
+
+		mockTask.EXPECT().HandleTaskResponse(gomock.Any(), taskResponse, false).
+			Return(&payloads.Task{ID: "task-on-boot"}, nil)
+
+		taskID, err := svc.SetOnBoot(t.Context(), vdiID, payloads.VDIOnBootReset)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "task-on-boot", taskID)
+	})
+
+	t.Run("rejects an unknown mode", func(t *testing.T) {
+		svc, server, _ := setupActionTestServer(t, mockVDIs()[0], func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("no request expected")
+		})
+		defer server.Close()
+
+		_, err := svc.SetOnBoot(t.Context(), vdiID, "discard")
+
+		assert.ErrorContains(t, err, "invalid on_boot mode")
+	})
+}
+
+func TestEnableDisableCBT(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID1))
+
+	for _, operation := range []payloads.VDIOperation{payloads.VDIOperationEnableCBT, payloads.VDIOperationDisableCBT} {
+		t.Run(string(operation), func(t *testing.T) {
+			taskResponse := payloads.TaskIDResponse{TaskID: "task-" + string(operation)}
+			svc, server, mockTask := setupActionTestServer(t, mockVDIs()[0], func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, string(operation), r.PathValue("action"))
+				_ = json.NewEncoder(w).Encode(taskResponse)
+			})
+			defer server.Close()
+
+			mockTask.EXPECT().HandleTaskResponse(gomock.Any(), taskResponse, false).
+				Return(&payloads.Task{ID: taskResponse.TaskID}, nil)
+
+			var taskID string
+			var err error
+			if operation == payloads.VDIOperationEnableCBT {
+				taskID, err = svc.EnableCBT(t.Context(), vdiID)
+			} else {
+				taskID, err = svc.DisableCBT(t.Context(), vdiID)
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, taskResponse.TaskID, taskID)
+		})
+	}
+
+	t.Run("refused while CBT is being disabled", func(t *testing.T) {
+		vdi := mockVDIs()[0]
+		vdi.CurrentOperations = map[string]payloads.VDIOperation{"op": payloads.VDIOperationDisableCBT}
+		svc, server, _ := setupActionTestServer(t, vdi, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("no request expected")
+		})
+		defer server.Close()
+
+		_, err := svc.EnableCBT(t.Context(), vdiID)
+
+		assert.ErrorContains(t, err, "disable_cbt operation in progress")
+	})
+}
+
+// setupCBTTestServer serves the current snapshot, its changed blocks bitmap
+// against the base snapshot and its raw content, honoring Range requests.
+func setupCBTTestServer(t *testing.T, current *payloads.VDI, bitmap []byte,
+	content []byte) (*Service, *httptest.Server, *[]string) {
+	var ranges []string
+	svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/changed_blocks"):
+			assert.Equal(t, testVDIID1, r.URL.Query().Get("base"))
+			_ = json.NewEncoder(w).Encode(base64.StdEncoding.EncodeToString(bitmap))
+		case strings.HasSuffix(r.URL.Path, ".raw"):
+			ranges = append(ranges, r.Header.Get("Range"))
+			http.ServeContent(w, r, "disk.raw", time.Time{}, bytes.NewReader(content))
+		default:
+			_ = json.NewEncoder(w).Encode(current)
+		}
+	})
+	return svc, server, &ranges
+}
+
+func TestListChangedBlocks(t *testing.T) {
+	baseID := uuid.Must(uuid.FromString(testVDIID1))
+	currentID := uuid.Must(uuid.FromString(testVDIID2))
+
+	t.Run("decodes the bitmap", func(t *testing.T) {
+		svc, server, _ := setupCBTTestServer(t, mockVDIs()[1], []byte{0xC0, 0x01}, nil)
+		defer server.Close()
+
+		blocks, err := svc.ListChangedBlocks(t.Context(), baseID, currentID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), blocks.Count())
+		assert.Equal(t, []payloads.BlockExtent{
+			{Offset: 0, Length: 2 * payloads.CBTBlockSize},
+			{Offset: 15 * payloads.CBTBlockSize, Length: payloads.CBTBlockSize},
+		}, blocks.Extents())
+	})
+
+	t.Run("CBT disabled", func(t *testing.T) {
+		disabled := false
+		current := mockVDIs()[1]
+		current.CBTEnabled = &disabled
+		svc, server, _ := setupCBTTestServer(t, current, []byte{0xFF}, nil)
+		defer server.Close()
+
+		_, err := svc.ListChangedBlocks(t.Context(), baseID, currentID)
+
+		assert.ErrorContains(t, err, "changed block tracking is not enabled")
+	})
+
+	t.Run("same snapshot", func(t *testing.T) {
+		svc, server, _ := setupCBTTestServer(t, mockVDIs()[1], nil, nil)
+		defer server.Close()
+
+		_, err := svc.ListChangedBlocks(t.Context(), currentID, currentID)
+
+		assert.ErrorContains(t, err, "must be different")
+	})
+}
+
+func TestExportChangedBlocks(t *testing.T) {
+	baseID := uuid.Must(uuid.FromString(testVDIID1))
+	currentID := uuid.Must(uuid.FromString(testVDIID2))
+
+	content := make([]byte, 4*payloads.CBTBlockSize)
+	for i := range content {
+		content[i] = byte(i / int(payloads.CBTBlockSize))
+	}
+
+	t.Run("streams only the changed extents", func(t *testing.T) {
+		// Blocks 1, 2 and 3 changed.
+		svc, server, ranges := setupCBTTestServer(t, mockVDIs()[1], []byte{0x70}, content)
+		defer server.Close()
+
+		var extents []payloads.BlockExtent
+		err := svc.ExportChangedBlocks(t.Context(), baseID, currentID,
+			func(extent payloads.BlockExtent, data io.Reader) error {
+				read, err := io.ReadAll(data)
+				require.NoError(t, err)
+				assert.Equal(t, content[extent.Offset:extent.Offset+extent.Length], read)
+				extents = append(extents, extent)
+				return nil
+			})
+
+		require.NoError(t, err)
+		assert.Equal(t, []payloads.BlockExtent{
+			{Offset: payloads.CBTBlockSize, Length: 3 * payloads.CBTBlockSize},
+		}, extents)
+		assert.Equal(t, []string{fmt.Sprintf("bytes=%d-%d", payloads.CBTBlockSize, 4*payloads.CBTBlockSize-1)}, *ranges)
+	})
+
+	t.Run("callback error stops the export", func(t *testing.T) {
+		// Blocks 0 and 2 changed.
+		svc, server, ranges := setupCBTTestServer(t, mockVDIs()[1], []byte{0xA0}, content)
+		defer server.Close()
+
+		err := svc.ExportChangedBlocks(t.Context(), baseID, currentID,
+			func(payloads.BlockExtent, io.Reader) error {
+				return errors.New("disk full")
+			})
+
+		assert.ErrorContains(t, err, "disk full")
+		assert.Len(t, *ranges, 1)
+	})
+
+	t.Run("trailing partial block", func(t *testing.T) {
+		// The disk ends in the middle of block 3, and blocks 2 and 3 changed.
+		current := mockVDIs()[1]
+		current.Size = 3*payloads.CBTBlockSize + payloads.CBTBlockSize/2
+		svc, server, ranges := setupCBTTestServer(t, current, []byte{0x30}, content[:current.Size])
+		defer server.Close()
+
+		var extents []payloads.BlockExtent
+		err := svc.ExportChangedBlocks(t.Context(), baseID, currentID,
+			func(extent payloads.BlockExtent, data io.Reader) error {
+				read, err := io.ReadAll(data)
+				require.NoError(t, err)
+				assert.Len(t, read, int(extent.Length))
+				extents = append(extents, extent)
+				return nil
+			})
+
+		require.NoError(t, err)
+		assert.Equal(t, []payloads.BlockExtent{
+			{Offset: 2 * payloads.CBTBlockSize, Length: current.Size - 2*payloads.CBTBlockSize},
+		}, extents)
+		assert.Equal(t, []string{fmt.Sprintf("bytes=%d-%d", 2*payloads.CBTBlockSize, current.Size-1)}, *ranges)
+	})
+
+	t.Run("nil callback", func(t *testing.T) {
+		svc, server, _ := setupCBTTestServer(t, mockVDIs()[1], nil, nil)
+		defer server.Close()
+
+		err := svc.ExportChangedBlocks(t.Context(), baseID, currentID, nil)
+
+		assert.ErrorContains(t, err, "callback function cannot be nil")
+	})
+}
+
+func TestConvertedImportExport(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID1))
+	disk := make([]byte, 3<<20)
+	copy(disk[1<<20:], "converted disk content")
+
+	t.Run("imports a QCOW2 image as VHD", func(t *testing.T) {
+		converted, err := diskimage.FromRaw(payloads.VDIFormatQCOW2, bytes.NewReader(disk), int64(len(disk)))
+		require.NoError(t, err)
+		qcow2, err := io.ReadAll(converted)
+		require.NoError(t, err)
+
+		var uploaded []byte
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/vdis/"+testVDIID1+".vhd", r.URL.Path)
+			uploaded, err = io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, r.ContentLength, int64(len(uploaded)))
+		})
+		defer server.Close()
+
+		err = svc.Import(t.Context(), vdiID, payloads.VDIFormatQCOW2, bytes.NewReader(qcow2), int64(len(qcow2)))
+		require.NoError(t, err)
+
+		img, err := diskimage.Open(payloads.VDIFormatVHD, bytes.NewReader(uploaded), int64(len(uploaded)))
+		require.NoError(t, err)
+		content := make([]byte, len(disk))
+		_, err = img.ReadAt(content, 0)
+		require.NoError(t, err)
+		assert.Equal(t, disk, content)
+	})
+
+	t.Run("import requires random access", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("no request expected")
+		})
+		defer server.Close()
+
+		err := svc.Import(t.Context(), vdiID, payloads.VDIFormatVMDK, io.LimitReader(strings.NewReader("KDMV"), 4), 4)
+
+		assert.ErrorContains(t, err, "requires content implementing io.ReaderAt")
+	})
+
+	t.Run("exports a VMDK image from the raw content", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/vdis/" + testVDIID1:
+				_ = json.NewEncoder(w).Encode(payloads.VDI{UUID: vdiID, Size: int64(len(disk))})
+			case "/vdis/" + testVDIID1 + ".raw":
+				_, _ = w.Write(disk)
+			default:
+				t.Errorf("unexpected request %s", r.URL.Path)
+			}
+		})
+		defer server.Close()
+
+		var vmdk []byte
+		err := svc.Export(t.Context(), vdiID, payloads.VDIFormatVMDK, func(r io.Reader) error {
+			var err error
+			vmdk, err = io.ReadAll(r)
+			return err
+		})
+		require.NoError(t, err)
+
+		img, err := diskimage.Open(payloads.VDIFormatVMDK, bytes.NewReader(vmdk), int64(len(vmdk)))
+		require.NoError(t, err)
+		content := make([]byte, len(disk))
+		_, err = img.ReadAt(content, 0)
+		require.NoError(t, err)
+		assert.Equal(t, disk, content)
+	})
+}
+
+// resumableUploadHandler emulates a server supporting resumable uploads. When
+// failAt is reached, it keeps half of the chunk and fails the request once.
+func resumableUploadHandler(t *testing.T, received *[]byte, failAt int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var start, end, total int
+		contentRange := r.Header.Get("Content-Range")
+		switch {
+		case contentRange == "":
+			*received = body
+			return
+		case strings.HasPrefix(contentRange, "bytes */"):
+		default:
+			_, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total)
+			require.NoError(t, err)
+			if start != len(*received) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			if start == failAt {
+				failAt = -1
+				*received = append(*received, body[:len(body)/2]...)
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			*received = append(*received, body...)
+			if end == total-1 {
+				return
+			}
+		}
+
+		if len(*received) > 0 {
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(*received)-1))
+		}
+		w.WriteHeader(client.StatusResumeIncomplete)
+	}
+}
+
+func TestImportWithOptions(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID1))
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	checksum := sha256.Sum256(content)
+	chunkRetryDelay = time.Millisecond
+
+	t.Run("resumes a failed chunk", func(t *testing.T) {
+		var received []byte
+		svc, server, _ := setupTestServerWithHandler(t, resumableUploadHandler(t, &received, 400))
+		defer server.Close()
+
+		var last payloads.VDITransferProgress
+		result, err := svc.ImportWithOptions(t.Context(), vdiID, payloads.VDIFormatRaw,
+			bytes.NewReader(content), int64(len(content)), payloads.VDITransferOptions{
+				ChunkSize:  200,
+				MaxRetries: 1,
+				SHA256:     true,
+				OnProgress: func(p payloads.VDITransferProgress) { last = p },
+			})
+
+		require.NoError(t, err)
+		assert.Equal(t, content, received)
+		assert.Equal(t, int64(len(content)), result.Transferred)
+		assert.Equal(t, hex.EncodeToString(checksum[:]), result.SHA256)
+		assert.Equal(t, int64(len(content)), last.Transferred)
+		assert.Equal(t, int64(len(content)), last.Total)
+	})
+
+	t.Run("gives up after the maximum retries", func(t *testing.T) {
+		var received []byte
+		svc, server, _ := setupTestServerWithHandler(t, resumableUploadHandler(t, &received, 400))
+		defer server.Close()
+
+		_, err := svc.ImportWithOptions(t.Context(), vdiID, payloads.VDIFormatRaw,
+			bytes.NewReader(content), int64(len(content)), payloads.VDITransferOptions{ChunkSize: 200})
+
+		assert.ErrorContains(t, err, "VDI import failed at offset 400")
+	})
+
+	t.Run("does not probe the server first", func(t *testing.T) {
+		var received []byte
+		resumable := resumableUploadHandler(t, &received, -1)
+		requests := 0
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			if requests++; requests == 1 {
+				assert.Equal(t, "bytes 0-199/1000", r.Header.Get("Content-Range"))
+			}
+			resumable(w, r)
+		})
+		defer server.Close()
+
+		_, err := svc.ImportWithOptions(t.Context(), vdiID, payloads.VDIFormatRaw,
+			bytes.NewReader(content), int64(len(content)), payloads.VDITransferOptions{ChunkSize: 200})
+
+		require.NoError(t, err)
+		assert.Equal(t, content, received)
+		assert.Equal(t, 5, requests)
+	})
+
+	t.Run("fails when the server does not support resumable uploads", func(t *testing.T) {
+		requests := 0
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_, _ = io.Copy(io.Discard, r.Body)
+		})
+		defer server.Close()
+
+		_, err := svc.ImportWithOptions(t.Context(), vdiID, payloads.VDIFormatRaw,
+			bytes.NewReader(content), int64(len(content)), payloads.VDITransferOptions{ChunkSize: 200, MaxRetries: 3})
+
+		assert.ErrorContains(t, err, "server completed the upload early, at offset 200 of 1000")
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("fails when a state request completes the upload", func(t *testing.T) {
+		requests := 0
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_, _ = io.Copy(io.Discard, r.Body)
+			if !strings.HasPrefix(r.Header.Get("Content-Range"), "bytes */") {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		})
+		defer server.Close()
+
+		_, err := svc.ImportWithOptions(t.Context(), vdiID, payloads.VDIFormatRaw,
+			bytes.NewReader(content), int64(len(content)), payloads.VDITransferOptions{ChunkSize: 200, MaxRetries: 3})
+
+		assert.ErrorContains(t, err, "server completed the upload early, on an upload state request")
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("chunked import requires random access", func(t *testing.T) {
+		svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("no request expected")
+		})
+		defer server.Close()
+
+		_, err := svc.ImportWithOptions(t.Context(), vdiID, payloads.VDIFormatRaw,
+			io.LimitReader(bytes.NewReader(content), int64(len(content))), int64(len(content)),
+			payloads.VDITransferOptions{ChunkSize: 200})
+
+		assert.ErrorContains(t, err, "chunked import requires content implementing io.ReaderAt")
+	})
+}
+
+func TestExportWithOptions(t *testing.T) {
+	vdiID := uuid.Must(uuid.FromString(testVDIID1))
+	content := bytes.Repeat([]byte("abcdefghij"), 100)
+	checksum := sha256.Sum256(content)
+
+	svc, server, _ := setupTestServerWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vdis/"+testVDIID1+".vhd", r.URL.Path)
+		_, _ = w.Write(content)
+	})
+	defer server.Close()
+
+	var last payloads.VDITransferProgress
+	result, err := svc.ExportWithOptions(t.Context(), vdiID, payloads.VDIFormatVHD, func(r io.Reader) error {
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}, payloads.VDITransferOptions{
+		SHA256:     true,
+		OnProgress: func(p payloads.VDITransferProgress) { last = p },
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), result.Transferred)
+	assert.Equal(t, hex.EncodeToString(checksum[:]), result.SHA256)
+	assert.Equal(t, int64(len(content)), last.Total)
+	assert.Equal(t, int64(len(content)), last.Transferred)
+}
@@ -0,0 +1,53 @@
+package vgputype
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	client *client.Client
+	log    *logger.Logger
+}
+
+func New(client *client.Client, log *logger.Logger) library.VGPUType {
+	return &Service{
+		client: client,
+		log:    log,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*payloads.VGPUType, error) {
+	var result payloads.VGPUType
+	path := core.NewPathBuilder().Resource(payloads.ResourceTypeVGPUType.Path()).ID(id).Build()
+	if err := client.TypedGet(ctx, s.client, path, core.EmptyParams, &result); err != nil {
+		s.log.Error("Failed to get vGPU type by ID", zap.String("vgpuTypeID", id.String()), zap.Error(err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Service) GetAll(ctx context.Context, limit int, filter string) ([]*payloads.VGPUType, error) {
+	path := core.NewPathBuilder().Resource(payloads.ResourceTypeVGPUType.Path()).Build()
+	params := map[string]any{"fields": "*"}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if filter != "" {
+		params["filter"] = filter
+	}
+
+	var result []*payloads.VGPUType
+	if err := client.TypedGet(ctx, s.client, path, params, &result); err != nil {
+		s.log.Error("Failed to get all vGPU types", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package vgputype
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
+)
+
+var testVGPUTypeID = uuid.Must(uuid.FromString("9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c01"))
+
+func setupTestServer(t *testing.T) library.VGPUType {
+	t.Helper()
+	typeJSON := fmt.Sprintf(`{"id": "%s", "type": "vgpuType", "vendorName": "NVIDIA Corporation",
+		"modelName": "GRID T4-4Q", "framebufferSize": 4294967296, "maxHeads": 4,
+		"maxResolutionX": 7680, "maxResolutionY": 4320, "experimental": false}`, testVGPUTypeID)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v0/vgpu-types", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", typeJSON)
+	})
+	mux.HandleFunc("GET /rest/v0/vgpu-types/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, typeJSON)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	log, err := logger.New(false, []string{"stdout"}, []string{"stderr"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	return New(&client.Client{
+		HttpClient: server.Client(),
+		BaseURL:    &url.URL{Scheme: "http", Host: server.URL[7:], Path: "/rest/v0"},
+		AuthToken:  "test-token",
+	}, log)
+}
+
+func TestGet(t *testing.T) {
+	svc := setupTestServer(t)
+
+	vgpuType, err := svc.Get(t.Context(), testVGPUTypeID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "GRID T4-4Q", vgpuType.ModelName)
+	assert.Equal(t, int64(4294967296), vgpuType.FramebufferSize)
+}
+
+func TestGetAll(t *testing.T) {
+	svc := setupTestServer(t)
+
+	vgpuTypes, err := svc.GetAll(t.Context(), 2, "")
+
+	require.NoError(t, err)
+	require.Len(t, vgpuTypes, 1)
+	assert.Equal(t, 4, vgpuTypes[0].MaxHeads)
+}
@@ -3,6 +3,7 @@ package vm
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gofrs/uuid"
@@ -20,7 +21,11 @@ type Service struct {
 	// Needed by VM for the task related but not part of the VM interface
 	taskService library.Task
 	poolService library.Pool
+	vbdService  library.VBD
+	vdiService  library.VDI
+	pciService  library.PCI
 	tagService  *tagger.Tagger
+	jsonrpcSvc  library.JSONRPC
 
 	client *client.Client
 	log    *logger.Logger
@@ -30,13 +35,21 @@ func New(
 	client *client.Client,
 	task library.Task,
 	pool library.Pool,
+	vbd library.VBD,
+	vdi library.VDI,
+	pci library.PCI,
+	jsonrpcSvc library.JSONRPC,
 	log *logger.Logger,
 ) library.VM {
 	return &Service{
 		client:      client,
 		taskService: task,
 		poolService: pool,
+		vbdService:  vbd,
+		vdiService:  vdi,
+		pciService:  pci,
 		tagService:  tagger.New(client, log, payloads.ResourceTypeVM),
+		jsonrpcSvc:  jsonrpcSvc,
 		log:         log,
 	}
 }
@@ -286,3 +299,204 @@ func (s *Service) GetVDIs(ctx context.Context, vmID uuid.UUID, limit int, filter
 func (s *Service) GetTasks(ctx context.Context, id uuid.UUID, limit int, filter string) ([]*payloads.Task, error) {
 	return tasker.GetTasks(ctx, s.client, s.log, payloads.ResourceTypeVM, id, limit, filter)
 }
+
+func (s *Service) GetCDDrives(ctx context.Context, vmID uuid.UUID) ([]*payloads.VBD, error) {
+	drives, err := s.vbdService.GetAll(ctx, 0, fmt.Sprintf("VM:%s is_cd_drive?", vmID))
+	if err != nil {
+		s.log.Error("Failed to get CD drives for VM", zap.String("vmID", vmID.String()), zap.Error(err))
+		return nil, err
+	}
+	return drives, nil
+}
+
+func (s *Service) InsertCD(ctx context.Context, vmID uuid.UUID, isoVDIID uuid.UUID) error {
+	vdi, err := s.vdiService.Get(ctx, isoVDIID)
+	if err != nil {
+		return fmt.Errorf("failed to get ISO VDI %s: %w", isoVDIID, err)
+	}
+	// The SR is read directly, as the SR service depends on this one.
+	var sr payloads.StorageRepository
+	path := core.NewPathBuilder().Resource(payloads.ResourceTypeSR.Path()).ID(vdi.SR).Build()
+	if err := client.TypedGet(ctx, s.client, path, core.EmptyParams, &sr); err != nil {
+		return fmt.Errorf("failed to get SR %s of VDI %s: %w", vdi.SR, isoVDIID, err)
+	}
+	if sr.ContentType != payloads.SRContentTypeISO {
+		return fmt.Errorf("VDI %s is not an ISO: SR %s is not an ISO SR (content type %q)",
+			isoVDIID, vdi.SR, sr.ContentType)
+	}
+
+	drives, err := s.GetCDDrives(ctx, vmID)
+	if err != nil {
+		return err
+	}
+
+	// Without a CD drive, attaching the ISO creates one.
+	if len(drives) == 0 {
+		_, err := s.vbdService.Create(ctx, &payloads.CreateVBDParams{
+			VM:   vmID,
+			VDI:  isoVDIID,
+			Type: payloads.VBDTypeCD,
+			Mode: payloads.VBDModeRO,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create CD drive on VM %s: %w", vmID, err)
+		}
+		return nil
+	}
+
+	// XO returns nothing.
+	var result any
+	params := map[string]any{
+		"id":    vmID.String(),
+		"cd_id": isoVDIID.String(),
+		// Replace the ISO currently inserted, if any.
+		"force": true,
+	}
+	return s.jsonrpcSvc.Call("vm.insertCd", params, &result,
+		zap.String("vmID", vmID.String()), zap.String("vdiID", isoVDIID.String()))
+}
+
+func (s *Service) EjectCD(ctx context.Context, vmID uuid.UUID) error {
+	drives, err := s.GetCDDrives(ctx, vmID)
+	if err != nil {
+		return err
+	}
+
+	inserted := slices.ContainsFunc(drives, func(drive *payloads.VBD) bool {
+		return drive.VDI != nil && *drive.VDI != uuid.Nil
+	})
+	if !inserted {
+		return nil
+	}
+
+	// XO returns nothing.
+	var result any
+	return s.jsonrpcSvc.Call("vm.ejectCd", map[string]any{"id": vmID.String()}, &result,
+		zap.String("vmID", vmID.String()))
+}
+
+func (s *Service) AttachPCI(ctx context.Context, vmID uuid.UUID, pciID uuid.UUID) (payloads.PCIDom0Access, error) {
+	access, err := s.pciService.GetDom0Access(ctx, pciID)
+	if err != nil {
+		return "", err
+	}
+	if !access.Hidden() {
+		if access, err = s.pciService.Hide(ctx, pciID); err != nil {
+			return "", fmt.Errorf("failed to hide PCI device %s from dom0: %w", pciID, err)
+		}
+		s.log.Info("PCI device hidden from dom0", zap.String("pciID", pciID.String()),
+			zap.String("dom0Access", string(access)))
+	}
+
+	var result any
+	params := map[string]any{
+		"id":   vmID.String(),
+		"pcis": []string{pciID.String()},
+	}
+	if err := s.jsonrpcSvc.Call("vm.attachPcis", params, &result, zap.String("vmID", vmID.String())); err != nil {
+		return access, err
+	}
+	return access, nil
+}
+
+func (s *Service) DetachPCI(
+	ctx context.Context, vmID uuid.UUID, pciID uuid.UUID, unhide bool) (payloads.PCIDom0Access, error) {
+	var result any
+	params := map[string]any{
+		"id":     vmID.String(),
+		"pciIds": []string{pciID.String()},
+	}
+	if err := s.jsonrpcSvc.Call("vm.detachPcis", params, &result, zap.String("vmID", vmID.String())); err != nil {
+		return "", err
+	}
+
+	access, err := s.pciService.GetDom0Access(ctx, pciID)
+	if err != nil {
+		return "", err
+	}
+	if unhide && access.Hidden() {
+		if access, err = s.pciService.Unhide(ctx, pciID); err != nil {
+			return "", fmt.Errorf("failed to give PCI device %s back to dom0: %w", pciID, err)
+		}
+	}
+	return access, nil
+}
+
+func (s *Service) CreateVTPM(ctx context.Context, vmID uuid.UUID) (uuid.UUID, error) {
+	vm, err := s.GetByID(ctx, vmID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := checkHaltedUEFI(vm, "create a vTPM"); err != nil {
+		return uuid.Nil, err
+	}
+	pool, err := s.poolService.Get(ctx, vm.PoolID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !pool.VTPMSupported {
+		return uuid.Nil, fmt.Errorf("pool %s does not support vTPMs", vm.PoolID)
+	}
+
+	var result string
+	if err := s.jsonrpcSvc.Call("vtpm.create", map[string]any{"id": vmID.String()}, &result,
+		zap.String("vmID", vmID.String())); err != nil {
+		return uuid.Nil, err
+	}
+	id, err := uuid.FromString(result)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid vTPM ID %q returned by XO: %w", result, err)
+	}
+	return id, nil
+}
+
+func (s *Service) DeleteVTPM(ctx context.Context, vmID uuid.UUID) error {
+	vm, err := s.GetByID(ctx, vmID)
+	if err != nil {
+		return err
+	}
+	if len(vm.VTPMs) == 0 {
+		return nil
+	}
+	if vm.PowerState != payloads.PowerStateHalted {
+		return fmt.Errorf("VM %s must be halted to delete its vTPM, it is %s", vmID, vm.PowerState)
+	}
+
+	for _, id := range vm.VTPMs {
+		var result any
+		if err := s.jsonrpcSvc.Call("vtpm.destroy", map[string]any{"id": id.String()}, &result,
+			zap.String("vmID", vmID.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) SetSecureBoot(ctx context.Context, vmID uuid.UUID, enabled bool) error {
+	vm, err := s.GetByID(ctx, vmID)
+	if err != nil {
+		return err
+	}
+	if err := checkHaltedUEFI(vm, "change its Secure Boot"); err != nil {
+		return err
+	}
+
+	var result any
+	params := map[string]any{
+		"id":         vmID.String(),
+		"secureBoot": enabled,
+	}
+	return s.jsonrpcSvc.Call("vm.set", params, &result, zap.String("vmID", vmID.String()))
+}
+
+// checkHaltedUEFI checks that a VM boots with UEFI and is halted, as needed
+// by its Secure Boot and vTPM.
+func checkHaltedUEFI(vm *payloads.VM, operation string) error {
+	if vm.Boot.Firmware != payloads.BootFirmwareUEFI {
+		return fmt.Errorf("VM %s must boot with UEFI to %s, its firmware is %q", vm.ID, operation, vm.Boot.Firmware)
+	}
+	if vm.PowerState != payloads.PowerStateHalted {
+		return fmt.Errorf("VM %s must be halted to %s, it is %s", vm.ID, operation, vm.PowerState)
+	}
+	return nil
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/docker/go-units"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
@@ -81,7 +83,8 @@ func setupTestServerWithHandler(t *testing.T, handler http.HandlerFunc) (*httpte
 	ctrl := gomock.NewController(t)
 	mockTask := mock.NewMockTask(ctrl)
 	mockPool := mock.NewMockPool(ctrl)
-	return server, New(restClient, mockTask, mockPool, log).(*Service), mockPool
+	return server, New(restClient, mockTask, mockPool, mock.NewMockVBD(ctrl), mock.NewMockVDI(ctrl), mock.NewMockPCI(ctrl),
+		mock.NewMockJSONRPC(ctrl), log).(*Service), mockPool
 }
 
 func setupTestServer(t *testing.T) (*httptest.Server, library.VM, *mock.MockPool) {
@@ -180,7 +183,8 @@ func setupTestServer(t *testing.T) (*httptest.Server, library.VM, *mock.MockPool
 	mockTask := mock.NewMockTask(ctrl)
 	mockPool := mock.NewMockPool(ctrl)
 
-	return server, New(restClient, mockTask, mockPool, log), mockPool
+	return server, New(restClient, mockTask, mockPool, mock.NewMockVBD(ctrl), mock.NewMockVDI(ctrl), mock.NewMockPCI(ctrl),
+		mock.NewMockJSONRPC(ctrl), log), mockPool
 }
 
 func TestGetByID(t *testing.T) {
@@ -353,3 +357,285 @@ func TestGetVDIs(t *testing.T) {
 	})
 
 }
+
+func TestCDROM(t *testing.T) {
+	vmID := uuid.Must(uuid.FromString(mockVMID1))
+	isoID := uuid.Must(uuid.FromString("30000000-0000-0000-0000-000000000001"))
+	driveID := uuid.Must(uuid.FromString("30000000-0000-0000-0000-000000000002"))
+	isoSRID := uuid.Must(uuid.FromString("30000000-0000-0000-0000-000000000003"))
+	userSRID := uuid.Must(uuid.FromString("30000000-0000-0000-0000-000000000004"))
+	cdFilter := "VM:" + mockVMID1 + " is_cd_drive?"
+	isoVDI := &payloads.VDI{ID: isoID, SR: isoSRID}
+
+	setup := func(t *testing.T) (library.VM, *mock.MockVBD, *mock.MockVDI, *mock.MockJSONRPC) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /rest/v0/srs/{id}", func(w http.ResponseWriter, r *http.Request) {
+			sr := payloads.StorageRepository{ContentType: "user"}
+			if r.PathValue("id") == isoSRID.String() {
+				sr.ContentType = payloads.SRContentTypeISO
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(sr)
+		})
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		ctrl := gomock.NewController(t)
+		log, _ := logger.New(false, []string{"stdout"}, []string{"stderr"})
+		restClient := &client.Client{
+			HttpClient: server.Client(),
+			BaseURL:    &url.URL{Scheme: "http", Host: server.URL[7:], Path: "/rest/v0"},
+			AuthToken:  "test-token",
+		}
+		mockVBD := mock.NewMockVBD(ctrl)
+		mockVDI := mock.NewMockVDI(ctrl)
+		mockJSONRPC := mock.NewMockJSONRPC(ctrl)
+		svc := New(restClient, mock.NewMockTask(ctrl), mock.NewMockPool(ctrl), mockVBD, mockVDI,
+			mock.NewMockPCI(ctrl), mockJSONRPC, log)
+		return svc, mockVBD, mockVDI, mockJSONRPC
+	}
+
+	t.Run("get CD drives", func(t *testing.T) {
+		svc, mockVBD, _, _ := setup(t)
+		mockVBD.EXPECT().GetAll(gomock.Any(), 0, cdFilter).
+			Return([]*payloads.VBD{{ID: driveID, IsCDDrive: true}}, nil)
+
+		drives, err := svc.GetCDDrives(t.Context(), vmID)
+
+		require.NoError(t, err)
+		require.Len(t, drives, 1)
+		assert.Equal(t, driveID, drives[0].ID)
+	})
+
+	t.Run("insert in an existing drive", func(t *testing.T) {
+		svc, mockVBD, mockVDI, mockJSONRPC := setup(t)
+		mockVDI.EXPECT().Get(gomock.Any(), isoID).Return(isoVDI, nil)
+		mockVBD.EXPECT().GetAll(gomock.Any(), 0, cdFilter).
+			Return([]*payloads.VBD{{ID: driveID, IsCDDrive: true}}, nil)
+		// XO returns nothing: the result is left unset.
+		mockJSONRPC.EXPECT().Call("vm.insertCd", map[string]any{
+			"id":    mockVMID1,
+			"cd_id": isoID.String(),
+			"force": true,
+		}, gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, svc.InsertCD(t.Context(), vmID, isoID))
+	})
+
+	t.Run("insert creates a drive when the VM has none", func(t *testing.T) {
+		svc, mockVBD, mockVDI, _ := setup(t)
+		mockVDI.EXPECT().Get(gomock.Any(), isoID).Return(isoVDI, nil)
+		mockVBD.EXPECT().GetAll(gomock.Any(), 0, cdFilter).Return([]*payloads.VBD{}, nil)
+		mockVBD.EXPECT().Create(gomock.Any(), &payloads.CreateVBDParams{
+			VM:   vmID,
+			VDI:  isoID,
+			Type: payloads.VBDTypeCD,
+			Mode: payloads.VBDModeRO,
+		}).Return(driveID, nil)
+
+		assert.NoError(t, svc.InsertCD(t.Context(), vmID, isoID))
+	})
+
+	t.Run("insert fails when the ISO does not exist", func(t *testing.T) {
+		svc, _, mockVDI, _ := setup(t)
+		mockVDI.EXPECT().Get(gomock.Any(), isoID).Return(nil, fmt.Errorf("not found"))
+
+		assert.ErrorContains(t, svc.InsertCD(t.Context(), vmID, isoID), "failed to get ISO VDI")
+	})
+
+	t.Run("insert fails when the VDI is not an ISO", func(t *testing.T) {
+		svc, _, mockVDI, _ := setup(t)
+		mockVDI.EXPECT().Get(gomock.Any(), isoID).Return(&payloads.VDI{ID: isoID, SR: userSRID}, nil)
+
+		assert.ErrorContains(t, svc.InsertCD(t.Context(), vmID, isoID), "is not an ISO SR")
+	})
+
+	t.Run("eject", func(t *testing.T) {
+		svc, mockVBD, _, mockJSONRPC := setup(t)
+		mockVBD.EXPECT().GetAll(gomock.Any(), 0, cdFilter).
+			Return([]*payloads.VBD{{ID: driveID, IsCDDrive: true, VDI: &isoID}}, nil)
+		mockJSONRPC.EXPECT().Call("vm.ejectCd", map[string]any{"id": mockVMID1}, gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		assert.NoError(t, svc.EjectCD(t.Context(), vmID))
+	})
+
+	t.Run("eject does nothing when the drive is empty", func(t *testing.T) {
+		svc, mockVBD, _, _ := setup(t)
+		mockVBD.EXPECT().GetAll(gomock.Any(), 0, cdFilter).
+			Return([]*payloads.VBD{{ID: driveID, IsCDDrive: true}}, nil)
+
+		assert.NoError(t, svc.EjectCD(t.Context(), vmID))
+	})
+}
+
+func TestPCIPassthrough(t *testing.T) {
+	vmID := uuid.Must(uuid.FromString(mockVMID1))
+	pciID := uuid.Must(uuid.FromString("40000000-0000-0000-0000-000000000001"))
+	attachParams := map[string]any{"id": mockVMID1, "pcis": []string{pciID.String()}}
+	detachParams := map[string]any{"id": mockVMID1, "pciIds": []string{pciID.String()}}
+
+	setup := func(t *testing.T) (library.VM, *mock.MockPCI, *mock.MockJSONRPC) {
+		ctrl := gomock.NewController(t)
+		log, _ := logger.New(false, []string{"stdout"}, []string{"stderr"})
+		mockPCI := mock.NewMockPCI(ctrl)
+		mockJSONRPC := mock.NewMockJSONRPC(ctrl)
+		svc := New(nil, mock.NewMockTask(ctrl), mock.NewMockPool(ctrl), mock.NewMockVBD(ctrl), mock.NewMockVDI(ctrl),
+			mockPCI, mockJSONRPC, log)
+		return svc, mockPCI, mockJSONRPC
+	}
+
+	t.Run("attach hides the device", func(t *testing.T) {
+		svc, mockPCI, mockJSONRPC := setup(t)
+		gomock.InOrder(
+			mockPCI.EXPECT().GetDom0Access(gomock.Any(), pciID).Return(payloads.PCIDom0AccessEnabled, nil),
+			mockPCI.EXPECT().Hide(gomock.Any(), pciID).Return(payloads.PCIDom0AccessDisableOnReboot, nil),
+			mockJSONRPC.EXPECT().Call("vm.attachPcis", attachParams, gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		access, err := svc.AttachPCI(t.Context(), vmID, pciID)
+
+		require.NoError(t, err)
+		assert.True(t, access.RebootRequired())
+	})
+
+	t.Run("attach a hidden device", func(t *testing.T) {
+		svc, mockPCI, mockJSONRPC := setup(t)
+		mockPCI.EXPECT().GetDom0Access(gomock.Any(), pciID).Return(payloads.PCIDom0AccessDisabled, nil)
+		mockJSONRPC.EXPECT().Call("vm.attachPcis", attachParams, gomock.Any(), gomock.Any()).Return(nil)
+
+		access, err := svc.AttachPCI(t.Context(), vmID, pciID)
+
+		require.NoError(t, err)
+		assert.Equal(t, payloads.PCIDom0AccessDisabled, access)
+	})
+
+	t.Run("detach and unhide", func(t *testing.T) {
+		svc, mockPCI, mockJSONRPC := setup(t)
+		gomock.InOrder(
+			mockJSONRPC.EXPECT().Call("vm.detachPcis", detachParams, gomock.Any(), gomock.Any()).Return(nil),
+			mockPCI.EXPECT().GetDom0Access(gomock.Any(), pciID).Return(payloads.PCIDom0AccessDisabled, nil),
+			mockPCI.EXPECT().Unhide(gomock.Any(), pciID).Return(payloads.PCIDom0AccessEnableOnReboot, nil),
+		)
+
+		access, err := svc.DetachPCI(t.Context(), vmID, pciID, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, payloads.PCIDom0AccessEnableOnReboot, access)
+	})
+
+	t.Run("detach keeps the device hidden", func(t *testing.T) {
+		svc, mockPCI, mockJSONRPC := setup(t)
+		mockJSONRPC.EXPECT().Call("vm.detachPcis", detachParams, gomock.Any(), gomock.Any()).Return(nil)
+		mockPCI.EXPECT().GetDom0Access(gomock.Any(), pciID).Return(payloads.PCIDom0AccessDisabled, nil)
+
+		access, err := svc.DetachPCI(t.Context(), vmID, pciID, false)
+
+		require.NoError(t, err)
+		assert.True(t, access.Hidden())
+	})
+}
+
+func TestVTPMAndSecureBoot(t *testing.T) {
+	vmID := uuid.Must(uuid.FromString(mockVMID1))
+	poolID := uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000010"))
+	vtpmID := uuid.Must(uuid.FromString("50000000-0000-0000-0000-000000000001"))
+
+	setup := func(t *testing.T, vm payloads.VM) (library.VM, *mock.MockPool, *mock.MockJSONRPC) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /rest/v0/vms/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(vm)
+		})
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		ctrl := gomock.NewController(t)
+		log, _ := logger.New(false, []string{"stdout"}, []string{"stderr"})
+		restClient := &client.Client{
+			HttpClient: server.Client(),
+			BaseURL:    &url.URL{Scheme: "http", Host: server.URL[7:], Path: "/rest/v0"},
+			AuthToken:  "test-token",
+		}
+		mockPool := mock.NewMockPool(ctrl)
+		mockJSONRPC := mock.NewMockJSONRPC(ctrl)
+		svc := New(restClient, mock.NewMockTask(ctrl), mockPool, mock.NewMockVBD(ctrl), mock.NewMockVDI(ctrl),
+			mock.NewMockPCI(ctrl), mockJSONRPC, log)
+		return svc, mockPool, mockJSONRPC
+	}
+
+	haltedUEFI := payloads.VM{
+		ID:         vmID,
+		PowerState: payloads.PowerStateHalted,
+		Boot:       payloads.Boot{Firmware: payloads.BootFirmwareUEFI},
+		PoolID:     poolID,
+	}
+
+	t.Run("create a vTPM", func(t *testing.T) {
+		svc, mockPool, mockJSONRPC := setup(t, haltedUEFI)
+		mockPool.EXPECT().Get(gomock.Any(), poolID).Return(&payloads.Pool{VTPMSupported: true}, nil)
+		mockJSONRPC.EXPECT().Call("vtpm.create", map[string]any{"id": mockVMID1}, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, _ map[string]any, result any, _ ...zap.Field) error {
+				*result.(*string) = vtpmID.String()
+				return nil
+			})
+
+		id, err := svc.CreateVTPM(t.Context(), vmID)
+
+		require.NoError(t, err)
+		assert.Equal(t, vtpmID, id)
+	})
+
+	t.Run("create a vTPM on an unsupported pool", func(t *testing.T) {
+		svc, mockPool, _ := setup(t, haltedUEFI)
+		mockPool.EXPECT().Get(gomock.Any(), poolID).Return(&payloads.Pool{}, nil)
+
+		_, err := svc.CreateVTPM(t.Context(), vmID)
+
+		assert.ErrorContains(t, err, "does not support vTPMs")
+	})
+
+	t.Run("create a vTPM on a BIOS VM", func(t *testing.T) {
+		vm := haltedUEFI
+		vm.Boot.Firmware = "bios"
+		svc, _, _ := setup(t, vm)
+
+		_, err := svc.CreateVTPM(t.Context(), vmID)
+
+		assert.ErrorContains(t, err, "must boot with UEFI")
+	})
+
+	t.Run("delete the vTPM", func(t *testing.T) {
+		vm := haltedUEFI
+		vm.VTPMs = []uuid.UUID{vtpmID}
+		svc, _, mockJSONRPC := setup(t, vm)
+		mockJSONRPC.EXPECT().Call("vtpm.destroy", map[string]any{"id": vtpmID.String()}, gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		require.NoError(t, svc.DeleteVTPM(t.Context(), vmID))
+	})
+
+	t.Run("delete without vTPM", func(t *testing.T) {
+		svc, _, _ := setup(t, haltedUEFI)
+
+		require.NoError(t, svc.DeleteVTPM(t.Context(), vmID))
+	})
+
+	t.Run("enable Secure Boot", func(t *testing.T) {
+		svc, _, mockJSONRPC := setup(t, haltedUEFI)
+		params := map[string]any{"id": mockVMID1, "secureBoot": true}
+		mockJSONRPC.EXPECT().Call("vm.set", params, gomock.Any(), gomock.Any()).Return(nil)
+
+		require.NoError(t, svc.SetSecureBoot(t.Context(), vmID, true))
+	})
+
+	t.Run("enable Secure Boot on a running VM", func(t *testing.T) {
+		vm := haltedUEFI
+		vm.PowerState = payloads.PowerStateRunning
+		svc, _, _ := setup(t, vm)
+
+		err := svc.SetSecureBoot(t.Context(), vmID, true)
+
+		assert.ErrorContains(t, err, "must be halted")
+	})
+}
@@ -8,15 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"path"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/core"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/config"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/middleware"
 )
 
 type Token string
@@ -49,6 +53,17 @@ type Client struct {
 
 	RetryMode    core.RetryMode
 	RetryMaxTime time.Duration
+
+	// credentials returns the credentials to authenticate again with when XO
+	// rejects AuthToken. It is nil for a fixed token.
+	credentials config.CredentialsFunc
+	// authMu guards AuthToken, which is replaced when authenticating again.
+	authMu sync.RWMutex
+	// refreshMu serializes the authentications, so that concurrent requests
+	// rejected with the same token authenticate only once.
+	refreshMu sync.Mutex
+
+	middlewares []middleware.Middleware
 }
 
 // New creates an authenticated client with the provided configuration.
@@ -83,7 +98,7 @@ func New(config *config.Config) (*Client, error) {
 	}
 
 	httpClient := &http.Client{
-		Transport: transport,
+		Transport: middleware.ChainTransport(transport, config.Transports...),
 		Timeout:   clientTimeout,
 	}
 
@@ -92,24 +107,55 @@ func New(config *config.Config) (*Client, error) {
 		BaseURL:      baseURL,
 		RetryMode:    config.RetryMode,
 		RetryMaxTime: config.RetryMaxTime,
+		middlewares:  config.Middlewares,
 	}
 
-	if config.Token != "" {
+	switch {
+	case config.Credentials != nil:
+		client.credentials = config.Credentials
+	case config.Token != "":
 		client.AuthToken = Token(config.Token)
-	} else if config.Username != "" && config.Password != "" {
-		token, err := client.authenticate(config.Username, config.Password)
+	case config.Username != "" && config.Password != "":
+		client.credentials = passwordCredentials(config.Username, config.Password)
+	default:
+		return nil, errors.New("either token or username/password are required for authentication")
+	}
+
+	if client.credentials != nil {
+		token, err := client.login(context.Background())
 		if err != nil {
 			return nil, fmt.Errorf("failed to authenticate: %w", err)
 		}
 		client.AuthToken = token
-	} else {
-		return nil, errors.New("either token or username/password are required for authentication")
 	}
 
 	return client, nil
 }
 
-func (c *Client) authenticate(username, password string) (Token, error) {
+func passwordCredentials(username, password string) config.CredentialsFunc {
+	return func(context.Context) (config.Credentials, error) {
+		return config.Credentials{Username: username, Password: password}, nil
+	}
+}
+
+// login returns a token for the credentials: either the token itself, or one
+// returned by XO for the username and the password.
+func (c *Client) login(ctx context.Context) (Token, error) {
+	credentials, err := c.credentials(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get credentials: %w", err)
+	}
+	switch {
+	case credentials.Token != "":
+		return Token(credentials.Token), nil
+	case credentials.Username != "" && credentials.Password != "":
+		return c.authenticate(ctx, credentials.Username, credentials.Password)
+	default:
+		return "", errors.New("credentials have neither a token nor a username and a password")
+	}
+}
+
+func (c *Client) authenticate(ctx context.Context, username, password string) (Token, error) {
 	authURL := *c.BaseURL
 	authURL.Path = path.Join(strings.TrimSuffix(c.BaseURL.Path, core.RestV0Path), "auth/login")
 
@@ -123,7 +169,7 @@ func (c *Client) authenticate(username, password string) (Token, error) {
 		return "", fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, authURL.String(), bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL.String(), bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -150,6 +196,31 @@ func (c *Client) authenticate(username, password string) (Token, error) {
 	return "", fmt.Errorf("no auth token found")
 }
 
+func (c *Client) token() Token {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.AuthToken
+}
+
+// reauthenticate replaces the token rejected by XO, unless another request
+// already did.
+func (c *Client) reauthenticate(ctx context.Context, rejected Token) (Token, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if token := c.token(); token != rejected {
+		return token, nil
+	}
+
+	token, err := c.login(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.authMu.Lock()
+	c.AuthToken = token
+	c.authMu.Unlock()
+	return token, nil
+}
+
 func (c *Client) buildURL(endpoint string) url.URL {
 	reqURL := *c.BaseURL
 
@@ -164,21 +235,37 @@ func (c *Client) buildURL(endpoint string) url.URL {
 
 // doRequest performs an HTTP request and returns the raw response.
 // The caller is responsible for closing the response body when finished reading it.
-// For error responses (non-2xx), the body is read, closed, and included in the error message.
-func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
-	// #nosec G124 -- Outbound request cookie for SDK auth; Secure/HttpOnly/SameSite do not apply here.
-	req.AddCookie(&http.Cookie{
-		Name:  authCookieName,
-		Value: c.AuthToken.String(),
-	})
-
-	// #nosec G704 -- The URL is provided by the SDK user via configuration, this is not an SSRF vulnerability
-	resp, err := c.HttpClient.Do(req)
+// For error responses (non-2xx and not in accepted), the body is read, closed, and included in the error message.
+func (c *Client) doRequest(req *http.Request, accepted ...int) (*http.Response, error) {
+	token := c.token()
+	resp, err := c.send(req, token)
 	if err != nil {
-		return nil, core.ErrFailedToDoRequest.WithArgs(err, req.URL.String())
+		return nil, err
+	}
+
+	// An expired token is replaced, and the request sent again once, unless its
+	// body is a stream which cannot be read again.
+	if resp.StatusCode == http.StatusUnauthorized && c.credentials != nil && (req.Body == nil || req.GetBody != nil) {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		token, err = c.reauthenticate(req.Context(), token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate again: %w", err)
+		}
+		retry := req.Clone(req.Context())
+		retry.Header.Del("Cookie")
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return nil, core.ErrFailedToMakeRequest.WithArgs(err, req.URL.String())
+			}
+		}
+		if resp, err = c.send(retry, token); err != nil {
+			return nil, err
+		}
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+	if (resp.StatusCode < 200 || resp.StatusCode >= 300) && !slices.Contains(accepted, resp.StatusCode) {
 		bodyBytes, readErr := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
 		if readErr != nil {
@@ -190,29 +277,58 @@ func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+func (c *Client) send(req *http.Request, token Token) (*http.Response, error) {
+	// #nosec G124 -- Outbound request cookie for SDK auth; Secure/HttpOnly/SameSite do not apply here.
+	req.AddCookie(&http.Cookie{
+		Name:  authCookieName,
+		Value: token.String(),
+	})
+
+	// #nosec G704 -- The URL is provided by the SDK user via configuration, this is not an SSRF vulnerability
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, core.ErrFailedToDoRequest.WithArgs(err, req.URL.String())
+	}
+	return resp, nil
+}
+
 func (c *Client) do(ctx context.Context, method, endpoint string, params map[string]any, result any) error {
-	reqURL := c.buildURL(endpoint)
+	req := &middleware.Request{
+		Method:   method,
+		Endpoint: endpoint,
+		Params:   params,
+		Result:   result,
+		Header:   make(http.Header),
+	}
+	_, err := middleware.Chain(c.call, c.middlewares...)(ctx, req)
+	return err
+}
+
+// call sends a request, once the middlewares have run, and decodes its response.
+func (c *Client) call(ctx context.Context, r *middleware.Request) (*middleware.Response, error) {
+	reqURL := c.buildURL(r.Endpoint)
 
 	var reqBody io.Reader
-	if params != nil && (method == "POST" || method == "PUT" || method == "PATCH") {
-		jsonData, err := json.Marshal(params)
+	if r.Params != nil && (r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH") {
+		jsonData, err := json.Marshal(r.Params)
 		if err != nil {
-			return core.ErrFailedToMarshalParams.WithArgs(err, string(jsonData))
+			return nil, core.ErrFailedToMarshalParams.WithArgs(err, string(jsonData))
 		}
 		reqBody = bytes.NewBuffer(jsonData)
-	} else if params != nil {
+	} else if r.Params != nil {
 		q := reqURL.Query()
-		for k, v := range params {
+		for k, v := range r.Params {
 			q.Add(k, fmt.Sprintf("%v", v))
 		}
 		reqURL.RawQuery = q.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), reqBody)
+	req, err := http.NewRequestWithContext(ctx, r.Method, reqURL.String(), reqBody)
 	if err != nil {
-		return core.ErrFailedToMakeRequest.WithArgs(err, reqURL.String())
+		return nil, core.ErrFailedToMakeRequest.WithArgs(err, reqURL.String())
 	}
 
+	maps.Copy(req.Header, r.Header)
 	req.Header.Set("Accept", "application/json")
 	if reqBody != nil {
 		req.Header.Set("Content-Type", "application/json")
@@ -220,28 +336,60 @@ func (c *Client) do(ctx context.Context, method, endpoint string, params map[str
 
 	resp, err := c.doRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return core.ErrFailedToReadResponse.WithArgs(err, string(bodyBytes))
+		return nil, core.ErrFailedToReadResponse.WithArgs(err, string(bodyBytes))
 	}
+	response := &middleware.Response{StatusCode: resp.StatusCode, Header: resp.Header}
 
 	// Parse response as JSON first; if it fails and the expected result is a string, fall back to raw string.
-	if result != nil && len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, result); err != nil {
+	if r.Result != nil && len(bodyBytes) > 0 {
+		if err := json.Unmarshal(bodyBytes, r.Result); err != nil {
 			// If JSON unmarshaling fails but the caller expects a string, return the raw body.
-			if strPtr, ok := result.(*string); ok {
+			if strPtr, ok := r.Result.(*string); ok {
 				*strPtr = string(bodyBytes)
-				return nil
+				return response, nil
 			}
-			return core.ErrFailedToUnmarshalResponse.WithArgs(err, string(bodyBytes))
+			return nil, core.ErrFailedToUnmarshalResponse.WithArgs(err, string(bodyBytes))
 		}
 	}
 
-	return nil
+	return response, nil
+}
+
+// doMiddlewares sends a raw request through the middlewares, which see neither
+// params nor result.
+// The caller is responsible for closing the response body when finished reading it.
+func (c *Client) doMiddlewares(req *http.Request, endpoint string, accepted ...int) (*http.Response, error) {
+	var resp *http.Response
+	send := func(ctx context.Context, r *middleware.Request) (*middleware.Response, error) {
+		req = req.WithContext(ctx)
+		maps.Copy(req.Header, r.Header)
+		var err error
+		resp, err = c.doRequest(req, accepted...)
+		if err != nil {
+			return nil, err
+		}
+		return &middleware.Response{StatusCode: resp.StatusCode, Header: resp.Header}, nil
+	}
+
+	_, err := middleware.Chain(send, c.middlewares...)(req.Context(), &middleware.Request{
+		Method:   req.Method,
+		Endpoint: endpoint,
+		Header:   make(http.Header),
+	})
+	if err != nil {
+		// A middleware failed after the request was sent.
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return nil, err
+	}
+	return resp, nil
 }
 
 // doRaw performs a raw HTTP request and returns the response.
@@ -264,7 +412,7 @@ func (c *Client) doRaw(ctx context.Context, method, endpoint string,
 		req.ContentLength = contentLength[0]
 	}
 
-	return c.doRequest(req)
+	return c.doMiddlewares(req, endpoint)
 }
 
 func (c *Client) get(ctx context.Context, endpoint string, params map[string]any, result any) error {
@@ -405,6 +553,85 @@ func RawGet(ctx context.Context, c *Client, endpoint string) (*http.Response, er
 	return c.doRaw(ctx, "GET", endpoint, nil, "")
 }
 
+// RawGetRange performs a raw GET request for the byte range [offset, offset+length) of the resource.
+// The caller is responsible for closing the response body when finished reading it.
+// An error is returned if the server ignores the Range header and sends the whole content.
+func RawGetRange(ctx context.Context, c *Client, endpoint string, offset, length int64) (*http.Response, error) {
+	if offset < 0 || length <= 0 {
+		return nil, fmt.Errorf("invalid range: offset %d, length %d", offset, length)
+	}
+
+	reqURL := c.buildURL(endpoint)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, core.ErrFailedToMakeRequest.WithArgs(err, reqURL.String())
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	resp, err := c.doMiddlewares(req, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("server does not support range requests on %s: %s", endpoint, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// StatusResumeIncomplete is returned by servers supporting resumable uploads
+// when only part of the content has been received.
+const StatusResumeIncomplete = http.StatusPermanentRedirect
+
+// RawPutRange uploads the byte range [offset, offset+length) of a content of
+// total bytes, using a Content-Range header. A zero length sends an empty
+// "bytes */total" request, used to query the upload state.
+// Both 2xx and StatusResumeIncomplete responses are returned without error;
+// on the latter, ReceivedBytes reports how much of the content the server has.
+// The caller is responsible for closing the response body when finished reading it.
+func RawPutRange(ctx context.Context, c *Client, endpoint string,
+	body io.Reader, contentType string, offset, length, total int64) (*http.Response, error) {
+	if offset < 0 || length < 0 || offset+length > total {
+		return nil, fmt.Errorf("invalid range: offset %d, length %d, total %d", offset, length, total)
+	}
+	if length == 0 {
+		body = http.NoBody
+	}
+
+	reqURL := c.buildURL(endpoint)
+	req, err := http.NewRequestWithContext(ctx, "PUT", reqURL.String(), body)
+	if err != nil {
+		return nil, core.ErrFailedToMakeRequest.WithArgs(err, reqURL.String())
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.ContentLength = length
+	if length == 0 {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", total))
+	} else {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, total))
+	}
+
+	return c.doMiddlewares(req, endpoint, StatusResumeIncomplete)
+}
+
+// ReceivedBytes parses the Range header of a StatusResumeIncomplete response
+// ("bytes=0-N") and returns the number of bytes the server has received.
+func ReceivedBytes(resp *http.Response) (int64, error) {
+	header := resp.Header.Get("Range")
+	if header == "" {
+		return 0, nil
+	}
+
+	var first, last int64
+	if _, err := fmt.Sscanf(header, "bytes=%d-%d", &first, &last); err != nil || first != 0 || last < first {
+		return 0, fmt.Errorf("invalid Range header %q", header)
+	}
+	return last + 1, nil
+}
+
 func RawPut(ctx context.Context, c *Client, endpoint string,
 	body io.Reader, contentType string, contentLength ...int64) (*http.Response, error) {
 	return c.doRaw(ctx, "PUT", endpoint, body, contentType, contentLength...)
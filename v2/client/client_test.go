@@ -1,17 +1,24 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/config"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/middleware"
 )
 
 var ctx = context.Background()
@@ -211,3 +218,278 @@ func TestTypedGet(t *testing.T) {
 	assert.Equal(t, "test-item", result.Name)
 	assert.Equal(t, 123, result.Value)
 }
+
+func TestRawGetRange(t *testing.T) {
+	content := []byte("0123456789abcdef")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case restPath + "/ranged":
+			http.ServeContent(w, r, "disk.raw", time.Time{}, bytes.NewReader(content))
+		case restPath + "/full":
+			_, _ = w.Write(content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		HttpClient: http.DefaultClient,
+		BaseURL:    &url.URL{Scheme: httpScheme, Host: server.URL[7:], Path: restPath},
+		AuthToken:  testTokenValue,
+	}
+
+	t.Run("returns the requested range", func(t *testing.T) {
+		resp, err := RawGetRange(ctx, client, "ranged", 4, 6)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "456789", string(data))
+	})
+
+	t.Run("fails when the server ignores the range", func(t *testing.T) {
+		_, err := RawGetRange(ctx, client, "full", 4, 6)
+		assert.ErrorContains(t, err, "does not support range requests")
+	})
+
+	t.Run("rejects an invalid range", func(t *testing.T) {
+		_, err := RawGetRange(ctx, client, "ranged", 0, 0)
+		assert.ErrorContains(t, err, "invalid range")
+	})
+}
+
+func TestRawPutRange(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, body...)
+
+		if r.Header.Get("Content-Range") == "bytes 6-9/10" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(received)-1))
+		w.WriteHeader(StatusResumeIncomplete)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		HttpClient: http.DefaultClient,
+		BaseURL:    &url.URL{Scheme: httpScheme, Host: server.URL[7:], Path: restPath},
+		AuthToken:  testTokenValue,
+	}
+
+	t.Run("partial upload reports the received bytes", func(t *testing.T) {
+		resp, err := RawPutRange(ctx, client, "upload", strings.NewReader("012345"), "", 0, 6, 10)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, StatusResumeIncomplete, resp.StatusCode)
+		n, err := ReceivedBytes(resp)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), n)
+	})
+
+	t.Run("last chunk completes the upload", func(t *testing.T) {
+		resp, err := RawPutRange(ctx, client, "upload", strings.NewReader("6789"), "", 6, 4, 10)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "0123456789", string(received))
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := RawPutRange(ctx, client, "upload", strings.NewReader(""), "", 8, 4, 10)
+		assert.ErrorContains(t, err, "invalid range")
+	})
+}
+
+// expiringXO is a REST API whose token expires when expire is called. A login
+// returns a new token.
+type expiringXO struct {
+	mu     sync.Mutex
+	token  string
+	logins atomic.Int32
+	bodies []string
+}
+
+func (x *expiringXO) expire() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.token = ""
+}
+
+func (x *expiringXO) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if r.URL.Path == "/auth/login" {
+		x.token = fmt.Sprintf("token-%d", x.logins.Add(1))
+		http.SetCookie(w, &http.Cookie{Name: authCookieName, Value: x.token})
+		return
+	}
+	cookie, err := r.Cookie(authCookieName)
+	if err != nil || x.token == "" || cookie.Value != x.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	x.bodies = append(x.bodies, string(body))
+	_, _ = w.Write([]byte(`{"result":"success"}`))
+}
+
+func TestReauthenticate(t *testing.T) {
+	xo := &expiringXO{}
+	server := httptest.NewServer(xo)
+	defer server.Close()
+
+	client, err := New(&config.Config{Url: server.URL, Username: "testuser", Password: "testpass"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, xo.logins.Load())
+
+	t.Run("request sent again with a new token", func(t *testing.T) {
+		xo.expire()
+		var result map[string]any
+		err := client.post(ctx, "test", map[string]any{"key": "value"}, &result)
+		require.NoError(t, err)
+		assert.Equal(t, "success", result["result"])
+		assert.EqualValues(t, 2, xo.logins.Load())
+		assert.Equal(t, []string{`{"key":"value"}`}, xo.bodies)
+	})
+
+	t.Run("concurrent requests authenticate once", func(t *testing.T) {
+		xo.expire()
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, client.get(ctx, "test", nil, nil))
+			}()
+		}
+		wg.Wait()
+		assert.EqualValues(t, 3, xo.logins.Load())
+	})
+
+	t.Run("stream not sent again", func(t *testing.T) {
+		xo.expire()
+		body := io.MultiReader(strings.NewReader("data"))
+		_, err := RawPut(ctx, client, "test", body, "application/octet-stream")
+		assert.ErrorContains(t, err, "401")
+		assert.EqualValues(t, 3, xo.logins.Load())
+	})
+}
+
+func TestReauthenticateCredentials(t *testing.T) {
+	var tokens atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(authCookieName)
+		// Only the last token fetched is valid.
+		if err != nil || cookie.Value != fmt.Sprintf("secret-%d", tokens.Load()) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := New(&config.Config{
+		Url: server.URL,
+		Credentials: func(context.Context) (config.Credentials, error) {
+			return config.Credentials{Token: fmt.Sprintf("secret-%d", tokens.Add(1))}, nil
+		},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, "secret-1", client.AuthToken)
+
+	// The token was rotated in the secret manager.
+	tokens.Add(1)
+	require.NoError(t, client.get(ctx, "test", nil, nil))
+	assert.EqualValues(t, "secret-3", client.AuthToken)
+}
+
+func TestFixedTokenRejected(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := New(&config.Config{Url: server.URL, Token: testToken})
+	require.NoError(t, err)
+
+	err = client.get(ctx, "test", nil, nil)
+	assert.ErrorContains(t, err, "401")
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestMiddlewares(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	var requests []middleware.Request
+	audit := func(next middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			resp, err := next(ctx, req)
+			// The result is decoded once the next handler returned.
+			requests = append(requests, *req)
+			return resp, err
+		}
+	}
+	var transported []string
+	transport := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			transported = append(transported, req.URL.Path)
+			return next.RoundTrip(req)
+		})
+	}
+
+	client, err := New(&config.Config{
+		Url:         server.URL,
+		Token:       testToken,
+		Middlewares: []middleware.Middleware{middleware.UserAgent("test-agent"), middleware.RequestID(), audit},
+		Transports:  []middleware.Transport{transport},
+	})
+	require.NoError(t, err)
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	err = client.post(ctx, "vms", map[string]any{"name_label": "web"}, &result)
+	require.NoError(t, err)
+	resp, err := RawGet(ctx, client, "vdis/1.raw")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "POST", requests[0].Method)
+	assert.Equal(t, "vms", requests[0].Endpoint)
+	assert.Equal(t, map[string]any{"name_label": "web"}, requests[0].Params)
+	assert.Equal(t, "123", requests[0].Result.(*struct {
+		ID string `json:"id"`
+	}).ID)
+	assert.Equal(t, "vdis/1.raw", requests[1].Endpoint)
+	assert.Nil(t, requests[1].Params)
+
+	require.Len(t, headers, 2)
+	for _, header := range headers {
+		assert.Equal(t, "test-agent", header.Get("User-Agent"))
+		assert.NotEmpty(t, header.Get(middleware.RequestIDHeader))
+	}
+	assert.Equal(t, []string{restPath + "/vms", restPath + "/vdis/1.raw"}, transported)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -153,6 +153,27 @@ func TestSRReclaimSpace(t *testing.T) {
 	})
 }
 
+func TestSRHealth(t *testing.T) {
+	t.Parallel()
+	ctx, client, _ := SetupTestContext(t)
+
+	t.Run("HealthValidSR", func(t *testing.T) {
+		t.Parallel()
+		health, err := client.SR().Health(ctx, intTests.testSR.ID)
+		require.NoError(t, err, "Health should not return an error")
+		require.NotNil(t, health)
+		assert.Equal(t, intTests.testSR.ID, health.SR, "health report should target the requested SR")
+		assert.Len(t, health.PBDs, len(intTests.testSR.PBDs), "health report should list every PBD of the SR")
+		assert.GreaterOrEqual(t, health.PhysicalUsageRatio, 0.0)
+	})
+
+	t.Run("HealthInvalidSR", func(t *testing.T) {
+		t.Parallel()
+		_, err := client.SR().Health(ctx, uuid.FromStringOrNil("123e4567-e89b-12d3-a456-426655440000"))
+		require.Error(t, err, "expected error when inspecting a non-existent SR")
+	})
+}
+
 func srTagExists(ctx context.Context, client library.Library, srID uuid.UUID, tag string) bool {
 	sr, err := client.SR().Get(ctx, srID)
 	if err != nil {
@@ -5,22 +5,42 @@ about the v2 design choices, how to add new services, etc.
 package v2
 
 import (
+	"context"
 	"sync"
 
 	"github.com/subosito/gotenv"
 	v1 "github.com/vatesfr/xenorchestra-go-sdk/client"
 	"github.com/vatesfr/xenorchestra-go-sdk/internal/common/logger"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/config"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/payloads"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/acl"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/audit"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/auth"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/backup"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/cloudconfig"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/event"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/gpugroup"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/group"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/host"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/informer"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/jsonrpc"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/library"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/message"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/network"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/pbd"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/pci"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/pgpu"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/pool"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/remote"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/resourceset"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/schedule"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/server"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/sr"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/task"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/user"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/vbd"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/vdi"
+	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/vgputype"
 	"github.com/vatesfr/xenorchestra-go-sdk/pkg/services/vm"
 	"github.com/vatesfr/xenorchestra-go-sdk/v2/client"
 	"go.uber.org/zap"
@@ -36,6 +56,23 @@ type XOClient struct {
 	pbdService     library.PBD
 	srService      library.SR
 	networkService library.Network
+	cloudConfigSvc library.CloudConfig
+	userService    library.User
+	groupService   library.Group
+	authService    library.Auth
+	aclService     library.ACL
+	resourceSetSvc library.ResourceSet
+	backupService  library.Backup
+	remoteService  library.Remote
+	scheduleSvc    library.Schedule
+	messageService library.Message
+	auditService   library.Audit
+	serverService  library.Server
+	pciService     library.PCI
+	pgpuService    library.PGPU
+	gpuGroupSvc    library.GPUGroup
+	vgpuTypeSvc    library.VGPUType
+	eventService   library.Event
 	// We can provide access to the v1 client directly, allowing users to:
 	// 1. Access v1 functionality without initializing a separate client
 	// 2. Use v2 features while maintaining backward compatibility
@@ -44,10 +81,11 @@ type XOClient struct {
 	// JSON-RPC calls are made. This allows v2 client creation without requiring
 	// an active XOA connection at initialization time.
 	// This also avoid to open a websocket connection if the user only uses REST API.
-	v1InitOnce sync.Once // Mutex to initialize v1 client once
-	v1InitErr  error
-	v1Config   v1.Config
-	v1Client   v1.XOClient
+	// v1InitMu guards v1Client, whose creation is tried again on the next
+	// access when it fails.
+	v1InitMu sync.Mutex
+	v1Config v1.Config
+	v1Client *v1.Client
 	// Internal JSON-RPC service, we won't expose it to the user.
 	// The purpose of this service is to provide a common interface for the
 	// JSON-RPC calls, and to handle the errors and logging. When the REST
@@ -88,57 +126,102 @@ func New(config *config.Config) (library.Library, error) {
 		Token:              config.Token,
 		InsecureSkipVerify: config.InsecureSkipVerify,
 	}
+	// The websockets of the v1 client and of the event subscriptions fetch
+	// the credentials each time they sign in, including when they reconnect.
+	if config.Credentials != nil {
+		v1Config.Credentials = func(ctx context.Context) (v1.Credentials, error) {
+			credentials, err := config.Credentials(ctx)
+			return v1.Credentials(credentials), err
+		}
+	}
 
 	log, err := logger.New(config.Development, config.LogOutputPaths, config.LogErrorOutputPaths)
 	if err != nil {
 		return nil, err
 	}
 
+	xoClient := &XOClient{
+		v1Config: v1Config,
+		log:      log,
+	}
+
+	// Create a lazy JSONRPC service that will trigger v1Client creation on first call
+	xoClient.jsonrpcSvc = jsonrpc.NewLazy(xoClient.initV1Client, log)
+
 	taskService := task.New(client, log)
-	poolService := pool.New(client, taskService, log)
+	poolService := pool.New(client, taskService, xoClient.jsonrpcSvc, log)
 	hostService := host.New(client, log)
 	vdiService := vdi.New(client, taskService, log)
 	vbdService := vbd.New(client, taskService, log)
+	pciService := pci.New(client, xoClient.jsonrpcSvc, log)
+	vmService := vm.New(client, taskService, poolService, vbdService, vdiService, pciService,
+		xoClient.jsonrpcSvc, log)
 	pbdService := pbd.New(client, taskService, log)
-	srService := sr.New(client, taskService, log)
+	srService := sr.New(client, taskService, hostService, pbdService, vdiService, vbdService, vmService,
+		xoClient.jsonrpcSvc, log)
 	networkService := network.New(client, taskService, poolService, log)
+	cloudConfigSvc := cloudconfig.New(xoClient.jsonrpcSvc, log)
+	userService := user.New(xoClient.jsonrpcSvc, log)
+	groupService := group.New(xoClient.jsonrpcSvc, log)
+	authService := auth.New(xoClient.jsonrpcSvc, log)
+	aclService := acl.New(userService, groupService, xoClient.jsonrpcSvc, log)
+	resourceSetSvc := resourceset.New(client, vbdService, vdiService, xoClient.jsonrpcSvc, log)
+	backupService := backup.New(vmService, taskService, xoClient.jsonrpcSvc, log)
+	remoteService := remote.New(xoClient.jsonrpcSvc, log)
+	scheduleSvc := schedule.New(xoClient.jsonrpcSvc, log)
+	messageService := message.New(client, xoClient.jsonrpcSvc, log)
+	auditService := audit.New(xoClient.jsonrpcSvc, log)
+	serverService := server.New(xoClient.jsonrpcSvc, log)
+	pgpuService := pgpu.New(client, log)
+	gpuGroupSvc := gpugroup.New(client, log)
+	vgpuTypeSvc := vgputype.New(client, log)
+	eventService := event.New(v1Config, log)
 
-	xoClient := &XOClient{
-		vmService:      vm.New(client, taskService, poolService, log),
-		taskService:    taskService,
-		poolService:    poolService,
-		hostService:    hostService,
-		vdiService:     vdiService,
-		vbdService:     vbdService,
-		pbdService:     pbdService,
-		srService:      srService,
-		networkService: networkService,
-		v1Config:       v1Config,
-		log:            log,
-	}
-
-	// Create a lazy JSONRPC service that will trigger v1Client creation on first call
-	xoClient.jsonrpcSvc = jsonrpc.NewLazy(xoClient.initV1Client, log)
+	xoClient.vmService = vmService
+	xoClient.taskService = taskService
+	xoClient.poolService = poolService
+	xoClient.hostService = hostService
+	xoClient.vdiService = vdiService
+	xoClient.vbdService = vbdService
+	xoClient.pbdService = pbdService
+	xoClient.srService = srService
+	xoClient.networkService = networkService
+	xoClient.cloudConfigSvc = cloudConfigSvc
+	xoClient.userService = userService
+	xoClient.groupService = groupService
+	xoClient.authService = authService
+	xoClient.aclService = aclService
+	xoClient.resourceSetSvc = resourceSetSvc
+	xoClient.backupService = backupService
+	xoClient.remoteService = remoteService
+	xoClient.scheduleSvc = scheduleSvc
+	xoClient.messageService = messageService
+	xoClient.auditService = auditService
+	xoClient.serverService = serverService
+	xoClient.pciService = pciService
+	xoClient.pgpuService = pgpuService
+	xoClient.gpuGroupSvc = gpuGroupSvc
+	xoClient.vgpuTypeSvc = vgpuTypeSvc
+	xoClient.eventService = eventService
 
 	return xoClient, nil
 }
 
 // initV1Client initializes the v1 client lazily and thread-safely.
-// It is called at most once due to sync.Once.
+// A failed initialization is tried again by the next call.
 func (c *XOClient) initV1Client() (*v1.Client, error) {
-	c.v1InitOnce.Do(func() {
-		c.v1Client, c.v1InitErr = v1.NewClient(c.v1Config)
-	})
-	if c.v1InitErr != nil {
-		if c.v1InitErr != nil {
-			c.log.Error("Failed to initialize v1 client", zap.Error(c.v1InitErr))
-		}
-		return nil, c.v1InitErr
+	c.v1InitMu.Lock()
+	defer c.v1InitMu.Unlock()
+	if c.v1Client != nil {
+		return c.v1Client, nil
 	}
-	if c.v1Client == nil {
-		return nil, nil
+	client, err := v1.NewClient(c.v1Config)
+	if err != nil {
+		c.log.Error("Failed to initialize v1 client", zap.Error(err))
+		return nil, err
 	}
-	return c.v1Client.(*v1.Client), nil
+	c.v1Client = client.(*v1.Client)
+	return c.v1Client, nil
 }
 
 func (c *XOClient) VM() library.VM {
@@ -177,7 +260,85 @@ func (c *XOClient) Network() library.Network {
 	return c.networkService
 }
 
+func (c *XOClient) CloudConfig() library.CloudConfig {
+	return c.cloudConfigSvc
+}
+
+func (c *XOClient) User() library.User {
+	return c.userService
+}
+
+func (c *XOClient) Group() library.Group {
+	return c.groupService
+}
+
+func (c *XOClient) Auth() library.Auth {
+	return c.authService
+}
+
+func (c *XOClient) ACL() library.ACL {
+	return c.aclService
+}
+
+func (c *XOClient) ResourceSet() library.ResourceSet {
+	return c.resourceSetSvc
+}
+
+func (c *XOClient) Backup() library.Backup {
+	return c.backupService
+}
+
+func (c *XOClient) Remote() library.Remote {
+	return c.remoteService
+}
+
+func (c *XOClient) Schedule() library.Schedule {
+	return c.scheduleSvc
+}
+
+func (c *XOClient) Message() library.Message {
+	return c.messageService
+}
+
+func (c *XOClient) Audit() library.Audit {
+	return c.auditService
+}
+
+func (c *XOClient) Server() library.Server {
+	return c.serverService
+}
+
+func (c *XOClient) PCI() library.PCI {
+	return c.pciService
+}
+
+func (c *XOClient) PGPU() library.PGPU {
+	return c.pgpuService
+}
+
+func (c *XOClient) GPUGroup() library.GPUGroup {
+	return c.gpuGroupSvc
+}
+
+func (c *XOClient) VGPUType() library.VGPUType {
+	return c.vgpuTypeSvc
+}
+
+func (c *XOClient) Subscribe(
+	ctx context.Context,
+	filter payloads.ObjectEventFilter,
+) (<-chan payloads.ObjectEvent, error) {
+	return c.eventService.Subscribe(ctx, filter)
+}
+
+func (c *XOClient) NewInformer(options payloads.InformerOptions) (library.Informer, error) {
+	return informer.New(c.eventService, c.vmService, c.srService, c.hostService, c.poolService, options, c.log)
+}
+
 func (c *XOClient) V1Client() v1.XOClient {
-	_, _ = c.initV1Client()
-	return c.v1Client
+	client, err := c.initV1Client()
+	if err != nil {
+		return nil
+	}
+	return client
 }
@@ -53,5 +53,8 @@ func TestV1ClientLazyInitialization(t *testing.T) {
 	// The call will return nil due to initialization error, but no panic should occur
 	// In a real scenario with a valid XOA, this would return the initialized client
 	assert.Nil(t, v1Client, "v1Client should be nil due to connection error")
-	assert.NotNil(t, xoClient.v1InitErr, "v1Err should contain the connection error")
+	// The failed initialization is not kept, the next access tries again
+	_, err = xoClient.initV1Client()
+	assert.Error(t, err, "the initialization should be tried again and fail with the connection error")
+	assert.Nil(t, xoClient.v1Client, "v1Client should still not be initialized")
 }